@@ -0,0 +1,24 @@
+package fimage
+
+import "testing"
+
+func TestAccessorsAreNilSafe(t *testing.T) {
+	t.Parallel()
+
+	var file *File
+	if got := file.GetThumbnailURL(); got != "" {
+		t.Fatalf("nil File GetThumbnailURL() = %q", got)
+	}
+	if got := (&File{}).GetLocation(); got != nil {
+		t.Fatalf("GetLocation() = %v, want nil", got)
+	}
+
+	views := int64(5)
+	share := &ShareLink{MaxViews: &views}
+	if got := share.GetMaxViews(); got != 5 {
+		t.Fatalf("GetMaxViews() = %d, want 5", got)
+	}
+	if got := (&ShareLink{}).GetExpiresAt(); !got.IsZero() {
+		t.Fatalf("GetExpiresAt() = %v, want zero", got)
+	}
+}
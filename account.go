@@ -0,0 +1,390 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AccountService handles account-level operations.
+type AccountService struct {
+	client *Client
+}
+
+// UploadEmail is the account's upload-by-email address and its settings.
+type UploadEmail struct {
+	// Address is the secret email address that accepts image attachments.
+	Address string `json:"address"`
+
+	// Enabled indicates if emailed images are accepted.
+	Enabled bool `json:"enabled"`
+
+	// AlbumID is the album emailed images are filed into (if any).
+	AlbumID *int64 `json:"album_id,omitempty"`
+
+	// TagIDs are applied to every emailed image.
+	TagIDs []int64 `json:"tag_ids,omitempty"`
+
+	// UseSubjectAsDescription stores the email subject as the file description.
+	UseSubjectAsDescription bool `json:"use_subject_as_description"`
+
+	// AllowedSenders restricts which sender addresses are accepted.
+	// An empty list accepts mail from anyone who knows the address.
+	AllowedSenders []string `json:"allowed_senders,omitempty"`
+
+	// RotatedAt is when the address was last rotated.
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+}
+
+// UpdateUploadEmailOptions contains options for updating upload-by-email
+// settings. Nil fields are left unchanged.
+type UpdateUploadEmailOptions struct {
+	// Enabled sets whether emailed images are accepted.
+	Enabled *bool
+
+	// AlbumID sets the target album. Point to 0 to file images without an album.
+	AlbumID *int64
+
+	// TagIDs replaces the tags applied to emailed images.
+	TagIDs *[]int64
+
+	// UseSubjectAsDescription sets whether the subject becomes the description.
+	UseSubjectAsDescription *bool
+
+	// AllowedSenders replaces the accepted sender addresses.
+	AllowedSenders *[]string
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UpdateUploadEmailOptions) Validate() error {
+	var v validator
+	v.check(opts.AlbumID == nil || *opts.AlbumID >= 0, "AlbumID", "must not be negative")
+	if opts.AllowedSenders != nil {
+		for i, sender := range *opts.AllowedSenders {
+			v.check(isEmail(sender), fmt.Sprintf("AllowedSenders[%d]", i), "is not an email address: %q", sender)
+		}
+	}
+	return v.err()
+}
+
+// GetUploadEmail returns the upload-by-email address and settings.
+//
+// Example:
+//
+//	email, err := client.Account.GetUploadEmail(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Send images to %s\n", email.Address)
+func (s *AccountService) GetUploadEmail(ctx context.Context, callOpts ...CallOption) (*UploadEmail, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	var email UploadEmail
+	if err := s.client.request(ctx, http.MethodGet, "/api/account/upload-email", nil, &email); err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+// RotateUploadEmail replaces the upload-by-email address with a new secret
+// address. The old address stops accepting mail immediately.
+//
+// Example:
+//
+//	email, err := client.Account.RotateUploadEmail(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("New address: %s\n", email.Address)
+func (s *AccountService) RotateUploadEmail(ctx context.Context, callOpts ...CallOption) (*UploadEmail, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	var email UploadEmail
+	if err := s.client.request(ctx, http.MethodPost, "/api/account/upload-email/rotate", nil, &email); err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+// UpdateUploadEmail updates the upload-by-email settings.
+//
+// Example:
+//
+//	albumID := int64(123)
+//	email, err := client.Account.UpdateUploadEmail(ctx, &fimage.UpdateUploadEmailOptions{
+//	    AlbumID: &albumID,
+//	})
+func (s *AccountService) UpdateUploadEmail(ctx context.Context, opts *UpdateUploadEmailOptions, callOpts ...CallOption) (*UploadEmail, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	if opts == nil {
+		opts = &UpdateUploadEmailOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		Enabled                 *bool     `json:"enabled,omitempty"`
+		AlbumID                 *int64    `json:"album_id,omitempty"`
+		TagIDs                  *[]int64  `json:"tag_ids,omitempty"`
+		UseSubjectAsDescription *bool     `json:"use_subject_as_description,omitempty"`
+		AllowedSenders          *[]string `json:"allowed_senders,omitempty"`
+	}{
+		Enabled:                 opts.Enabled,
+		AlbumID:                 opts.AlbumID,
+		TagIDs:                  opts.TagIDs,
+		UseSubjectAsDescription: opts.UseSubjectAsDescription,
+		AllowedSenders:          opts.AllowedSenders,
+	}
+
+	var email UploadEmail
+	if err := s.client.request(ctx, http.MethodPut, "/api/account/upload-email", req, &email); err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+// BandwidthBudget is the monthly bandwidth budget of the account.
+type BandwidthBudget struct {
+	// BytesPerMonth is the budget in bytes per billing month, or 0 when no
+	// budget is set.
+	BytesPerMonth int64 `json:"bytes_per_month"`
+
+	// WebhookURL receives quota.warning events as usage approaches and
+	// crosses the budget (see the webhooks package).
+	WebhookURL string `json:"webhook_url,omitempty"`
+
+	// UpdatedAt is when the budget was last changed.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// BandwidthUsage is the bandwidth served in the current billing month.
+type BandwidthUsage struct {
+	// PeriodStart is the start of the billing month.
+	PeriodStart time.Time `json:"period_start"`
+
+	// PeriodEnd is the end of the billing month.
+	PeriodEnd time.Time `json:"period_end"`
+
+	// UsedBytes is the number of bytes served so far this month.
+	UsedBytes int64 `json:"used_bytes"`
+
+	// ProjectedBytes is the usage projected to the end of the month at the
+	// current rate.
+	ProjectedBytes int64 `json:"projected_bytes"`
+
+	// BudgetBytes is the monthly budget, or 0 when no budget is set.
+	BudgetBytes int64 `json:"budget_bytes"`
+
+	// Percent is UsedBytes as a percentage of BudgetBytes, or 0 when no
+	// budget is set.
+	Percent float64 `json:"percent"`
+}
+
+// OverBudget reports whether usage has reached the budget.
+func (u *BandwidthUsage) OverBudget() bool {
+	return u.BudgetBytes > 0 && u.UsedBytes >= u.BudgetBytes
+}
+
+// SetBandwidthBudget sets the monthly bandwidth budget. When webhookURL is
+// not empty, it receives quota.warning events as usage approaches and
+// crosses the budget. A budget of 0 removes it.
+//
+// Example:
+//
+//	budget, err := client.Account.SetBandwidthBudget(ctx, 500<<30, "https://example.com/hooks/fimage")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Budget: %d GiB/month\n", budget.BytesPerMonth>>30)
+func (s *AccountService) SetBandwidthBudget(ctx context.Context, bytesPerMonth int64, webhookURL string, callOpts ...CallOption) (*BandwidthBudget, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	if bytesPerMonth < 0 {
+		return nil, fmt.Errorf("bandwidth budget must not be negative, got %d", bytesPerMonth)
+	}
+	if webhookURL != "" {
+		u, err := url.Parse(webhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("invalid webhook URL %q", webhookURL)
+		}
+	}
+
+	req := struct {
+		BytesPerMonth int64  `json:"bytes_per_month"`
+		WebhookURL    string `json:"webhook_url"`
+	}{
+		BytesPerMonth: bytesPerMonth,
+		WebhookURL:    webhookURL,
+	}
+
+	var budget BandwidthBudget
+	if err := s.client.request(ctx, http.MethodPut, "/api/account/bandwidth/budget", req, &budget); err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+// GetBandwidthUsage returns the bandwidth served in the current billing
+// month, with the projected total and the budget.
+//
+// Example:
+//
+//	usage, err := client.Account.GetBandwidthUsage(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if usage.OverBudget() {
+//	    log.Printf("over budget: %d of %d bytes", usage.UsedBytes, usage.BudgetBytes)
+//	}
+func (s *AccountService) GetBandwidthUsage(ctx context.Context, callOpts ...CallOption) (*BandwidthUsage, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	var usage BandwidthUsage
+	if err := s.client.request(ctx, http.MethodGet, "/api/account/bandwidth", nil, &usage); err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// Plan describes the account's subscription plan.
+type Plan struct {
+	// Name is the plan name (e.g. "free", "pro").
+	Name string `json:"name"`
+
+	// StorageQuota is the storage limit in bytes, or 0 when unlimited.
+	StorageQuota int64 `json:"storage_quota"`
+
+	// BandwidthQuota is the monthly bandwidth limit in bytes, or 0 when
+	// unlimited.
+	BandwidthQuota int64 `json:"bandwidth_quota"`
+
+	// MaxFileSize is the largest accepted upload in bytes.
+	MaxFileSize int64 `json:"max_file_size"`
+
+	// RenewsAt is when the plan renews, if it is a paid plan.
+	RenewsAt *time.Time `json:"renews_at,omitempty"`
+}
+
+// Usage is the account's resource usage against its plan.
+type Usage struct {
+	// StorageUsed is the number of bytes stored, including the trash.
+	StorageUsed int64 `json:"storage_used"`
+
+	// StorageQuota is the storage limit in bytes, or 0 when unlimited.
+	StorageQuota int64 `json:"storage_quota"`
+
+	// FileCount is the number of stored files, including the trash.
+	FileCount int64 `json:"file_count"`
+
+	// BandwidthUsed is the number of bytes served in the current period.
+	BandwidthUsed int64 `json:"bandwidth_used"`
+
+	// PeriodStart is the start of the current billing period.
+	PeriodStart time.Time `json:"period_start"`
+
+	// PeriodEnd is the end of the current billing period.
+	PeriodEnd time.Time `json:"period_end"`
+
+	// Plan is the account's plan.
+	Plan Plan `json:"plan"`
+}
+
+// StorageRemaining returns the number of bytes that can still be stored,
+// or -1 when storage is unlimited.
+func (u *Usage) StorageRemaining() int64 {
+	if u.StorageQuota <= 0 {
+		return -1
+	}
+	if remaining := u.StorageQuota - u.StorageUsed; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// CanStore reports whether an upload of size bytes fits in the remaining
+// storage and the plan's file size limit, so callers can warn before an
+// upload fails with ErrQuotaExceeded or ErrFileTooLarge.
+func (u *Usage) CanStore(size int64) bool {
+	if u.Plan.MaxFileSize > 0 && size > u.Plan.MaxFileSize {
+		return false
+	}
+	remaining := u.StorageRemaining()
+	return remaining < 0 || size <= remaining
+}
+
+// Profile is the authenticated user's profile.
+type Profile struct {
+	// ID is the user ID.
+	ID int64 `json:"id"`
+
+	// Username is the user's login name.
+	Username string `json:"username"`
+
+	// Email is the user's email address.
+	Email string `json:"email"`
+
+	// DisplayName is the user's display name.
+	DisplayName string `json:"display_name"`
+
+	// AvatarURL is the URL of the user's avatar, if set.
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// Plan is the name of the user's plan.
+	Plan string `json:"plan"`
+
+	// CreatedAt is when the account was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetUsage returns storage, file count, and bandwidth usage together with
+// the plan limits.
+//
+// Example:
+//
+//	usage, err := client.Account.GetUsage(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !usage.CanStore(info.Size()) {
+//	    fmt.Println("Not enough storage left for this file")
+//	}
+//	fmt.Printf("%d of %d bytes used (%s plan)\n", usage.StorageUsed, usage.StorageQuota, usage.Plan.Name)
+func (s *AccountService) GetUsage(ctx context.Context, callOpts ...CallOption) (*Usage, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	var usage Usage
+	if err := s.client.request(ctx, http.MethodGet, "/api/account/usage", nil, &usage); err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// GetProfile returns the profile of the user the API token belongs to.
+//
+// Example:
+//
+//	profile, err := client.Account.GetProfile(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Signed in as %s (%s)\n", profile.Username, profile.Email)
+func (s *AccountService) GetProfile(ctx context.Context, callOpts ...CallOption) (*Profile, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAccount, callOpts)
+
+	var profile Profile
+	if err := s.client.request(ctx, http.MethodGet, "/api/account/profile", nil, &profile); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
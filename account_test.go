@@ -0,0 +1,180 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUsageCanStore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		usage     Usage
+		size      int64
+		remaining int64
+		want      bool
+	}{
+		{"unlimited", Usage{StorageUsed: 1 << 40}, 1 << 30, -1, true},
+		{"fits", Usage{StorageUsed: 60, StorageQuota: 100}, 40, 40, true},
+		{"too big for quota", Usage{StorageUsed: 60, StorageQuota: 100}, 41, 40, false},
+		{"over quota", Usage{StorageUsed: 120, StorageQuota: 100}, 1, 0, false},
+		{"max file size", Usage{Plan: Plan{MaxFileSize: 10}}, 11, -1, false},
+		{"within max file size", Usage{StorageQuota: 100, Plan: Plan{MaxFileSize: 10}}, 10, 100, true},
+	}
+	for _, tt := range tests {
+		if got := tt.usage.StorageRemaining(); got != tt.remaining {
+			t.Errorf("%s: StorageRemaining() = %d, want %d", tt.name, got, tt.remaining)
+		}
+		if got := tt.usage.CanStore(tt.size); got != tt.want {
+			t.Errorf("%s: CanStore(%d) = %v, want %v", tt.name, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestGetUsageAndProfile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/account/usage":
+			_, _ = w.Write([]byte(`{"storage_used":30,"storage_quota":100,"file_count":3,"plan":{"name":"pro","max_file_size":50}}`))
+		case "/api/account/profile":
+			_, _ = w.Write([]byte(`{"id":1,"username":"ada","plan":"pro","created_at":"2024-01-02T03:04:05Z"}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	usage, err := client.Account.GetUsage(context.Background())
+	if err != nil {
+		t.Fatalf("GetUsage returned error: %v", err)
+	}
+	if usage.StorageRemaining() != 70 || usage.Plan.Name != "pro" || usage.CanStore(60) {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	profile, err := client.Account.GetProfile(context.Background())
+	if err != nil {
+		t.Fatalf("GetProfile returned error: %v", err)
+	}
+	if profile.Username != "ada" || profile.CreatedAt.Year() != 2024 {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+}
+
+func TestSetBandwidthBudgetValidatesArguments(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPut || r.URL.Path != "/api/account/bandwidth/budget" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		if body["bytes_per_month"] != float64(1024) || body["webhook_url"] != "https://example.com/hook" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"bytes_per_month":1024,"webhook_url":"https://example.com/hook"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	invalid := []struct {
+		budget  int64
+		webhook string
+	}{
+		{-1, ""},
+		{1024, "ftp://example.com/hook"},
+		{1024, "example.com/hook"},
+		{1024, "https://"},
+	}
+	for _, tt := range invalid {
+		if _, err := client.Account.SetBandwidthBudget(context.Background(), tt.budget, tt.webhook); err == nil {
+			t.Errorf("SetBandwidthBudget(%d, %q): expected error", tt.budget, tt.webhook)
+		}
+	}
+	if requests != 0 {
+		t.Fatalf("invalid arguments reached the server %d times", requests)
+	}
+
+	budget, err := client.Account.SetBandwidthBudget(context.Background(), 1024, "https://example.com/hook")
+	if err != nil {
+		t.Fatalf("SetBandwidthBudget returned error: %v", err)
+	}
+	if budget.BytesPerMonth != 1024 {
+		t.Fatalf("unexpected budget: %+v", budget)
+	}
+}
+
+func TestUploadEmailRequests(t *testing.T) {
+	t.Parallel()
+
+	var updates []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/account/upload-email":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/account/upload-email/rotate":
+		case r.Method == http.MethodPut && r.URL.Path == "/api/account/upload-email":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			updates = append(updates, body)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"address":"in-7f3a@upload.example.com","enabled":true,"album_id":12,"allowed_senders":["ann@example.com"],"rotated_at":"2024-03-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	email, err := client.Account.GetUploadEmail(ctx)
+	if err != nil {
+		t.Fatalf("GetUploadEmail returned error: %v", err)
+	}
+	if email.Address != "in-7f3a@upload.example.com" || !email.Enabled || email.GetAlbumID() != 12 || email.RotatedAt == nil {
+		t.Fatalf("unexpected upload email: %+v", email)
+	}
+	if _, err := client.Account.RotateUploadEmail(ctx); err != nil {
+		t.Fatalf("RotateUploadEmail returned error: %v", err)
+	}
+
+	enabled := false
+	senders := []string{"ann@example.com"}
+	if _, err := client.Account.UpdateUploadEmail(ctx, &UpdateUploadEmailOptions{Enabled: &enabled, AllowedSenders: &senders}); err != nil {
+		t.Fatalf("UpdateUploadEmail returned error: %v", err)
+	}
+	if got, _ := json.Marshal(updates[0]); string(got) != `{"allowed_senders":["ann@example.com"],"enabled":false}` {
+		t.Fatalf("unexpected update body: %s", got)
+	}
+
+	albumID := int64(-1)
+	bad := []string{"ann@example.com", "not-an-address"}
+	_, err = client.Account.UpdateUploadEmail(ctx, &UpdateUploadEmailOptions{AlbumID: &albumID, AllowedSenders: &bad})
+	if got := invalidFields(t, err); got != "AlbumID,AllowedSenders[1]" {
+		t.Fatalf("invalid fields = %q", got)
+	}
+	if len(updates) != 1 {
+		t.Fatalf("expected invalid options to make no request, got %d updates", len(updates))
+	}
+}
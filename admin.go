@@ -0,0 +1,428 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdminService handles administrative operations on self-hosted instances.
+// It requires an API token with the admin scope.
+type AdminService struct {
+	client *Client
+}
+
+// ReindexState is the state of the search index rebuild.
+type ReindexState string
+
+const (
+	// ReindexIdle means no rebuild is running.
+	ReindexIdle ReindexState = "idle"
+
+	// ReindexRunning means a rebuild is in progress.
+	ReindexRunning ReindexState = "running"
+
+	// ReindexFailed means the last rebuild failed.
+	ReindexFailed ReindexState = "failed"
+)
+
+// SearchIndexStatus describes the search index and its rebuild progress.
+type SearchIndexStatus struct {
+	// State is the rebuild state.
+	State ReindexState `json:"state"`
+
+	// Indexed is the number of files indexed by the current or last rebuild.
+	Indexed int64 `json:"indexed"`
+
+	// Total is the number of files to index.
+	Total int64 `json:"total"`
+
+	// Drift is the number of files missing from or stale in the index.
+	Drift int64 `json:"drift"`
+
+	// Error describes why the last rebuild failed (if it did).
+	Error string `json:"error,omitempty"`
+
+	// StartedAt is when the current or last rebuild started.
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// FinishedAt is when the last rebuild finished.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Progress returns the fraction of files indexed, between 0 and 1.
+func (s *SearchIndexStatus) Progress() float64 {
+	if s.Total <= 0 {
+		return 0
+	}
+	return float64(s.Indexed) / float64(s.Total)
+}
+
+// ReindexSearch starts rebuilding the search index from scratch. Starting a
+// rebuild while one is running returns the running rebuild's status.
+//
+// Example:
+//
+//	status, err := client.Admin.ReindexStatus(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if status.Drift > 0 && status.State != fimage.ReindexRunning {
+//	    status, err = client.Admin.ReindexSearch(ctx)
+//	}
+func (s *AdminService) ReindexSearch(ctx context.Context, callOpts ...CallOption) (*SearchIndexStatus, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	var status SearchIndexStatus
+	if err := s.client.request(ctx, http.MethodPost, "/api/admin/search/reindex", nil, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// ReindexStatus returns the search index drift and rebuild progress.
+//
+// Example:
+//
+//	status, err := client.Admin.ReindexStatus(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s: %.0f%% (drift %d)\n", status.State, status.Progress()*100, status.Drift)
+func (s *AdminService) ReindexStatus(ctx context.Context, callOpts ...CallOption) (*SearchIndexStatus, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	var status SearchIndexStatus
+	if err := s.client.request(ctx, http.MethodGet, "/api/admin/search/reindex", nil, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// UserRole is the role of a user on a self-hosted instance.
+type UserRole string
+
+const (
+	// RoleUser is a regular user.
+	RoleUser UserRole = "user"
+
+	// RoleAdmin can manage users and the instance.
+	RoleAdmin UserRole = "admin"
+)
+
+// User is an account on a self-hosted instance.
+type User struct {
+	// ID is the unique identifier of the user.
+	ID int64 `json:"id"`
+
+	// Email is the user's email address.
+	Email string `json:"email"`
+
+	// Name is the user's display name.
+	Name string `json:"name"`
+
+	// Role is the user's role.
+	Role UserRole `json:"role"`
+
+	// Disabled reports whether the user is blocked from signing in and
+	// their tokens are rejected.
+	Disabled bool `json:"disabled"`
+
+	// QuotaBytes is the storage quota in bytes. Zero means unlimited.
+	QuotaBytes int64 `json:"quota_bytes"`
+
+	// UsedBytes is the storage used in bytes.
+	UsedBytes int64 `json:"used_bytes"`
+
+	// CreatedAt is the account creation timestamp.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UsersListResponse represents a page of users.
+type UsersListResponse struct {
+	// Users is the list of users.
+	Users []User `json:"users"`
+
+	// Total is the total number of matching users.
+	Total int64 `json:"total"`
+
+	// Page is the current page number.
+	Page int `json:"page"`
+
+	// Limit is the number of items per page.
+	Limit int `json:"limit"`
+}
+
+// ListUsersOptions contains options for listing users.
+type ListUsersOptions struct {
+	// Page is the page number (1-indexed).
+	Page int
+
+	// Limit is the number of items per page (max 100).
+	Limit int
+
+	// Query filters users by email or name.
+	Query string
+
+	// IncludeDisabled includes disabled users.
+	IncludeDisabled bool
+}
+
+// Validate checks the options for invalid fields.
+func (opts *ListUsersOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	return v.err()
+}
+
+// CreateUserOptions contains options for creating a user.
+type CreateUserOptions struct {
+	// Email is the user's email address (required).
+	Email string
+
+	// Name is the user's display name.
+	Name string
+
+	// Role is the user's role (default: RoleUser).
+	Role UserRole
+
+	// QuotaBytes is the storage quota in bytes (default: unlimited).
+	QuotaBytes int64
+}
+
+// Validate checks the options for invalid fields.
+func (opts *CreateUserOptions) Validate() error {
+	var v validator
+	v.check(isEmail(strings.TrimSpace(opts.Email)), "Email", "must be an email address")
+	v.check(opts.Role == "" || opts.Role == RoleUser || opts.Role == RoleAdmin, "Role", "has unsupported value %q", opts.Role)
+	v.check(opts.QuotaBytes >= 0, "QuotaBytes", "must not be negative")
+	return v.err()
+}
+
+// UserToken is a newly issued API token. The token is only returned once.
+type UserToken struct {
+	// UserID is the owner of the token.
+	UserID int64 `json:"user_id"`
+
+	// Token is the API token.
+	Token string `json:"token"`
+
+	// CreatedAt is when the token was issued.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListUsers returns a paginated list of users.
+//
+// Example:
+//
+//	resp, err := client.Admin.ListUsers(ctx, &fimage.ListUsersOptions{Query: "@example.com"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, u := range resp.Users {
+//	    fmt.Printf("%s: %d/%d bytes\n", u.Email, u.UsedBytes, u.QuotaBytes)
+//	}
+func (s *AdminService) ListUsers(ctx context.Context, opts *ListUsersOptions, callOpts ...CallOption) (*UsersListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	query := url.Values{}
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Query != "" {
+			query.Set("q", opts.Query)
+		}
+		if opts.IncludeDisabled {
+			query.Set("include_disabled", "true")
+		}
+	}
+
+	setDefaultLimit(ctx, query)
+
+	var resp UsersListResponse
+	if err := s.client.requestWithQuery(ctx, "/api/admin/users", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetUser returns a user by ID.
+//
+// Example:
+//
+//	user, err := client.Admin.GetUser(ctx, 42)
+func (s *AdminService) GetUser(ctx context.Context, userID int64, callOpts ...CallOption) (*User, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	path := fmt.Sprintf("/api/admin/users/%d", userID)
+
+	var user User
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CreateUser creates a user.
+//
+// Example:
+//
+//	user, err := client.Admin.CreateUser(ctx, &fimage.CreateUserOptions{
+//	    Email:      "jane@example.com",
+//	    Name:       "Jane",
+//	    QuotaBytes: 50 << 30, // 50 GiB
+//	})
+func (s *AdminService) CreateUser(ctx context.Context, opts *CreateUserOptions, callOpts ...CallOption) (*User, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	if opts == nil {
+		return nil, fmt.Errorf("user options are required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		Email      string   `json:"email"`
+		Name       string   `json:"name,omitempty"`
+		Role       UserRole `json:"role,omitempty"`
+		QuotaBytes int64    `json:"quota_bytes,omitempty"`
+	}{
+		Email:      strings.TrimSpace(opts.Email),
+		Name:       strings.TrimSpace(opts.Name),
+		Role:       opts.Role,
+		QuotaBytes: opts.QuotaBytes,
+	}
+
+	var user User
+	if err := s.client.request(ctx, http.MethodPost, "/api/admin/users", req, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// DisableUser blocks a user from signing in and revokes their tokens.
+// Their files are kept.
+//
+// Example:
+//
+//	_, err := client.Admin.DisableUser(ctx, 42)
+func (s *AdminService) DisableUser(ctx context.Context, userID int64, callOpts ...CallOption) (*User, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	return s.setDisabled(ctx, userID, true)
+}
+
+// EnableUser re-enables a disabled user.
+//
+// Example:
+//
+//	_, err := client.Admin.EnableUser(ctx, 42)
+func (s *AdminService) EnableUser(ctx context.Context, userID int64, callOpts ...CallOption) (*User, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	return s.setDisabled(ctx, userID, false)
+}
+
+func (s *AdminService) setDisabled(ctx context.Context, userID int64, disabled bool) (*User, error) {
+	path := fmt.Sprintf("/api/admin/users/%d", userID)
+
+	req := struct {
+		Disabled bool `json:"disabled"`
+	}{
+		Disabled: disabled,
+	}
+
+	var user User
+	if err := s.client.request(ctx, http.MethodPatch, path, req, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetQuota sets a user's storage quota in bytes. Zero means unlimited.
+//
+// Example:
+//
+//	_, err := client.Admin.SetQuota(ctx, 42, 100<<30) // 100 GiB
+func (s *AdminService) SetQuota(ctx context.Context, userID int64, quotaBytes int64, callOpts ...CallOption) (*User, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	if quotaBytes < 0 {
+		return nil, fmt.Errorf("quota must not be negative, got %d", quotaBytes)
+	}
+
+	path := fmt.Sprintf("/api/admin/users/%d", userID)
+
+	req := struct {
+		QuotaBytes int64 `json:"quota_bytes"`
+	}{
+		QuotaBytes: quotaBytes,
+	}
+
+	var user User
+	if err := s.client.request(ctx, http.MethodPatch, path, req, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// DeleteUser permanently deletes a user. Use DisableUser instead to keep
+// their files.
+//
+// Example:
+//
+//	_, err := client.Admin.DeleteUser(ctx, 42)
+func (s *AdminService) DeleteUser(ctx context.Context, userID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	path := fmt.Sprintf("/api/admin/users/%d", userID)
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodDelete, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// ResetToken revokes a user's API tokens and issues a new one. The new
+// token is only returned once, so store it immediately.
+//
+// Example:
+//
+//	token, err := client.Admin.ResetToken(ctx, 42)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	vault.Put("fimage/jane", token.Token)
+func (s *AdminService) ResetToken(ctx context.Context, userID int64, callOpts ...CallOption) (*UserToken, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAdmin, callOpts)
+
+	path := fmt.Sprintf("/api/admin/users/%d/token", userID)
+
+	var token UserToken
+	if err := s.client.request(ctx, http.MethodPost, path, nil, &token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
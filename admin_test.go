@@ -0,0 +1,164 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminUserManagementRequests(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method string
+		path   string
+		body   map[string]interface{}
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{method: r.Method, path: r.URL.Path}
+		if r.ContentLength > 0 {
+			if err := json.NewDecoder(r.Body).Decode(&c.body); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+		}
+		calls = append(calls, c)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{"message":"User deleted"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":42,"email":"jane@example.com","name":"Jane","role":"admin","quota_bytes":100,"disabled":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	user, err := client.Admin.CreateUser(ctx, &CreateUserOptions{Email: " jane@example.com ", Name: "Jane", Role: RoleAdmin, QuotaBytes: 100})
+	if err != nil {
+		t.Fatalf("CreateUser returned error: %v", err)
+	}
+	if user.ID != 42 || user.Role != RoleAdmin || !user.Disabled {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+	if _, err := client.Admin.SetQuota(ctx, 42, 200); err != nil {
+		t.Fatalf("SetQuota returned error: %v", err)
+	}
+	if _, err := client.Admin.DisableUser(ctx, 42); err != nil {
+		t.Fatalf("DisableUser returned error: %v", err)
+	}
+	if _, err := client.Admin.EnableUser(ctx, 42); err != nil {
+		t.Fatalf("EnableUser returned error: %v", err)
+	}
+	resp, err := client.Admin.DeleteUser(ctx, 42)
+	if err != nil {
+		t.Fatalf("DeleteUser returned error: %v", err)
+	}
+	if resp.Message != "User deleted" {
+		t.Fatalf("unexpected message: %q", resp.Message)
+	}
+
+	want := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, "/api/admin/users", `{"email":"jane@example.com","name":"Jane","quota_bytes":100,"role":"admin"}`},
+		{http.MethodPatch, "/api/admin/users/42", `{"quota_bytes":200}`},
+		{http.MethodPatch, "/api/admin/users/42", `{"disabled":true}`},
+		{http.MethodPatch, "/api/admin/users/42", `{"disabled":false}`},
+		{http.MethodDelete, "/api/admin/users/42", `null`},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(calls))
+	}
+	for i, w := range want {
+		body, _ := json.Marshal(calls[i].body)
+		if calls[i].method != w.method || calls[i].path != w.path || string(body) != w.body {
+			t.Errorf("request %d: got %s %s %s, want %s %s %s", i, calls[i].method, calls[i].path, body, w.method, w.path, w.body)
+		}
+	}
+}
+
+func TestAdminValidatesUserOptions(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test-token", WithBaseURL("http://127.0.0.1:0"))
+
+	_, err := client.Admin.CreateUser(context.Background(), &CreateUserOptions{Email: "jane", Role: "owner", QuotaBytes: -1})
+	if got := invalidFields(t, err); got != "Email,Role,QuotaBytes" {
+		t.Fatalf("invalid fields = %q", got)
+	}
+	if _, err := client.Admin.SetQuota(context.Background(), 42, -1); err == nil {
+		t.Fatal("expected error for negative quota")
+	}
+}
+
+func TestAdminWithoutScopeIsForbidden(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"admin scope required","code":"admin_scope_required"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Admin.DeleteUser(context.Background(), 42)
+	if !errors.Is(err, ErrForbidden) || !IsForbidden(err) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if !IsCode(err, CodeAdminScopeRequired) {
+		t.Fatalf("expected %s, got %v", CodeAdminScopeRequired, err)
+	}
+}
+
+func TestAdminReindex(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/admin/search/reindex" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			if len(body) != 0 {
+				t.Errorf("unexpected body: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"state":"running","indexed":0,"total":400,"drift":25,"started_at":"2024-06-01T12:00:00Z"}`))
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"state":"running","indexed":100,"total":400,"drift":25,"started_at":"2024-06-01T12:00:00Z"}`))
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	started, err := client.Admin.ReindexSearch(ctx)
+	if err != nil {
+		t.Fatalf("ReindexSearch returned error: %v", err)
+	}
+	if started.State != ReindexRunning || started.Drift != 25 || started.StartedAt == nil || started.FinishedAt != nil {
+		t.Fatalf("unexpected status: %+v", started)
+	}
+
+	status, err := client.Admin.ReindexStatus(ctx)
+	if err != nil {
+		t.Fatalf("ReindexStatus returned error: %v", err)
+	}
+	if status.Indexed != 100 || status.Total != 400 || status.Progress() != 0.25 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
@@ -3,7 +3,10 @@ package fimage
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // AlbumsService handles album operations.
@@ -20,6 +23,13 @@ type CreateAlbumOptions struct {
 	Description string
 }
 
+// Validate checks the options for invalid fields.
+func (opts *CreateAlbumOptions) Validate() error {
+	var v validator
+	v.check(strings.TrimSpace(opts.Name) != "", "Name", "is required")
+	return v.err()
+}
+
 // UpdateAlbumOptions contains options for updating an album.
 type UpdateAlbumOptions struct {
 	// Name is the new album name (required).
@@ -29,6 +39,13 @@ type UpdateAlbumOptions struct {
 	Description string
 }
 
+// Validate checks the options for invalid fields.
+func (opts *UpdateAlbumOptions) Validate() error {
+	var v validator
+	v.check(strings.TrimSpace(opts.Name) != "", "Name", "is required")
+	return v.err()
+}
+
 // List returns all albums for the authenticated user.
 //
 // Example:
@@ -40,11 +57,10 @@ type UpdateAlbumOptions struct {
 //	for _, album := range albums {
 //	    fmt.Printf("%s (%d files)\n", album.Name, album.FileCount)
 //	}
-func (s *AlbumsService) List(ctx context.Context) ([]Album, error) {
-	var resp struct {
-		Albums []Album `json:"albums"`
-	}
+func (s *AlbumsService) List(ctx context.Context, callOpts ...CallOption) ([]Album, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
 
+	var resp AlbumsListResponse
 	if err := s.client.request(ctx, http.MethodGet, "/api/albums", nil, &resp); err != nil {
 		return nil, err
 	}
@@ -52,6 +68,28 @@ func (s *AlbumsService) List(ctx context.Context) ([]Album, error) {
 	return resp.Albums, nil
 }
 
+// ListWithAggregates returns all albums together with the combined size of
+// their files, computed by the server, for storage dashboards that would
+// otherwise page through every album's files.
+//
+// Example:
+//
+//	resp, err := client.Albums.ListWithAggregates(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d albums, %d bytes\n", len(resp.Albums), resp.TotalSize)
+func (s *AlbumsService) ListWithAggregates(ctx context.Context, callOpts ...CallOption) (*AlbumsListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	var resp AlbumsListResponse
+	if err := s.client.request(ctx, http.MethodGet, "/api/albums?include_aggregates=true", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // Get returns a specific album by ID.
 //
 // Example:
@@ -65,7 +103,9 @@ func (s *AlbumsService) List(ctx context.Context) ([]Album, error) {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Album: %s\n", album.Name)
-func (s *AlbumsService) Get(ctx context.Context, albumID int64) (*Album, error) {
+func (s *AlbumsService) Get(ctx context.Context, albumID int64, callOpts ...CallOption) (*Album, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
 	path := fmt.Sprintf("/api/albums/%d", albumID)
 
 	var album Album
@@ -88,9 +128,14 @@ func (s *AlbumsService) Get(ctx context.Context, albumID int64) (*Album, error)
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Created album: %s (ID: %d)\n", album.Name, album.ID)
-func (s *AlbumsService) Create(ctx context.Context, opts *CreateAlbumOptions) (*Album, error) {
-	if opts == nil || opts.Name == "" {
-		return nil, fmt.Errorf("album name is required")
+func (s *AlbumsService) Create(ctx context.Context, opts *CreateAlbumOptions, callOpts ...CallOption) (*Album, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if opts == nil {
+		return nil, fmt.Errorf("album options are required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	req := struct {
@@ -121,9 +166,14 @@ func (s *AlbumsService) Create(ctx context.Context, opts *CreateAlbumOptions) (*
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Updated album: %s\n", album.Name)
-func (s *AlbumsService) Update(ctx context.Context, albumID int64, opts *UpdateAlbumOptions) (*Album, error) {
-	if opts == nil || opts.Name == "" {
-		return nil, fmt.Errorf("album name is required")
+func (s *AlbumsService) Update(ctx context.Context, albumID int64, opts *UpdateAlbumOptions, callOpts ...CallOption) (*Album, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if opts == nil {
+		return nil, fmt.Errorf("album options are required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	path := fmt.Sprintf("/api/albums/%d", albumID)
@@ -144,6 +194,69 @@ func (s *AlbumsService) Update(ctx context.Context, albumID int64, opts *UpdateA
 	return &album, nil
 }
 
+// LinkFiles adds files to an album without removing them from their other
+// albums, so one file can appear in several albums. Use FilesService.Move
+// to move files into a single album instead.
+//
+// Example:
+//
+//	result, err := client.Albums.LinkFiles(ctx, 123, []int64{1, 2, 3})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Linked: %d, Failed: %d\n", len(result.Succeeded), len(result.Failed))
+func (s *AlbumsService) LinkFiles(ctx context.Context, albumID int64, fileIDs []int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/files", albumID)
+
+	req := struct {
+		FileIDs []int64 `json:"file_ids"`
+	}{
+		FileIDs: fileIDs,
+	}
+
+	var resp batchResponse
+	if err := s.client.request(ctx, http.MethodPost, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.idResult(fileIDs), nil
+}
+
+// UnlinkFiles removes files from an album. The files stay in their other
+// albums; files that were only in this album become unfiled.
+//
+// Example:
+//
+//	result, err := client.Albums.UnlinkFiles(ctx, 123, []int64{1, 2})
+func (s *AlbumsService) UnlinkFiles(ctx context.Context, albumID int64, fileIDs []int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/files", albumID)
+
+	req := struct {
+		FileIDs []int64 `json:"file_ids"`
+	}{
+		FileIDs: fileIDs,
+	}
+
+	var resp batchResponse
+	if err := s.client.request(ctx, http.MethodDelete, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.idResult(fileIDs), nil
+}
+
 // Delete deletes an album. Files in the album are not deleted,
 // they are moved to "no album".
 //
@@ -154,7 +267,9 @@ func (s *AlbumsService) Update(ctx context.Context, albumID int64, opts *UpdateA
 //	    log.Fatal(err)
 //	}
 //	fmt.Println("Album deleted")
-func (s *AlbumsService) Delete(ctx context.Context, albumID int64) (*MessageResponse, error) {
+func (s *AlbumsService) Delete(ctx context.Context, albumID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
 	path := fmt.Sprintf("/api/albums/%d", albumID)
 
 	var resp MessageResponse
@@ -164,3 +279,243 @@ func (s *AlbumsService) Delete(ctx context.Context, albumID int64) (*MessageResp
 
 	return &resp, nil
 }
+
+// MetadataFormat is the file format used to export and import album metadata.
+type MetadataFormat string
+
+const (
+	// MetadataFormatCSV is a spreadsheet-friendly CSV with one row per file
+	// and the columns filename, description, and tags.
+	MetadataFormatCSV MetadataFormat = "csv"
+
+	// MetadataFormatJSON is a JSON array of objects with the keys
+	// filename, description, and tags.
+	MetadataFormatJSON MetadataFormat = "json"
+)
+
+// contentType returns the MIME type for the metadata format.
+func (f MetadataFormat) contentType() string {
+	if f == MetadataFormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// ExportMetadata writes the filename, description, and tags of every file
+// in an album to w. The format defaults to CSV when empty.
+//
+// Example:
+//
+//	out, _ := os.Create("captions.csv")
+//	defer out.Close()
+//
+//	_, err := client.Albums.ExportMetadata(ctx, 123, out, fimage.MetadataFormatCSV)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (s *AlbumsService) ExportMetadata(ctx context.Context, albumID int64, w io.Writer, format MetadataFormat, callOpts ...CallOption) (int64, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if format == "" {
+		format = MetadataFormatCSV
+	}
+
+	query := url.Values{}
+	query.Set("format", string(format))
+	path := fmt.Sprintf("/api/albums/%d/metadata?%s", albumID, query.Encode())
+
+	resp, err := s.client.requestStream(ctx, http.MethodGet, path, nil, "", format.contentType())
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return n, nil
+}
+
+// ImportMetadata reads a metadata file produced by ExportMetadata (or edited
+// in a spreadsheet) and applies descriptions and tags to the matching files
+// in an album. Files are matched by filename. The format defaults to CSV
+// when empty.
+//
+// Example:
+//
+//	in, _ := os.Open("captions.csv")
+//	defer in.Close()
+//
+//	result, err := client.Albums.ImportMetadata(ctx, 123, in, fimage.MetadataFormatCSV)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Updated: %d, Skipped: %d\n", result.Updated, result.Skipped)
+func (s *AlbumsService) ImportMetadata(ctx context.Context, albumID int64, r io.Reader, format MetadataFormat, callOpts ...CallOption) (*MetadataImportResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	if format == "" {
+		format = MetadataFormatCSV
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/metadata", albumID)
+
+	resp, err := s.client.requestStream(ctx, http.MethodPut, path, r, format.contentType(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result MetadataImportResult
+	if err := s.client.unmarshalJSON(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ImportZipOptions contains options for importing a ZIP archive into an album.
+type ImportZipOptions struct {
+	// Filename is the archive name reported to the server.
+	// Defaults to "import.zip".
+	Filename string
+
+	// SkipDuplicates links files that already exist in the library instead
+	// of storing them again.
+	SkipDuplicates bool
+
+	// SingleFileOnly skips medium and thumbnail generation for imported images.
+	SingleFileOnly bool
+}
+
+// ImportZip streams a ZIP archive to the server, which extracts, deduplicates,
+// and files the contained images into an album. This is much faster than
+// uploading thousands of files individually.
+//
+// Example:
+//
+//	archive, _ := os.Open("wedding.zip")
+//	defer archive.Close()
+//
+//	resp, err := client.Albums.ImportZip(ctx, 123, archive, &fimage.ImportZipOptions{
+//	    SkipDuplicates: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, entry := range resp.Entries {
+//	    fmt.Printf("%s: %s\n", entry.Name, entry.Status)
+//	}
+func (s *AlbumsService) ImportZip(ctx context.Context, albumID int64, r io.Reader, opts *ImportZipOptions, callOpts ...CallOption) (*ZipImportResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	if opts == nil {
+		opts = &ImportZipOptions{}
+	}
+
+	filename := opts.Filename
+	if filename == "" {
+		filename = "import.zip"
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/import", albumID)
+
+	query := url.Values{}
+	if opts.SkipDuplicates {
+		query.Set("skip_duplicates", "true")
+	}
+	if opts.SingleFileOnly {
+		query.Set("single_file_only", "true")
+	}
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+
+	respBody, err := s.client.uploadMultipart(ctx, path, r, filename, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ZipImportResponse
+	if err := s.client.unmarshalJSON(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// FeedFormat is the syndication format of an album feed.
+type FeedFormat string
+
+const (
+	// FeedRSS is an RSS 2.0 feed.
+	FeedRSS FeedFormat = "rss"
+
+	// FeedAtom is an Atom feed.
+	FeedAtom FeedFormat = "atom"
+
+	// FeedJSON is a JSON Feed 1.1 document.
+	FeedJSON FeedFormat = "json"
+)
+
+// extension returns the file extension used in feed URLs.
+func (f FeedFormat) extension() string {
+	switch f {
+	case FeedAtom:
+		return "atom"
+	case FeedJSON:
+		return "json"
+	}
+	return "rss"
+}
+
+// FeedURL returns the public feed URL of an album for use in feed readers
+// and downstream sites. The format defaults to RSS when empty. No request
+// is made.
+//
+// Example:
+//
+//	fmt.Println(client.Albums.FeedURL(123, fimage.FeedAtom))
+//	// https://f-image.com/feeds/albums/123.atom
+func (s *AlbumsService) FeedURL(albumID int64, format FeedFormat) string {
+	return fmt.Sprintf("%s/feeds/albums/%d.%s", s.client.BaseURL, albumID, format.extension())
+}
+
+// Feed returns the most recent additions to an album, newest first.
+//
+// Example:
+//
+//	feed, err := client.Albums.Feed(ctx, 123)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range feed.Items {
+//	    if item.PublishedAt.After(lastSeen) {
+//	        fmt.Println("New:", item.URL)
+//	    }
+//	}
+func (s *AlbumsService) Feed(ctx context.Context, albumID int64, callOpts ...CallOption) (*AlbumFeed, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	path := fmt.Sprintf("/api/albums/%d/feed", albumID)
+
+	var feed AlbumFeed
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &feed); err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,329 @@
+package fimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinkAndUnlinkFilesReportPerItemResults(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/albums/7/files" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var body struct {
+			FileIDs []int64 `json:"file_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.FileIDs) != 2 || body.FileIDs[1] != 2 {
+			t.Errorf("unexpected body: %+v (%v)", body, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"success":true},{"id":2,"success":false,"status":404,"code":"file_not_found","error":"File not found"}]}`))
+		case http.MethodDelete:
+			_, _ = w.Write([]byte(`{"results":[{"id":1,"success":true},{"id":2,"success":true}]}`))
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	linked, err := client.Albums.LinkFiles(context.Background(), 7, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("LinkFiles returned error: %v", err)
+	}
+	if len(linked.Succeeded) != 1 || linked.Succeeded[0] != 1 {
+		t.Fatalf("unexpected successes: %v", linked.Succeeded)
+	}
+	if len(linked.Failed) != 1 || linked.Failed[0].ID != 2 || !IsNotFound(linked.Failed[0].Err) {
+		t.Fatalf("unexpected failures: %+v", linked.Failed)
+	}
+
+	unlinked, err := client.Albums.UnlinkFiles(context.Background(), 7, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("UnlinkFiles returned error: %v", err)
+	}
+	if !unlinked.OK() || len(unlinked.Succeeded) != 2 {
+		t.Fatalf("unexpected unlink result: %+v", unlinked)
+	}
+
+	if _, err := client.Albums.LinkFiles(context.Background(), 7, nil); err == nil {
+		t.Fatal("expected an error for no file IDs")
+	}
+}
+
+func TestFileInAlbum(t *testing.T) {
+	t.Parallel()
+
+	primary := int64(1)
+	tests := []struct {
+		name    string
+		file    File
+		albumID int64
+		want    bool
+	}{
+		{"primary", File{AlbumID: &primary}, 1, true},
+		{"linked", File{AlbumID: &primary, AlbumIDs: []int64{1, 4}}, 4, true},
+		{"other", File{AlbumID: &primary, AlbumIDs: []int64{1, 4}}, 5, false},
+		{"unfiled", File{}, 1, false},
+	}
+	for _, tt := range tests {
+		if got := tt.file.InAlbum(tt.albumID); got != tt.want {
+			t.Errorf("%s: InAlbum(%d) = %v, want %v", tt.name, tt.albumID, got, tt.want)
+		}
+	}
+}
+
+func TestListWithAggregatesDecodesTotalSize(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/albums" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include_aggregates"); got != "true" {
+			t.Errorf("unexpected include_aggregates: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"albums":[{"id":1,"name":"Trips"},{"id":2,"name":"Work"}],"total_size":987654321}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Albums.ListWithAggregates(context.Background())
+	if err != nil {
+		t.Fatalf("ListWithAggregates returned error: %v", err)
+	}
+	if len(resp.Albums) != 2 || resp.Albums[1].Name != "Work" {
+		t.Fatalf("unexpected albums: %+v", resp.Albums)
+	}
+	if resp.TotalSize != 987654321 {
+		t.Fatalf("unexpected total size: %d", resp.TotalSize)
+	}
+}
+
+func TestExportAndImportMetadata(t *testing.T) {
+	t.Parallel()
+
+	const csv = "filename,description,tags\nbeach.jpg,Sunset,\"travel,summer\"\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/albums/5/metadata" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.URL.Query().Get("format"); got != "csv" {
+				t.Errorf("unexpected format: %q", got)
+			}
+			if got := r.Header.Get("Accept"); got != "text/csv" {
+				t.Errorf("unexpected Accept: %q", got)
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte(csv))
+		case http.MethodPut:
+			if got := r.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("unexpected Content-Type: %q", got)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `[{"filename":"beach.jpg","description":"Sunset"}]` {
+				t.Errorf("unexpected body: %s", body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"updated":1,"skipped":1,"errors":[{"row":2,"filename":"gone.jpg","reason":"no such file"}]}`))
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	n, err := client.Albums.ExportMetadata(ctx, 5, &out, "")
+	if err != nil {
+		t.Fatalf("ExportMetadata returned error: %v", err)
+	}
+	if out.String() != csv || n != int64(len(csv)) {
+		t.Fatalf("unexpected export (%d bytes): %q", n, out.String())
+	}
+
+	result, err := client.Albums.ImportMetadata(ctx, 5, strings.NewReader(`[{"filename":"beach.jpg","description":"Sunset"}]`), MetadataFormatJSON)
+	if err != nil {
+		t.Fatalf("ImportMetadata returned error: %v", err)
+	}
+	if result.Updated != 1 || result.Skipped != 1 || len(result.Errors) != 1 || result.Errors[0].Filename != "gone.jpg" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestImportZipStreamsArchive(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/albums/9/import" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Encode(); got != "skip_duplicates=true" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("missing file part: %v", err)
+		} else {
+			data, _ := io.ReadAll(file)
+			if header.Filename != "wedding.zip" || string(data) != "PK\x03\x04" {
+				t.Errorf("unexpected archive %q: %q", header.Filename, data)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"imported":1,"duplicates":1,"skipped":1,"entries":[
+			{"name":"a.jpg","status":"imported","file_id":10},
+			{"name":"b.jpg","status":"duplicate","file_id":3},
+			{"name":"notes.txt","status":"skipped","reason":"not an image"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Albums.ImportZip(context.Background(), 9, strings.NewReader("PK\x03\x04"), &ImportZipOptions{
+		Filename:       "wedding.zip",
+		SkipDuplicates: true,
+	})
+	if err != nil {
+		t.Fatalf("ImportZip returned error: %v", err)
+	}
+	if resp.Imported != 1 || resp.Duplicates != 1 || resp.Skipped != 1 || len(resp.Entries) != 3 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if e := resp.Entries[1]; e.Status != ZipEntryDuplicate || e.GetFileID() != 3 {
+		t.Fatalf("unexpected duplicate entry: %+v", e)
+	}
+	if e := resp.Entries[2]; e.Status != ZipEntrySkipped || e.FileID != nil || e.Reason != "not an image" {
+		t.Fatalf("unexpected skipped entry: %+v", e)
+	}
+}
+
+func TestAlbumFeed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/albums/5/feed" || r.URL.RawQuery != "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"album_id": 5,
+			"title": "Trips",
+			"description": "Holiday photos",
+			"updated_at": "2024-05-02T10:00:00Z",
+			"items": [
+				{"file_id": 31, "title": "beach.jpg", "url": "https://cdn.example.com/31.jpg", "thumbnail_url": "https://cdn.example.com/31_thumb.jpg", "published_at": "2024-05-02T10:00:00Z"},
+				{"file_id": 30, "title": "dunes.jpg", "url": "https://cdn.example.com/30.jpg", "published_at": "2024-05-01T09:00:00Z"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	feed, err := client.Albums.Feed(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("Feed returned error: %v", err)
+	}
+	if feed.AlbumID != 5 || feed.Title != "Trips" || feed.UpdatedAt.IsZero() || len(feed.Items) != 2 {
+		t.Fatalf("unexpected feed: %+v", feed)
+	}
+	if item := feed.Items[0]; item.FileID != 31 || item.GetThumbnailURL() != "https://cdn.example.com/31_thumb.jpg" {
+		t.Fatalf("unexpected first item: %+v", item)
+	}
+	if feed.Items[1].ThumbnailURL != nil {
+		t.Fatalf("expected no thumbnail on second item, got %q", *feed.Items[1].ThumbnailURL)
+	}
+}
+
+func TestAlbumFeedURL(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test-token", WithBaseURL("https://f-image.example.com"))
+
+	tests := []struct {
+		format FeedFormat
+		want   string
+	}{
+		{FeedRSS, "https://f-image.example.com/feeds/albums/9.rss"},
+		{FeedAtom, "https://f-image.example.com/feeds/albums/9.atom"},
+		{FeedJSON, "https://f-image.example.com/feeds/albums/9.json"},
+		{"", "https://f-image.example.com/feeds/albums/9.rss"},
+	}
+	for _, tt := range tests {
+		if got := client.Albums.FeedURL(9, tt.format); got != tt.want {
+			t.Errorf("FeedURL(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestAlbumAutoSplit(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/albums/6/auto-split" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"album_id": 6,
+			"groups": [
+				{"album_id": 0, "name": "Jun 1, 10:00-12:30", "start": "2024-06-01T10:00:00Z", "end": "2024-06-01T12:30:00Z", "file_count": 40},
+				{"album_id": 0, "name": "Jun 1, 15:00-15:45", "start": "2024-06-01T15:00:00Z", "end": "2024-06-01T15:45:00Z", "file_count": 12}
+			],
+			"unsorted": 3
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	result, err := client.Albums.AutoSplit(ctx, 6, &AutoSplitOptions{GapMinutes: 90, DryRun: true})
+	if err != nil {
+		t.Fatalf("AutoSplit returned error: %v", err)
+	}
+	if result.AlbumID != 6 || result.Unsorted != 3 || len(result.Groups) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if g := result.Groups[1]; g.FileCount != 12 || g.AlbumID != 0 || g.End.Sub(g.Start) != 45*time.Minute {
+		t.Fatalf("unexpected group: %+v", g)
+	}
+	if _, err := client.Albums.AutoSplit(ctx, 6, nil); err != nil {
+		t.Fatalf("AutoSplit returned error: %v", err)
+	}
+
+	for _, opts := range []AutoSplitOptions{{GapMinutes: -1}, {GapMinutes: maxAutoSplitGap + 1}} {
+		_, err := client.Albums.AutoSplit(ctx, 6, &opts)
+		if got := invalidFields(t, err); got != "GapMinutes" {
+			t.Errorf("GapMinutes %d: invalid fields = %q", opts.GapMinutes, got)
+		}
+	}
+
+	want := []string{`{"gap_minutes":90,"dry_run":true}`, `{}`}
+	if strings.Join(bodies, " ") != strings.Join(want, " ") {
+		t.Fatalf("request bodies = %v, want %v", bodies, want)
+	}
+}
@@ -0,0 +1,164 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// albumSettingsTTL is how long Upload reuses album settings before fetching
+// them again.
+const albumSettingsTTL = time.Minute
+
+// AlbumSettings are the default upload settings of an album. Upload applies
+// them to files uploaded into the album, so upload policy lives with the
+// album instead of in every uploading service.
+type AlbumSettings struct {
+	// AutoTags are tag names added to every file uploaded into the album.
+	AutoTags []string `json:"auto_tags"`
+
+	// StripMetadata removes GPS or all EXIF metadata from uploads.
+	StripMetadata StripMode `json:"strip_metadata"`
+
+	// WatermarkPreset is the name of a transform preset applied as a
+	// watermark to uploads (see PresetsService).
+	WatermarkPreset string `json:"watermark_preset"`
+
+	// Visibility is the visibility of uploaded files.
+	Visibility Visibility `json:"visibility"`
+}
+
+// Validate checks the settings for invalid fields.
+func (settings *AlbumSettings) Validate() error {
+	var v validator
+	switch settings.StripMetadata {
+	case StripModeNone, StripModeGPS, StripModeAll:
+	default:
+		v.check(false, "StripMetadata", "has unsupported value %q", settings.StripMetadata)
+	}
+	v.check(settings.WatermarkPreset == "" || validPresetName(settings.WatermarkPreset), "WatermarkPreset", "is not a valid preset name")
+	v.check(settings.Visibility == "" || settings.Visibility.Valid(), "Visibility", "has unsupported value %q", settings.Visibility)
+	return v.err()
+}
+
+// cachedAlbumSettings is an entry of the client's album settings cache.
+type cachedAlbumSettings struct {
+	settings *AlbumSettings
+	expires  time.Time
+}
+
+// GetSettings returns the default upload settings of an album.
+//
+// Example:
+//
+//	settings, err := client.Albums.GetSettings(ctx, 123)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println("Auto tags:", settings.AutoTags)
+func (s *AlbumsService) GetSettings(ctx context.Context, albumID int64, callOpts ...CallOption) (*AlbumSettings, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	path := fmt.Sprintf("/api/albums/%d/settings", albumID)
+
+	var settings AlbumSettings
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &settings); err != nil {
+		return nil, err
+	}
+
+	s.client.albumSettings.Store(albumID, &cachedAlbumSettings{
+		settings: &settings,
+		expires:  time.Now().Add(albumSettingsTTL),
+	})
+
+	return &settings, nil
+}
+
+// SetSettings replaces the default upload settings of an album and returns
+// the stored settings. Files already in the album are not changed.
+//
+// Example:
+//
+//	_, err := client.Albums.SetSettings(ctx, 123, &fimage.AlbumSettings{
+//	    AutoTags:        []string{"press"},
+//	    StripMetadata:   fimage.StripModeGPS,
+//	    WatermarkPreset: "logo-corner",
+//	    Visibility:      fimage.VisibilityUnlisted,
+//	})
+func (s *AlbumsService) SetSettings(ctx context.Context, albumID int64, settings *AlbumSettings, callOpts ...CallOption) (*AlbumSettings, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if settings == nil {
+		return nil, fmt.Errorf("album settings are required")
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/settings", albumID)
+
+	var stored AlbumSettings
+	if err := s.client.request(ctx, http.MethodPut, path, settings, &stored); err != nil {
+		s.client.albumSettings.Delete(albumID)
+		return nil, err
+	}
+
+	s.client.albumSettings.Store(albumID, &cachedAlbumSettings{
+		settings: &stored,
+		expires:  time.Now().Add(albumSettingsTTL),
+	})
+
+	return &stored, nil
+}
+
+// uploadDefaults returns the settings of an album for Upload, reusing a
+// recently fetched copy. Servers without album settings report 404, which
+// is treated as an album without defaults.
+func (s *AlbumsService) uploadDefaults(ctx context.Context, albumID int64) (*AlbumSettings, error) {
+	if v, ok := s.client.albumSettings.Load(albumID); ok {
+		if cached := v.(*cachedAlbumSettings); time.Now().Before(cached.expires) {
+			return cached.settings, nil
+		}
+	}
+
+	settings, err := s.GetSettings(ctx, albumID)
+	if IsNotFound(err) {
+		settings = &AlbumSettings{}
+		s.client.albumSettings.Store(albumID, &cachedAlbumSettings{
+			settings: settings,
+			expires:  time.Now().Add(albumSettingsTTL),
+		})
+		return settings, nil
+	}
+	return settings, err
+}
+
+// withAlbumDefaults returns a copy of opts with album settings filled in
+// for fields the caller left unset. Auto tags are added to opts.Tags.
+func (opts *UploadOptions) withAlbumDefaults(settings *AlbumSettings) *UploadOptions {
+	merged := *opts
+	if merged.StripMetadata == StripModeNone {
+		merged.StripMetadata = settings.StripMetadata
+	}
+	if merged.WatermarkPreset == "" {
+		merged.WatermarkPreset = settings.WatermarkPreset
+	}
+	if merged.Visibility == "" {
+		merged.Visibility = settings.Visibility
+	}
+	if len(settings.AutoTags) > 0 {
+		seen := make(map[string]bool, len(opts.Tags))
+		merged.Tags = append([]string(nil), opts.Tags...)
+		for _, tag := range opts.Tags {
+			seen[tag] = true
+		}
+		for _, tag := range settings.AutoTags {
+			if !seen[tag] {
+				seen[tag] = true
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+	}
+	return &merged
+}
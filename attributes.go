@@ -0,0 +1,187 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// maxAttributeKeyLength is the longest attribute key accepted by the API.
+const maxAttributeKeyLength = 64
+
+// validAttributeKey reports whether key may be used as a custom attribute key.
+// Keys may contain letters, digits, underscores, dashes, and dots.
+func validAttributeKey(key string) bool {
+	if key == "" || len(key) > maxAttributeKeyLength {
+		return false
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// setAttributeQuery adds attribute filters to a query as attr.<key>=<value>.
+func setAttributeQuery(query url.Values, attrs map[string]string) {
+	for key, value := range attrs {
+		query.Set("attr."+key, value)
+	}
+}
+
+// GetAttributes returns the custom attributes stored on a file.
+//
+// Example:
+//
+//	attrs, err := client.Files.GetAttributes(ctx, 123)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(attrs["sku"])
+func (s *FilesService) GetAttributes(ctx context.Context, fileID int64, callOpts ...CallOption) (map[string]string, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	path := fmt.Sprintf("/api/files/%d/attributes", fileID)
+
+	var resp struct {
+		Attributes map[string]string `json:"attributes"`
+	}
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Attributes == nil {
+		resp.Attributes = map[string]string{}
+	}
+
+	return resp.Attributes, nil
+}
+
+// SetAttributes merges custom attributes into a file's existing attributes
+// and returns the resulting set. Keys with an empty value are removed.
+//
+// Example:
+//
+//	attrs, err := client.Files.SetAttributes(ctx, 123, map[string]string{
+//	    "sku":      "TSHIRT-RED-M",
+//	    "order_id": "1042",
+//	})
+func (s *FilesService) SetAttributes(ctx context.Context, fileID int64, attrs map[string]string, callOpts ...CallOption) (map[string]string, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if len(attrs) == 0 {
+		return nil, fmt.Errorf("at least one attribute is required")
+	}
+
+	var v validator
+	v.attributes("Attributes", attrs)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/files/%d/attributes", fileID)
+
+	req := struct {
+		Attributes map[string]string `json:"attributes"`
+	}{
+		Attributes: attrs,
+	}
+
+	var resp struct {
+		Attributes map[string]string `json:"attributes"`
+	}
+	if err := s.client.request(ctx, http.MethodPatch, path, req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Attributes == nil {
+		resp.Attributes = map[string]string{}
+	}
+
+	return resp.Attributes, nil
+}
+
+// attributes records errors for invalid attribute keys.
+func (v *validator) attributes(field string, attrs map[string]string) {
+	for key := range attrs {
+		v.check(validAttributeKey(key), fmt.Sprintf("%s[%q]", field, key),
+			"must be 1-%d letters, digits, '_', '-', or '.'", maxAttributeKeyLength)
+	}
+}
+
+// AttributeSearchOptions contains options for searching files by attribute.
+type AttributeSearchOptions struct {
+	// Page is the page number (1-indexed).
+	Page int
+
+	// Limit is the number of items per page (max 100).
+	Limit int
+
+	// Sort is the result ordering. Defaults to SortCreatedDesc.
+	Sort SortOrder
+
+	// AlbumID limits the search to an album.
+	AlbumID *int64
+}
+
+// Validate checks the options for invalid fields.
+func (opts *AttributeSearchOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	v.check(opts.Sort == "" || opts.Sort.Valid(), "Sort", "has unsupported value %q", opts.Sort)
+	return v.err()
+}
+
+// SearchByAttribute returns the files whose custom attribute key equals value,
+// using the server-side attribute index.
+//
+// Example:
+//
+//	resp, err := client.Files.SearchByAttribute(ctx, "sku", "TSHIRT-RED-M", nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, file := range resp.Files {
+//	    fmt.Println(file.URL)
+//	}
+func (s *FilesService) SearchByAttribute(ctx context.Context, key, value string, opts *AttributeSearchOptions, callOpts ...CallOption) (*FilesListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if !validAttributeKey(key) {
+		return nil, fmt.Errorf("invalid attribute key: %q", key)
+	}
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("value", value)
+
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Sort != "" {
+			query.Set("sort", string(opts.Sort))
+		}
+		if opts.AlbumID != nil {
+			query.Set("album_id", strconv.FormatInt(*opts.AlbumID, 10))
+		}
+	}
+
+	setDefaultLimit(ctx, query)
+
+	var resp FilesListResponse
+	if err := s.client.requestWithQuery(ctx, "/api/files/attributes/search", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
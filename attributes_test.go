@@ -0,0 +1,55 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSearchByAttributeSendsKeyValueAndOptions(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodGet || r.URL.Path != "/api/files/attributes/search" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Encode(); got != "album_id=3&key=sku&limit=10&page=2&sort=created_asc&value=TSHIRT+RED%2FM" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":8,"original_name":"shirt.jpg"}],"total":11,"page":2,"limit":10}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	albumID := int64(3)
+	resp, err := client.Files.SearchByAttribute(ctx, "sku", "TSHIRT RED/M", &AttributeSearchOptions{
+		Page:    2,
+		Limit:   10,
+		Sort:    SortCreatedAsc,
+		AlbumID: &albumID,
+	})
+	if err != nil {
+		t.Fatalf("SearchByAttribute returned error: %v", err)
+	}
+	if resp.Total != 11 || len(resp.Files) != 1 || resp.Files[0].ID != 8 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	if _, err := client.Files.SearchByAttribute(ctx, "bad key", "x", nil); err == nil {
+		t.Fatal("expected error for invalid attribute key")
+	}
+	_, err = client.Files.SearchByAttribute(ctx, "sku", "x", &AttributeSearchOptions{Limit: 500, Sort: "newest"})
+	if got := invalidFields(t, err); got != "Limit,Sort" {
+		t.Fatalf("invalid fields = %q", got)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected invalid calls to make no request, got %d requests", n)
+	}
+}
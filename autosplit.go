@@ -0,0 +1,102 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxAutoSplitGap is the largest gap accepted by AutoSplit, one week.
+const maxAutoSplitGap = 7 * 24 * 60
+
+// AutoSplitOptions contains options for splitting an album by capture time.
+type AutoSplitOptions struct {
+	// GapMinutes is the pause between photos, in minutes, that starts a new
+	// group (default: 60, max one week).
+	GapMinutes int
+
+	// DryRun returns the groups without creating sub-albums.
+	DryRun bool
+}
+
+// Validate checks the options for invalid fields.
+func (opts *AutoSplitOptions) Validate() error {
+	var v validator
+	v.check(opts.GapMinutes >= 0 && opts.GapMinutes <= maxAutoSplitGap, "GapMinutes", "must be between 0 and %d", maxAutoSplitGap)
+	return v.err()
+}
+
+// AutoSplitGroup is a cluster of photos taken close together.
+type AutoSplitGroup struct {
+	// AlbumID is the ID of the created sub-album, or 0 for a dry run.
+	AlbumID int64 `json:"album_id"`
+
+	// Name is the sub-album name, derived from the time range.
+	Name string `json:"name"`
+
+	// Start is the capture time of the first photo.
+	Start time.Time `json:"start"`
+
+	// End is the capture time of the last photo.
+	End time.Time `json:"end"`
+
+	// FileCount is the number of photos in the group.
+	FileCount int64 `json:"file_count"`
+}
+
+// AutoSplitResult is the structure created by AutoSplit.
+type AutoSplitResult struct {
+	// AlbumID is the ID of the album that was split.
+	AlbumID int64 `json:"album_id"`
+
+	// Groups are the capture-time groups, oldest first.
+	Groups []AutoSplitGroup `json:"groups"`
+
+	// Unsorted is the number of files without a capture time, which were
+	// left out of every group.
+	Unsorted int64 `json:"unsorted"`
+}
+
+// AutoSplit splits an album of event photos into sub-albums by capture
+// time: a new sub-album starts whenever no photo was taken for GapMinutes.
+// Files are linked into their sub-album and stay in the parent album.
+//
+// Example:
+//
+//	result, err := client.Albums.AutoSplit(ctx, 123, &fimage.AutoSplitOptions{
+//	    GapMinutes: 90,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, g := range result.Groups {
+//	    fmt.Printf("%s: %d photos\n", g.Name, g.FileCount)
+//	}
+func (s *AlbumsService) AutoSplit(ctx context.Context, albumID int64, opts *AutoSplitOptions, callOpts ...CallOption) (*AutoSplitResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if opts == nil {
+		opts = &AutoSplitOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		GapMinutes int  `json:"gap_minutes,omitempty"`
+		DryRun     bool `json:"dry_run,omitempty"`
+	}{
+		GapMinutes: opts.GapMinutes,
+		DryRun:     opts.DryRun,
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/auto-split", albumID)
+
+	var result AutoSplitResult
+	if err := s.client.request(ctx, http.MethodPost, path, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
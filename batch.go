@@ -0,0 +1,150 @@
+package fimage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BatchItemError describes an item of a batch operation that failed.
+type BatchItemError struct {
+	// Index is the position of the item in the request.
+	Index int
+
+	// ID is the ID of the item, or zero when the item has none yet
+	// (e.g. a failed upload).
+	ID int64
+
+	// Err is why the item failed. Errors reported by the server are
+	// *APIError values, so IsNotFound and friends work on them.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BatchItemError) Error() string {
+	if e.ID != 0 {
+		return fmt.Sprintf("item %d (id %d): %v", e.Index, e.ID, e.Err)
+	}
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchResult is the per-item outcome of a batch operation. A batch call
+// only returns an error when the request as a whole failed; items that
+// failed individually are listed in Failed.
+type BatchResult[T any] struct {
+	// Succeeded holds the result of every item that succeeded, in request
+	// order. For operations on existing files it holds their IDs.
+	Succeeded []T
+
+	// Failed lists the items that failed, in request order.
+	Failed []BatchItemError
+
+	// Message is a human-readable summary from the API, if any.
+	Message string
+}
+
+// OK reports whether every item succeeded.
+func (r *BatchResult[T]) OK() bool {
+	return len(r.Failed) == 0
+}
+
+// Err returns a *BatchError describing the failed items, or nil if every
+// item succeeded.
+//
+// Example:
+//
+//	result, err := client.Files.BatchDelete(ctx, ids)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := result.Err(); err != nil {
+//	    log.Printf("partial failure: %v", err)
+//	}
+func (r *BatchResult[T]) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return &BatchError{Total: len(r.Succeeded) + len(r.Failed), Failed: r.Failed}
+}
+
+// BatchError is returned by BatchResult.Err when some items of a batch
+// failed. errors.Is and errors.As look through every item error.
+type BatchError struct {
+	// Total is the number of items in the batch.
+	Total int
+
+	// Failed lists the items that failed.
+	Failed []BatchItemError
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	if len(e.Failed) == 1 {
+		return fmt.Sprintf("1 of %d batch items failed: %v", e.Total, &e.Failed[0])
+	}
+
+	msgs := make([]string, 0, len(e.Failed))
+	for i := range e.Failed {
+		msgs = append(msgs, e.Failed[i].Error())
+	}
+	return fmt.Sprintf("%d of %d batch items failed: %s", len(e.Failed), e.Total, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the item errors.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i := range e.Failed {
+		errs[i] = &e.Failed[i]
+	}
+	return errs
+}
+
+// batchItemResponse is the per-item outcome reported by batch endpoints.
+type batchItemResponse struct {
+	ID      int64  `json:"id"`
+	Success bool   `json:"success"`
+	Status  int    `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// batchResponse is the response body of batch endpoints.
+type batchResponse struct {
+	Results []batchItemResponse `json:"results"`
+	Message string              `json:"message"`
+}
+
+// idResult converts a batch response for ids into a BatchResult. Items the
+// server did not report on are counted as failed.
+func (r *batchResponse) idResult(ids []int64) *BatchResult[int64] {
+	byID := make(map[int64]*batchItemResponse, len(r.Results))
+	for i := range r.Results {
+		byID[r.Results[i].ID] = &r.Results[i]
+	}
+
+	result := &BatchResult[int64]{Message: r.Message}
+	for i, id := range ids {
+		item, ok := byID[id]
+		switch {
+		case !ok:
+			result.Failed = append(result.Failed, BatchItemError{
+				Index: i,
+				ID:    id,
+				Err:   fmt.Errorf("no result reported for file %d", id),
+			})
+		case item.Success:
+			result.Succeeded = append(result.Succeeded, id)
+		default:
+			result.Failed = append(result.Failed, BatchItemError{
+				Index: i,
+				ID:    id,
+				Err:   &APIError{StatusCode: item.Status, Message: item.Error, Code: item.Code},
+			})
+		}
+	}
+	return result
+}
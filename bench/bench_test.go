@@ -0,0 +1,165 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// uploadSizes are the file sizes used by the upload benchmarks.
+var uploadSizes = []int{64 << 10, 4 << 20, 32 << 20}
+
+// zeroReader yields n zero bytes without allocating them up front.
+func zeroReader(n int) io.Reader {
+	return io.LimitReader(zeros{}, int64(n))
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// BenchmarkUploadStreamed measures Files.Upload, which streams the
+// multipart body while the file is read.
+func BenchmarkUploadStreamed(b *testing.B) {
+	client := newServer(b)
+	ctx := context.Background()
+
+	for _, size := range uploadSizes {
+		b.Run(sizeName(size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := client.Files.Upload(ctx, zeroReader(size), &fimage.UploadOptions{
+					Filename: "bench.jpg",
+					Size:     int64(size),
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkUploadBuffered is the baseline for BenchmarkUploadStreamed: the
+// multipart body is assembled in memory before it is sent, as the SDK did
+// before uploads were streamed.
+func BenchmarkUploadBuffered(b *testing.B) {
+	client := newServer(b)
+	ctx := context.Background()
+
+	for _, size := range uploadSizes {
+		b.Run(sizeName(size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var buf bytes.Buffer
+				w := multipart.NewWriter(&buf)
+				part, err := w.CreateFormFile("file", "bench.jpg")
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := io.Copy(part, zeroReader(size)); err != nil {
+					b.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					b.Fatal(err)
+				}
+
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.BaseURL+"/api/files/upload", &buf)
+				if err != nil {
+					b.Fatal(err)
+				}
+				req.Header.Set("Content-Type", w.FormDataContentType())
+				resp, err := client.HTTPClient.Do(req)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var out fimage.UploadResponse
+				err = json.NewDecoder(resp.Body).Decode(&out)
+				resp.Body.Close()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkListDecode measures fetching and decoding a full list page.
+func BenchmarkListDecode(b *testing.B) {
+	client := newServer(b)
+	ctx := context.Background()
+
+	b.SetBytes(int64(len(listBody)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Files.List(ctx, &fimage.ListOptions{Limit: listPageSize})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(resp.Files) != listPageSize {
+			b.Fatalf("got %d files", len(resp.Files))
+		}
+	}
+}
+
+// BenchmarkBatchDeleteConcurrent measures batch deletes of 100 IDs issued
+// from many goroutines at once.
+func BenchmarkBatchDeleteConcurrent(b *testing.B) {
+	client := newServer(b)
+	ctx := context.Background()
+
+	ids := make([]int64, 100)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	for _, workers := range []int{1, 8, 32} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			jobs := make(chan struct{})
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range jobs {
+						result, err := client.Files.BatchDelete(ctx, ids)
+						if err != nil {
+							b.Error(err)
+							continue
+						}
+						if !result.OK() {
+							b.Error(result.Err())
+						}
+					}
+				}()
+			}
+			for i := 0; i < b.N; i++ {
+				jobs <- struct{}{}
+			}
+			close(jobs)
+			wg.Wait()
+		})
+	}
+}
+
+// sizeName formats a byte count for sub-benchmark names.
+func sizeName(n int) string {
+	if n >= 1<<20 {
+		return fmt.Sprintf("%dMiB", n>>20)
+	}
+	return fmt.Sprintf("%dKiB", n>>10)
+}
@@ -0,0 +1,13 @@
+// Package bench holds reproducible benchmarks for the F-Image SDK.
+//
+// The benchmarks run against an in-process fake API server, so results
+// depend only on the SDK and the machine, not on the network. They cover
+// upload throughput (streamed versus buffered multipart), list decoding, and
+// concurrent batch operations.
+//
+// Run them before a release and compare against the previous one with
+// benchstat:
+//
+//	go test ./bench -run '^$' -bench . -benchmem -count 10 > new.txt
+//	benchstat old.txt new.txt
+package bench
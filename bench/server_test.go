@@ -0,0 +1,102 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// listPageSize is the number of files returned per list page.
+const listPageSize = 100
+
+// listBody is a pre-rendered page of listPageSize realistic file records.
+var listBody = func() []byte {
+	files := make([]map[string]any, listPageSize)
+	for i := range files {
+		files[i] = map[string]any{
+			"id":            i + 1,
+			"original_name": fmt.Sprintf("IMG_%04d.jpg", i+1),
+			"url":           fmt.Sprintf("https://i.f-image.com/images/%08x.jpg", i+1),
+			"medium_url":    fmt.Sprintf("https://i.f-image.com/images/%08x_m.jpg", i+1),
+			"thumbnail_url": fmt.Sprintf("https://i.f-image.com/images/%08x_t.jpg", i+1),
+			"mime_type":     "image/jpeg",
+			"size":          2_400_000 + i,
+			"width":         4032,
+			"height":        3024,
+			"description":   strings.Repeat("sunset over the bay ", 3),
+			"created_at":    "2024-05-01T12:00:00Z",
+			"attributes":    map[string]string{"camera": "X100V", "project": "coast"},
+		}
+	}
+	body, err := json.Marshal(map[string]any{"files": files, "total": 10_000, "page": 1, "limit": listPageSize})
+	if err != nil {
+		panic(err)
+	}
+	return body
+}()
+
+// newServer starts a fake API server that serves uploads, list pages, and
+// batch deletes, and returns a client pointed at it.
+func newServer(tb testing.TB) *fimage.Client {
+	tb.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/files/upload":
+			mr, err := r.MultipartReader()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var size int64
+			for {
+				part, err := mr.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				n, _ := io.Copy(io.Discard, part)
+				if part.FormName() == "file" {
+					size = n
+				}
+			}
+			_, _ = fmt.Fprintf(w, `{"success":true,"data":{"id":1,"size":%d}}`, size)
+		case "/api/files":
+			_, _ = w.Write(listBody)
+		case "/api/files/batch-delete":
+			var req struct {
+				FileIDs []int64 `json:"file_ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var b strings.Builder
+			b.WriteString(`{"results":[`)
+			for i, id := range req.FileIDs {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				b.WriteString(`{"id":` + strconv.FormatInt(id, 10) + `,"success":true}`)
+			}
+			b.WriteString(`]}`)
+			_, _ = io.WriteString(w, b.String())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	tb.Cleanup(server.Close)
+
+	return fimage.NewClient("bench-token", fimage.WithBaseURL(server.URL), fimage.WithHTTPClient(server.Client()))
+}
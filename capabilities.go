@@ -0,0 +1,119 @@
+package fimage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Feature names an optional server feature reported by Capabilities.
+type Feature string
+
+const (
+	// FeatureAITagging is automatic tagging of uploaded images.
+	FeatureAITagging Feature = "ai_tagging"
+
+	// FeatureAltText is alt text generation (Files.GenerateAltText).
+	FeatureAltText Feature = "alt_text"
+
+	// FeatureEnhance is AI enhancement (Files.Enhance).
+	FeatureEnhance Feature = "enhance"
+
+	// FeatureVideo is video upload and playback.
+	FeatureVideo Feature = "video"
+
+	// FeatureCollections is multi-album collections.
+	FeatureCollections Feature = "collections"
+
+	// FeatureTransforms is on-the-fly transformation URLs and presets.
+	FeatureTransforms Feature = "transforms"
+
+	// FeatureChangeFeed is the file change feed (Files.Changes).
+	FeatureChangeFeed Feature = "change_feed"
+)
+
+// Capabilities describes what the target instance supports.
+type Capabilities struct {
+	// Version is the server version.
+	Version string `json:"version"`
+
+	// SelfHosted reports whether the instance is self-hosted.
+	SelfHosted bool `json:"self_hosted"`
+
+	// Features maps feature names to whether they are enabled.
+	Features map[Feature]bool `json:"features"`
+
+	// MaxUploadSize is the largest accepted upload in bytes.
+	// Zero means the server did not report it.
+	MaxUploadSize int64 `json:"max_upload_size"`
+
+	// Variants lists the size variants the instance generates.
+	Variants []Variant `json:"variants"`
+
+	// Legacy is set when the server predates capability discovery. Only the
+	// core API is assumed to be available.
+	Legacy bool `json:"-"`
+}
+
+// Supports reports whether a feature is enabled on the instance.
+func (c *Capabilities) Supports(feature Feature) bool {
+	return c.Features[feature]
+}
+
+// Capabilities returns the features and limits of the target instance, so
+// one codebase can adapt to both SaaS and older self-hosted servers. Servers
+// that predate capability discovery yield Legacy capabilities with no
+// optional features.
+//
+// The result is remembered by the client: afterwards, methods that need an
+// unsupported feature fail fast with a *NotSupportedError instead of making
+// the request.
+//
+// Example:
+//
+//	caps, err := client.Capabilities(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if caps.Supports(fimage.FeatureAltText) {
+//	    suggestion, err := client.Files.GenerateAltText(ctx, fileID, "en")
+//	}
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	var caps Capabilities
+	if err := c.request(ctx, http.MethodGet, "/api/capabilities", nil, &caps); err != nil {
+		if !IsNotFound(err) {
+			return nil, err
+		}
+		caps = Capabilities{
+			Variants: []Variant{VariantOriginal, VariantMedium, VariantThumbnail},
+			Legacy:   true,
+		}
+	}
+	if caps.Features == nil {
+		caps.Features = map[Feature]bool{}
+	}
+	c.capabilities.Store(&caps)
+
+	return &caps, nil
+}
+
+// featureRequest is like request for endpoints that belong to an optional
+// feature. It fails fast when cached capabilities rule the feature out and
+// reports missing endpoints as *NotSupportedError.
+func (c *Client) featureRequest(ctx context.Context, feature Feature, method, path string, body interface{}, result interface{}) error {
+	if caps := c.capabilities.Load(); caps != nil && !caps.Supports(feature) {
+		return &NotSupportedError{Feature: feature}
+	}
+
+	err := c.request(ctx, method, path, body, result)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		missing := apiErr.StatusCode == http.StatusNotImplemented ||
+			(apiErr.StatusCode == http.StatusNotFound && apiErr.Code == "")
+		if missing {
+			return &NotSupportedError{Feature: feature, Err: err}
+		}
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCapabilitiesDecodesFeaturesAndLimits(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/capabilities" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"version":"2.4.1",
+			"self_hosted":true,
+			"features":{"alt_text":true,"enhance":false,"hologram_export":true},
+			"max_upload_size":52428800,
+			"variants":["original","medium"]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities returned error: %v", err)
+	}
+	if caps.Version != "2.4.1" || !caps.SelfHosted || caps.Legacy || caps.MaxUploadSize != 52428800 {
+		t.Fatalf("unexpected capabilities: %+v", caps)
+	}
+	if len(caps.Variants) != 2 || caps.Variants[1] != VariantMedium {
+		t.Fatalf("unexpected variants: %v", caps.Variants)
+	}
+
+	tests := []struct {
+		feature Feature
+		want    bool
+	}{
+		{FeatureAltText, true},
+		{FeatureEnhance, false},
+		{FeatureVideo, false},
+		{"hologram_export", true},
+		{"teleport", false},
+	}
+	for _, tt := range tests {
+		if got := caps.Supports(tt.feature); got != tt.want {
+			t.Errorf("Supports(%q) = %v, want %v", tt.feature, got, tt.want)
+		}
+	}
+}
+
+func TestCapabilitiesFallsBackToLegacy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities returned error: %v", err)
+	}
+	if !caps.Legacy || caps.Supports(FeatureAltText) || len(caps.Variants) != 3 {
+		t.Fatalf("unexpected legacy capabilities: %+v", caps)
+	}
+}
@@ -0,0 +1,137 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ChangeType is the kind of change recorded in the change feed.
+type ChangeType string
+
+const (
+	// ChangeCreated means a file was uploaded.
+	ChangeCreated ChangeType = "created"
+
+	// ChangeUpdated means a file's metadata changed.
+	ChangeUpdated ChangeType = "updated"
+
+	// ChangeTrashed means a file was moved to the trash.
+	ChangeTrashed ChangeType = "trashed"
+
+	// ChangeRestored means a file was restored from the trash.
+	ChangeRestored ChangeType = "restored"
+
+	// ChangePurged means a file was permanently deleted. Purge records are
+	// tombstones: they carry only the file ID and purge time.
+	ChangePurged ChangeType = "purged"
+)
+
+// Change is a single entry in the change feed.
+type Change struct {
+	// Type is the kind of change.
+	Type ChangeType `json:"type"`
+
+	// FileID is the ID of the changed file.
+	FileID int64 `json:"file_id"`
+
+	// At is when the change happened. For tombstones it is the purge time.
+	At time.Time `json:"at"`
+
+	// File is the file state after the change. It is nil for tombstones.
+	File *File `json:"file,omitempty"`
+}
+
+// Tombstone reports whether the change records a permanently deleted file.
+// Downstream caches and search indexes should evict FileID.
+func (c *Change) Tombstone() bool {
+	return c.Type == ChangePurged
+}
+
+// ChangesOptions contains options for reading the change feed.
+type ChangesOptions struct {
+	// Cursor resumes the feed after the last change of a previous page.
+	// Empty starts from the oldest retained change.
+	Cursor string
+
+	// Limit is the number of changes per page (max 100).
+	Limit int
+}
+
+// Validate checks the options for invalid fields.
+func (opts *ChangesOptions) Validate() error {
+	var v validator
+	v.paging(0, opts.Limit)
+	return v.err()
+}
+
+// ChangesResponse is a page of the change feed.
+type ChangesResponse struct {
+	// Changes are the changes in the order they happened.
+	Changes []Change `json:"changes"`
+
+	// NextCursor resumes the feed after the last change in this page.
+	// Persist it to continue where you left off.
+	NextCursor string `json:"next_cursor"`
+
+	// HasMore reports whether more changes are available right now.
+	HasMore bool `json:"has_more"`
+}
+
+// Changes returns file changes in the order they happened, including
+// tombstones for permanently deleted files, so downstream caches and search
+// indexes can be kept in sync deterministically.
+//
+// Example:
+//
+//	cursor := loadCursor()
+//	for {
+//	    page, err := client.Files.Changes(ctx, &fimage.ChangesOptions{Cursor: cursor})
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, change := range page.Changes {
+//	        if change.Tombstone() {
+//	            index.Delete(change.FileID)
+//	            continue
+//	        }
+//	        index.Put(change.File)
+//	    }
+//	    cursor = page.NextCursor
+//	    saveCursor(cursor)
+//	    if !page.HasMore {
+//	        break
+//	    }
+//	}
+func (s *FilesService) Changes(ctx context.Context, opts *ChangesOptions, callOpts ...CallOption) (*ChangesResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	query := url.Values{}
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+		if opts.Cursor != "" {
+			query.Set("cursor", opts.Cursor)
+		}
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	setDefaultLimit(ctx, query)
+
+	path := "/api/files/changes"
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+
+	var resp ChangesResponse
+	if err := s.client.featureRequest(ctx, FeatureChangeFeed, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,66 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChangesFollowsCursorAndDecodesTombstones(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/files/changes" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			if got := r.URL.Query().Get("limit"); got != "2" {
+				t.Errorf("unexpected limit: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"changes":[
+				{"type":"updated","file_id":1,"at":"2024-05-01T10:00:00Z","file":{"id":1,"original_name":"a.jpg"}},
+				{"type":"purged","file_id":2,"at":"2024-05-01T11:00:00Z"}
+			],"next_cursor":"c2","has_more":true}`))
+		case "c2":
+			_, _ = w.Write([]byte(`{"changes":[],"next_cursor":"c2","has_more":false}`))
+		default:
+			t.Errorf("unexpected cursor: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	page, err := client.Files.Changes(context.Background(), &ChangesOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("Changes returned error: %v", err)
+	}
+	if len(page.Changes) != 2 || page.NextCursor != "c2" || !page.HasMore {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+
+	update := page.Changes[0]
+	if update.Tombstone() || update.File == nil || update.File.OriginalName != "a.jpg" {
+		t.Fatalf("unexpected update: %+v", update)
+	}
+
+	purge := page.Changes[1]
+	if !purge.Tombstone() || purge.FileID != 2 || purge.File != nil {
+		t.Fatalf("unexpected tombstone: %+v", purge)
+	}
+	if !purge.At.Equal(time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected purge time: %s", purge.At)
+	}
+
+	page, err = client.Files.Changes(context.Background(), &ChangesOptions{Cursor: page.NextCursor})
+	if err != nil {
+		t.Fatalf("Changes returned error: %v", err)
+	}
+	if len(page.Changes) != 0 || page.HasMore {
+		t.Fatalf("unexpected last page: %+v", page)
+	}
+}
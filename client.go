@@ -9,7 +9,12 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,6 +22,9 @@ const (
 	// DefaultBaseURL is the default F-Image API base URL.
 	DefaultBaseURL = "https://f-image.com"
 
+	// SandboxBaseURL is the base URL of the F-Image sandbox environment.
+	SandboxBaseURL = "https://sandbox.f-image.com"
+
 	// DefaultTimeout is the default HTTP client timeout.
 	DefaultTimeout = 30 * time.Second
 
@@ -38,13 +46,82 @@ type Client struct {
 	// userAgent is the User-Agent header value.
 	userAgent string
 
+	// locale is the Accept-Language header value.
+	locale string
+
+	// appInfo identifies the application built on the SDK.
+	appInfo string
+
+	// disableTelemetry suppresses the X-SDK-* headers.
+	disableTelemetry bool
+
+	// tolerantDecoding skips malformed records in list responses.
+	tolerantDecoding bool
+
+	// signingKey is the secret used by SignURL.
+	signingKey []byte
+
+	// clockSkew is the tolerated drift between local and server clocks.
+	clockSkew time.Duration
+
+	// encryptionKey enables client-side encryption of uploads.
+	encryptionKey []byte
+
+	// codec is the preferred response encoding; nil means JSON only.
+	codec Codec
+
+	// capabilities caches the result of the last Capabilities call.
+	capabilities atomic.Pointer[Capabilities]
+
+	// albumSettings caches album upload settings by album ID.
+	albumSettings sync.Map
+
+	// clockOffset is the measured server-minus-local clock offset in
+	// nanoseconds, set by SyncClock.
+	clockOffset atomic.Int64
+
+	// life tracks in-flight work for Close and Shutdown.
+	life *lifecycle
+
+	// limiter paces requests; nil means unlimited.
+	limiter RateLimiter
+
+	// rateLimit is the server rate limit state from the latest response.
+	rateLimit atomic.Pointer[RateLimit]
+
+	// rateLimitWait waits for rate limit resets instead of failing.
+	rateLimitWait bool
+
+	// defaultHeaders are added to every request.
+	defaultHeaders http.Header
+
+	// middleware wraps every HTTP request, outermost first.
+	middleware []Middleware
+
+	// marshalJSON and unmarshalJSON encode request and decode response
+	// bodies.
+	marshalJSON   MarshalFunc
+	unmarshalJSON UnmarshalFunc
+
+	// serviceDefaults are the call defaults of each service.
+	serviceDefaults map[Service]Defaults
+
 	// Services
-	Files  *FilesService
-	Logos  *LogosService
-	Albums *AlbumsService
-	Share  *ShareService
-	Tags   *TagsService
-	Trash  *TrashService
+	Files         *FilesService
+	Logos         *LogosService
+	Albums        *AlbumsService
+	Share         *ShareService
+	Tags          *TagsService
+	Trash         *TrashService
+	Jobs          *JobsService
+	Account       *AccountService
+	Gallery       *GalleryService
+	Transforms    *TransformsService
+	Presets       *PresetsService
+	Encryption    *EncryptionService
+	Admin         *AdminService
+	Pins          *PinsService
+	Notifications *NotificationsService
 }
 
 // ClientOption is a function that configures the Client.
@@ -78,6 +155,104 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithAppInfo identifies your application to F-Image support.
+//
+// The name and version are appended to the User-Agent header and sent in
+// the X-SDK-App header, which helps platform support find your integration
+// during incident triage.
+func WithAppInfo(name, version string) ClientOption {
+	return func(c *Client) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		c.appInfo = name
+		if version = strings.TrimSpace(version); version != "" {
+			c.appInfo = name + "/" + version
+		}
+	}
+}
+
+// WithoutTelemetry disables the X-SDK-* headers that report the SDK
+// version, Go runtime, and application identity.
+func WithoutTelemetry() ClientOption {
+	return func(c *Client) {
+		c.disableTelemetry = true
+	}
+}
+
+// WithSandbox points the client at the F-Image sandbox environment.
+//
+// The sandbox is isolated from production data and is seeded with fixture
+// albums, tags, and files, so it is safe for end-to-end tests. Use a
+// sandbox token (prefixed with "fimg_test_") with this option.
+func WithSandbox() ClientOption {
+	return func(c *Client) {
+		c.BaseURL = SandboxBaseURL
+	}
+}
+
+// WithLocale sets the preferred language for server messages, for example
+// "zh-CN". It is sent as the Accept-Language header on every request.
+func WithLocale(locale string) ClientOption {
+	return func(c *Client) {
+		c.locale = strings.TrimSpace(locale)
+	}
+}
+
+// WithTolerantDecoding makes file, trash, and share list calls skip records
+// that fail to decode instead of failing the whole call. Skipped records are
+// reported in the Warnings field of the list response and by
+// Iterator.Warnings.
+//
+// It covers the calls returning a FilesListResponse (Files.List, Files.Search,
+// Files.SearchByColor, Files.SearchByAttribute, and Tags.GetFiles),
+// Trash.List, Share.List, and their ListAll iterators. Other list calls, such
+// as Albums.List or Tags.List, still fail on the first malformed record.
+func WithTolerantDecoding() ClientOption {
+	return func(c *Client) {
+		c.tolerantDecoding = true
+	}
+}
+
+// WithSigningKey sets the URL signing secret from the dashboard, enabling
+// SignURL.
+func WithSigningKey(key string) ClientOption {
+	return func(c *Client) {
+		c.signingKey = []byte(key)
+	}
+}
+
+// WithClockSkew sets how far the local clock may drift from the server clock
+// before signed URLs fail validation (default: DefaultClockSkew).
+func WithClockSkew(skew time.Duration) ClientOption {
+	return func(c *Client) {
+		if skew >= 0 {
+			c.clockSkew = skew
+		}
+	}
+}
+
+// WithDefaultHeaders adds headers to every request, including multipart
+// uploads, e.g. a tenant ID required by an enterprise gateway. Per-call
+// headers set with WithHeader take precedence.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token", fimage.WithDefaultHeaders(map[string]string{
+//	    "X-Tenant-ID": "acme",
+//	}))
+func WithDefaultHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = http.Header{}
+		}
+		for key, value := range headers {
+			c.defaultHeaders.Set(key, value)
+		}
+	}
+}
+
 // NewClient creates a new F-Image API client.
 //
 // The apiToken is required and can be obtained from your F-Image dashboard
@@ -96,10 +271,16 @@ func NewClient(apiToken string, opts ...ClientOption) *Client {
 	c := &Client{
 		BaseURL: DefaultBaseURL,
 		HTTPClient: &http.Client{
-			Timeout: DefaultTimeout,
+			Timeout:   DefaultTimeout,
+			Transport: newTransport(),
 		},
 		apiToken:  apiToken,
 		userAgent: fmt.Sprintf("f-image-go/%s", Version),
+		clockSkew: DefaultClockSkew,
+		life:      newLifecycle(),
+
+		marshalJSON:   json.Marshal,
+		unmarshalJSON: json.Unmarshal,
 	}
 
 	// Apply options
@@ -114,22 +295,63 @@ func NewClient(apiToken string, opts ...ClientOption) *Client {
 	c.Share = &ShareService{client: c}
 	c.Tags = &TagsService{client: c}
 	c.Trash = &TrashService{client: c}
+	c.Jobs = &JobsService{client: c}
+	c.Account = &AccountService{client: c}
+	c.Gallery = &GalleryService{client: c}
+	c.Transforms = &TransformsService{client: c}
+	c.Presets = &PresetsService{client: c}
+	c.Encryption = &EncryptionService{client: c}
+	c.Admin = &AdminService{client: c}
+	c.Pins = &PinsService{client: c}
+	c.Notifications = &NotificationsService{client: c}
 
 	return c
 }
 
-// request performs an HTTP request and decodes the response.
+// request performs an HTTP request and decodes the response. With
+// WithRateLimitWait, requests rejected with 429 are retried after the rate
+// limit window resets; with WithRetries, failed requests are retried with
+// backoff.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	ctx, end, err := c.start(ctx)
+	if err != nil {
+		return err
+	}
+	defer end()
+
 	// Build URL
 	reqURL := c.BaseURL + path
 
 	// Prepare request body
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
+		if jsonBody, err = c.marshalJSON(body); err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.pace(ctx); err != nil {
+			return err
+		}
+		err := c.send(ctx, method, reqURL, jsonBody, result)
+		wait, retry := c.rateLimitBackoff(err, attempt)
+		if !retry {
+			wait, retry = retryBackoff(ctx, method, err, attempt)
+		}
+		if !retry {
+			return err
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// send performs a single attempt of request with an encoded JSON body.
+func (c *Client) send(ctx context.Context, method, reqURL string, jsonBody []byte, result interface{}) error {
+	var bodyReader io.Reader
+	if jsonBody != nil {
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
@@ -140,34 +362,38 @@ func (c *Client) request(ctx context.Context, method, path string, body interfac
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("User-Agent", c.userAgent)
-	if body != nil {
+	c.setHeaders(req)
+	if jsonBody != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	req.Header.Set("Accept", "application/json")
 
 	// Execute request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp)
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
+	captureResponse(ctx, resp, respBody)
+	raw := respBody
+	if respBody, err = c.normalizeBody(resp, respBody); err != nil {
+		return err
+	}
 
 	// Check for errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return parseAPIError(resp.StatusCode, respBody)
+		return parseAPIError(resp, raw, respBody)
 	}
 
 	// Decode response
 	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
+		if err := c.decode(respBody, result); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
 	}
@@ -183,53 +409,126 @@ func (c *Client) requestWithQuery(ctx context.Context, path string, query url.Va
 	return c.request(ctx, http.MethodGet, path, nil, result)
 }
 
-// uploadMultipart performs a multipart file upload.
-func (c *Client) uploadMultipart(ctx context.Context, path string, reader io.Reader, filename string, fields map[string]string) ([]byte, error) {
-	// Create multipart writer
-	var buf bytes.Buffer
-	writer := multipart.NewWriter(&buf)
+// requestStream performs an HTTP request with a raw body and returns the
+// response for the caller to stream. The caller must close the response body.
+// accept replaces the codec's Accept header, since a streamed body is never
+// normalized to JSON. Non-2xx responses are converted to an *APIError.
+func (c *Client) requestStream(ctx context.Context, method, path string, body io.Reader, contentType, accept string) (_ *http.Response, err error) {
+	ctx, end, err := c.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			end()
+		}
+	}()
+	if err := c.pace(ctx); err != nil {
+		return nil, err
+	}
 
-	// Add fields before the file so streaming servers can inspect metadata first.
-	for key, value := range fields {
-		if err := writer.WriteField(key, value); err != nil {
-			return nil, fmt.Errorf("failed to write field %s: %w", key, err)
+	// Build URL
+	reqURL := c.BaseURL + path
+
+	// Create request
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	c.setHeaders(req)
+	req.Header.Set("Accept", accept)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	// Execute request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	c.recordRateLimit(resp)
+
+	// Check for errors
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		captureResponse(ctx, resp, respBody)
+		raw := respBody
+		if respBody, err = c.normalizeBody(resp, respBody); err != nil {
+			return nil, err
 		}
+		return nil, parseAPIError(resp, raw, respBody)
 	}
 
-	// Add file field
-	part, err := writer.CreateFormFile("file", filename)
+	captureResponse(ctx, resp, nil)
+	resp.Body = &lifecycleBody{ReadCloser: resp.Body, end: end}
+	return resp, nil
+}
+
+// uploadMultipart performs a multipart file upload. The body is streamed to
+// the server while the file is read, so the file is never held in memory.
+// size is the file length in bytes, or -1 if unknown; when it is known (or can
+// be detected from the reader) the request carries a Content-Length instead of
+// using chunked transfer encoding.
+func (c *Client) uploadMultipart(ctx context.Context, path string, reader io.Reader, filename string, fields map[string]string, size int64) ([]byte, error) {
+	ctx, end, err := c.start(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, err
 	}
-	if _, err := io.Copy(part, reader); err != nil {
-		return nil, fmt.Errorf("failed to copy file data: %w", err)
+	defer end()
+	if err := c.pace(ctx); err != nil {
+		return nil, err
 	}
 
-	// Close writer
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	if size < 0 {
+		size = readerSize(reader)
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	writer := multipart.NewWriter(pw)
+
+	contentLength := int64(-1)
+	if size >= 0 {
+		overhead, err := multipartOverhead(writer.Boundary(), filename, fields)
+		if err != nil {
+			return nil, err
+		}
+		contentLength = overhead + size
 	}
 
 	// Build URL
 	reqURL := c.BaseURL + path
 
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, &buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, pr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	if contentLength >= 0 {
+		req.ContentLength = contentLength
+	}
 
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("User-Agent", c.userAgent)
+	c.setHeaders(req)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("Accept", "application/json")
+
+	// Produce the body while the HTTP client sends it
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, reader, filename, fields))
+	}()
 
 	// Execute request
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	c.recordRateLimit(resp)
 	defer resp.Body.Close()
 
 	// Read response body
@@ -237,31 +536,159 @@ func (c *Client) uploadMultipart(ctx context.Context, path string, reader io.Rea
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	captureResponse(ctx, resp, respBody)
+	raw := respBody
+	if respBody, err = c.normalizeBody(resp, respBody); err != nil {
+		return nil, err
+	}
 
 	// Check for errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, parseAPIError(resp.StatusCode, respBody)
+		return nil, parseAPIError(resp, raw, respBody)
 	}
 
 	return respBody, nil
 }
 
-// parseAPIError parses an API error response.
-func parseAPIError(statusCode int, body []byte) error {
+// writeMultipart writes the form fields followed by the file part to w and
+// closes it. Fields come first so streaming servers can inspect metadata
+// before the file data arrives.
+func writeMultipart(w *multipart.Writer, reader io.Reader, filename string, fields map[string]string) error {
+	for key, value := range fields {
+		if err := w.WriteField(key, value); err != nil {
+			return fmt.Errorf("failed to write field %s: %w", key, err)
+		}
+	}
+
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	if reader != nil {
+		if _, err := io.Copy(part, reader); err != nil {
+			return fmt.Errorf("failed to copy file data: %w", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	return nil
+}
+
+// multipartOverhead returns the number of bytes writeMultipart adds around
+// the file data for the given boundary.
+func multipartOverhead(boundary, filename string, fields map[string]string) (int64, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(boundary); err != nil {
+		return 0, fmt.Errorf("failed to set multipart boundary: %w", err)
+	}
+	if err := writeMultipart(w, nil, filename, fields); err != nil {
+		return 0, err
+	}
+	return int64(buf.Len()), nil
+}
+
+// readerSize returns the number of bytes left in r, or -1 if it cannot be
+// determined without reading.
+func readerSize(r io.Reader) int64 {
+	switch v := r.(type) {
+	case *bytes.Reader:
+		return int64(v.Len())
+	case *bytes.Buffer:
+		return int64(v.Len())
+	case *strings.Reader:
+		return int64(v.Len())
+	case *os.File:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return info.Size() - offset
+	}
+	return -1
+}
+
+// setHeaders sets the headers shared by all API requests.
+func (c *Client) setHeaders(req *http.Request) {
+	userAgent := c.userAgent
+	if c.appInfo != "" {
+		userAgent = userAgent + " " + c.appInfo
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", c.accept())
+	if id := requestIDFor(req.Context()); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	if c.locale != "" {
+		req.Header.Set("Accept-Language", c.locale)
+	}
+
+	if !c.disableTelemetry {
+		req.Header.Set("X-SDK-Name", "f-image-go")
+		req.Header.Set("X-SDK-Version", Version)
+		req.Header.Set("X-SDK-Runtime", runtime.Version())
+		if c.appInfo != "" {
+			req.Header.Set("X-SDK-App", c.appInfo)
+		}
+	}
+	for key, values := range c.defaultHeaders {
+		req.Header[key] = values
+	}
+	for key, values := range requestOptionsFrom(req.Context()).header {
+		req.Header[key] = values
+	}
+}
+
+// parseAPIError parses an API error response. raw is the body as received
+// and body the same body normalized to JSON.
+func parseAPIError(resp *http.Response, raw, body []byte) error {
+	statusCode, header := resp.StatusCode, resp.Header
+
+	var rateLimit *RateLimit
+	if statusCode == http.StatusTooManyRequests {
+		rateLimit = parseRateLimit(header)
+	}
+
+	requestID := header.Get(RequestIDHeader)
+	var method, path string
+	if resp.Request != nil {
+		if requestID == "" {
+			requestID = resp.Request.Header.Get(RequestIDHeader)
+		}
+		method, path = resp.Request.Method, resp.Request.URL.Path
+	}
 	var errResp struct {
-		Error               string     `json:"error"`
-		Message             string     `json:"message"`
-		URL                 string     `json:"url"`
-		UploadType          UploadType `json:"upload_type"`
-		Domain              string     `json:"domain"`
-		Exists              bool       `json:"exists"`
-		ForceUpdateRequired bool       `json:"force_update_required"`
+		Error               string                 `json:"error"`
+		Message             string                 `json:"message"`
+		Code                string                 `json:"code"`
+		Details             map[string]interface{} `json:"details"`
+		URL                 string                 `json:"url"`
+		UploadType          UploadType             `json:"upload_type"`
+		Domain              string                 `json:"domain"`
+		Exists              bool                   `json:"exists"`
+		ForceUpdateRequired bool                   `json:"force_update_required"`
+		AttemptsRemaining   *int                   `json:"attempts_remaining"`
+		RetryAfter          int64                  `json:"retry_after"`
 	}
 
 	if err := json.Unmarshal(body, &errResp); err != nil {
 		return &APIError{
 			StatusCode: statusCode,
 			Message:    string(body),
+			RetryAfter: parseRetryAfter(header.Get("Retry-After")),
+			RequestID:  requestID,
+			RateLimit:  rateLimit,
+			Method:     method,
+			Path:       path,
+			Body:       raw,
 		}
 	}
 
@@ -273,13 +700,47 @@ func parseAPIError(statusCode int, body []byte) error {
 		msg = http.StatusText(statusCode)
 	}
 
+	retryAfter := time.Duration(errResp.RetryAfter) * time.Second
+	if retryAfter <= 0 {
+		retryAfter = parseRetryAfter(header.Get("Retry-After"))
+	}
+
 	return &APIError{
 		StatusCode:          statusCode,
 		Message:             msg,
+		Code:                errResp.Code,
+		Details:             errResp.Details,
 		URL:                 errResp.URL,
 		UploadType:          errResp.UploadType,
 		Domain:              errResp.Domain,
 		Exists:              errResp.Exists,
 		ForceUpdateRequired: errResp.ForceUpdateRequired,
+		AttemptsRemaining:   errResp.AttemptsRemaining,
+		RetryAfter:          retryAfter,
+		RequestID:           requestID,
+		RateLimit:           rateLimit,
+		Method:              method,
+		Path:                path,
+		Body:                raw,
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
 	}
+	return 0
 }
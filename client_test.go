@@ -0,0 +1,844 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithLocaleSetsAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Language"); got != "zh-CN" {
+			t.Fatalf("unexpected Accept-Language: %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"文件不存在","code":"file_not_found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithLocale("zh-CN"))
+
+	_, err := client.Files.Delete(context.Background(), 1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.Code != "file_not_found" {
+		t.Fatalf("unexpected code: %q", apiErr.Code)
+	}
+	if !IsCode(err, CodeFileNotFound) {
+		t.Fatalf("expected IsCode to match %q", CodeFileNotFound)
+	}
+	if apiErr.Message != "文件不存在" {
+		t.Fatalf("unexpected message: %q", apiErr.Message)
+	}
+}
+
+func TestTolerantDecodingSkipsMalformedRecords(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1,"original_name":"a.jpg"},{"id":"bad"},{"id":3,"original_name":"c.jpg"}],"total":3,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	strict := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	if _, err := strict.Files.List(context.Background(), nil); err == nil {
+		t.Fatal("expected strict decoding to fail")
+	}
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithTolerantDecoding())
+	resp, err := client.Files.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(resp.Files) != 2 || resp.Files[0].ID != 1 || resp.Files[1].ID != 3 {
+		t.Fatalf("unexpected files: %+v", resp.Files)
+	}
+	if resp.Total != 3 {
+		t.Fatalf("unexpected total: %d", resp.Total)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Index != 1 {
+		t.Fatalf("unexpected warnings: %+v", resp.Warnings)
+	}
+}
+
+func TestWithAppInfoSetsUserAgentAndSDKHeaders(t *testing.T) {
+	t.Parallel()
+
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"albums":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithAppInfo("gallery", "2.1.0"))
+	if _, err := client.Albums.List(context.Background()); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got, want := header.Get("User-Agent"), "f-image-go/"+Version+" gallery/2.1.0"; got != want {
+		t.Fatalf("unexpected User-Agent: %q, want %q", got, want)
+	}
+	if got := header.Get("X-SDK-App"); got != "gallery/2.1.0" {
+		t.Fatalf("unexpected X-SDK-App: %q", got)
+	}
+	if got := header.Get("X-SDK-Version"); got != Version {
+		t.Fatalf("unexpected X-SDK-Version: %q", got)
+	}
+
+	client = NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithAppInfo("gallery", "2.1.0"), WithoutTelemetry())
+	if _, err := client.Albums.List(context.Background()); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got := header.Get("X-SDK-Version"); got != "" {
+		t.Fatalf("expected no X-SDK-Version header, got %q", got)
+	}
+}
+
+func TestPingReturnsAPIErrorWhenUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/health" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"maintenance"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	err := client.Ping(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 APIError, got %v", err)
+	}
+}
+
+func TestSignURLUsesSyncedClockAndSkew(t *testing.T) {
+	t.Parallel()
+
+	serverNow := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/time" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"time":"` + serverNow.Format(time.RFC3339) + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithSigningKey("secret"),
+		WithClockSkew(time.Minute),
+	)
+
+	if _, err := client.SyncClock(context.Background()); err != nil {
+		t.Fatalf("SyncClock returned error: %v", err)
+	}
+
+	signed, err := client.SignURL("https://cdn.f-image.com/u/1/photo.jpg", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("SignURL returned error: %v", err)
+	}
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("invalid URL %q: %v", signed, err)
+	}
+
+	nbf, _ := strconv.ParseInt(u.Query().Get("nbf"), 10, 64)
+	exp, _ := strconv.ParseInt(u.Query().Get("exp"), 10, 64)
+	wantNbf := serverNow.Add(-time.Minute).Unix()
+	if nbf < wantNbf-2 || nbf > wantNbf+2 {
+		t.Fatalf("nbf %d not near %d", nbf, wantNbf)
+	}
+	if exp-nbf != int64((12 * time.Minute).Seconds()) {
+		t.Fatalf("unexpected validity window: %ds", exp-nbf)
+	}
+	if u.Query().Get("sig") == "" {
+		t.Fatal("missing signature")
+	}
+}
+
+func TestRequestIDIsForwardedAndReportedInAPIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(RequestIDHeader); got != "trace-123" {
+			t.Fatalf("unexpected %s: %q", RequestIDHeader, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"boom"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Tags.List(WithRequestID(context.Background(), "trace-123"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "trace-123" {
+		t.Fatalf("expected APIError with request ID, got %v", err)
+	}
+}
+
+func TestGeneratedRequestIDIsReusedByRetries(t *testing.T) {
+	t.Parallel()
+
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		if len(ids) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	if _, err := client.Files.Get(context.Background(), 1, WithRetries(1)); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+		t.Fatalf("expected one request ID across attempts, got %q", ids)
+	}
+
+	if _, err := client.Files.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if ids[2] == ids[0] {
+		t.Fatalf("expected a new request ID per call, got %q", ids)
+	}
+}
+
+func TestFeatureMethodsReportNotSupported(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/capabilities" {
+			_, _ = w.Write([]byte(`{"version":"2.0","features":{"alt_text":true}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotImplemented)
+		_, _ = w.Write([]byte(`{"error":"not implemented"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	_, err := client.Files.GenerateAltText(ctx, 1, "en")
+	var notSupported *NotSupportedError
+	if !errors.As(err, &notSupported) || notSupported.Feature != FeatureAltText || notSupported.Err == nil {
+		t.Fatalf("expected NotSupportedError from 501, got %v", err)
+	}
+
+	if _, err := client.Capabilities(ctx); err != nil {
+		t.Fatalf("Capabilities returned error: %v", err)
+	}
+	before := atomic.LoadInt32(&calls)
+	_, err = client.Files.Enhance(ctx, 1, &EnhanceOptions{Denoise: true})
+	if !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("expected ErrNotSupported from capabilities, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != before {
+		t.Fatal("expected no request for a feature ruled out by capabilities")
+	}
+}
+
+func TestWithRawResponseCapturesBodyAndDecodes(t *testing.T) {
+	t.Parallel()
+
+	body := `{"id":7,"original_name":"a.jpg","extra":"kept"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var raw []byte
+	ctx := WithRequestOptions(context.Background(), WithRawResponse(&raw))
+	file, err := client.Files.Get(ctx, 7)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if file.ID != 7 {
+		t.Fatalf("unexpected file ID: %d", file.ID)
+	}
+	if string(raw) != body {
+		t.Fatalf("unexpected raw response: %s", raw)
+	}
+}
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	listErr := make(chan error, 1)
+	go func() {
+		_, err := client.Tags.List(context.Background())
+		listErr <- err
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- client.Close() }()
+
+	<-client.Done()
+	if _, err := client.Tags.List(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("expected ErrClientClosed after shutdown began, got %v", err)
+	}
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("Close returned before in-flight request finished: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-listErr; err != nil {
+		t.Fatalf("in-flight request failed: %v", err)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+}
+
+func TestShutdownDeadlineCancelsInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	listErr := make(chan error, 1)
+	go func() {
+		_, err := client.Tags.List(context.Background())
+		listErr <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := client.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if err := <-listErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected in-flight request to be canceled, got %v", err)
+	}
+}
+
+func TestRateLimitHeadersAreTrackedAndWaited(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+		if n < 3 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"rate limit exceeded"}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		_, _ = w.Write([]byte(`{"albums":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	if client.RateLimit() != nil {
+		t.Fatal("expected no rate limit before the first response")
+	}
+
+	_, err := client.Albums.List(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RateLimit == nil || apiErr.RateLimit.Remaining != 0 || apiErr.RateLimit.Limit != 100 {
+		t.Fatalf("expected 429 with rate limit, got %v", err)
+	}
+	if rl := client.RateLimit(); rl == nil || rl.Remaining != 0 {
+		t.Fatalf("unexpected client rate limit: %+v", rl)
+	}
+
+	waiting := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithRateLimitWait(true))
+	if _, err := waiting.Albums.List(context.Background()); err != nil {
+		t.Fatalf("expected the 429 to be retried, got %v", err)
+	}
+	if rl := waiting.RateLimit(); rl == nil || rl.Remaining != 99 {
+		t.Fatalf("unexpected client rate limit: %+v", rl)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestDoCallsUntypedEndpoints(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("missing authorization header")
+		}
+		if r.URL.Path != "/api/files/7/palette" || r.URL.Query().Get("n") != "3" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"palette":["#112233","#445566","#778899"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var out struct {
+		Palette []string `json:"palette"`
+	}
+	if err := client.Do(context.Background(), http.MethodGet, "/api/files/7/palette?n=3", nil, &out); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if len(out.Palette) != 3 {
+		t.Fatalf("unexpected palette: %v", out.Palette)
+	}
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/api/files/7/palette?n=3", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if err := client.Do(context.Background(), http.MethodGet, "api/files", nil, nil); err == nil {
+		t.Fatal("expected an error for a path without a leading slash")
+	}
+}
+
+func TestCustomHeadersApplyToUploads(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant-ID"); got != "acme" {
+			t.Errorf("unexpected X-Tenant-ID: %q", got)
+		}
+		if got := r.Header.Get("X-Cost-Center"); got != "marketing" {
+			t.Errorf("unexpected X-Cost-Center: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"status":200,"data":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()),
+		WithDefaultHeaders(map[string]string{"X-Tenant-ID": "acme", "X-Cost-Center": "default"}))
+
+	ctx := WithRequestOptions(context.Background(), WithHeader("X-Cost-Center", "marketing"))
+	if _, err := client.Files.Upload(ctx, strings.NewReader("data"), &UploadOptions{Filename: "a.png"}); err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+}
+
+func TestCallOptionsApplyToSingleCall(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/files/2" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		if r.URL.Path == "/api/files/1" {
+			if got := r.Header.Get(IdempotencyKeyHeader); got != "op-1" {
+				t.Errorf("unexpected Idempotency-Key: %q", got)
+			}
+			if got := r.Header.Get(RequestIDHeader); got != "trace-1" {
+				t.Errorf("unexpected X-Request-ID: %q", got)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var raw []byte
+	_, err := client.Files.Get(context.Background(), 1,
+		WithIdempotencyKey("op-1"), WithTraceID("trace-1"), WithRawResponse(&raw))
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if string(raw) != `{"id":1}` {
+		t.Fatalf("unexpected raw response: %s", raw)
+	}
+
+	_, err = client.Files.Get(context.Background(), 2, WithCallTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestCallTimeoutCoversRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	start := time.Now()
+	_, err := client.Files.Get(context.Background(), 1, WithRetries(5), WithCallTimeout(100*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("call outlived its timeout: %v", elapsed)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected 1 request before the deadline, got %d", n)
+	}
+}
+
+// slowServer answers every request with body after delay, counting the
+// requests it receives.
+func slowServer(t *testing.T, delay time.Duration, body string, requests *atomic.Int64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCallTimeoutCoversEveryRequestOfACall(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := slowServer(t, 150*time.Millisecond, `{"success":true,"data":{"id":1}}`, &requests)
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	// The album settings lookup and the upload each fit in the timeout, but
+	// not both together.
+	albumID := int64(7)
+	_, err := client.Files.Upload(context.Background(), strings.NewReader("image"),
+		&UploadOptions{AlbumID: &albumID}, WithCallTimeout(250*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Fatalf("expected 2 requests before the deadline, got %d", n)
+	}
+}
+
+func TestServiceDefaultTimeoutCoversEveryPage(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := slowServer(t, 100*time.Millisecond, `{"files":[{"id":1}],"total":10,"limit":1}`, &requests)
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()),
+		WithServiceDefaults(ServiceFiles, Defaults{Timeout: 250 * time.Millisecond}))
+
+	start := time.Now()
+	_, err := client.Files.ListAll(context.Background(), &ListOptions{Limit: 1}).Collect()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 600*time.Millisecond {
+		t.Fatalf("iteration outlived its timeout: %v", elapsed)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("expected 3 requests before the deadline, got %d", n)
+	}
+}
+
+func TestWithResponseExposesCacheHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.Header().Set("ETag", `W/"abc"`)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		w.Header().Set(RequestIDHeader, "req-1")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var resp Response
+	if _, err := client.Files.Get(context.Background(), 1, WithResponse(&resp)); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	want := Response{
+		StatusCode:   http.StatusOK,
+		CacheControl: "public, max-age=3600",
+		ETag:         `W/"abc"`,
+		LastModified: time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC),
+		RequestID:    "req-1",
+	}
+	if resp != want {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestMiddlewareWrapsRequestsInOrder(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Chain"); got != "outer,inner" {
+			t.Errorf("unexpected X-Chain: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				if req.Header.Get("Authorization") == "" {
+					t.Errorf("%s: request has no Authorization header", name)
+				}
+				if chain := req.Header.Get("X-Chain"); chain != "" {
+					name = chain + "," + name
+				}
+				req.Header.Set("X-Chain", name)
+				resp, err := next(req)
+				calls = append(calls, name)
+				return resp, err
+			}
+		}
+	}
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()),
+		WithMiddleware(trace("outer")), WithMiddleware(trace("inner")))
+
+	if _, err := client.Files.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if strings.Join(calls, " ") != "outer,inner outer" {
+		t.Fatalf("unexpected call order: %v", calls)
+	}
+}
+
+func TestWithJSONReplacesCodec(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"name":"Trips"}`))
+	}))
+	defer server.Close()
+
+	var marshals, unmarshals atomic.Int32
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()),
+		WithJSON(
+			func(v interface{}) ([]byte, error) {
+				marshals.Add(1)
+				return json.Marshal(v)
+			},
+			func(data []byte, v interface{}) error {
+				unmarshals.Add(1)
+				return json.Unmarshal(data, v)
+			},
+		))
+
+	album, err := client.Albums.Create(context.Background(), &CreateAlbumOptions{Name: "Trips"})
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if album.ID != 7 || marshals.Load() != 1 || unmarshals.Load() != 1 {
+		t.Fatalf("unexpected result: album %+v, %d marshals, %d unmarshals", album, marshals.Load(), unmarshals.Load())
+	}
+}
+
+func TestServiceDefaultsApplyPerService(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/files":
+			if got := r.URL.Query().Get("limit"); got != "100" {
+				t.Errorf("unexpected files limit: %q", got)
+			}
+			if attempts.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"files":[],"total":0,"page":1,"limit":100}`))
+		case "/api/shares":
+			if got := r.URL.Query().Get("limit"); got != "" {
+				t.Errorf("unexpected shares limit: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"shares":[],"total":0,"page":1,"limit":20}`))
+		case "/api/files/search":
+			if got := r.URL.Query().Get("limit"); got != "5" {
+				t.Errorf("unexpected search limit: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"files":[],"total":0,"page":1,"limit":5}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()),
+		WithServiceDefaults(ServiceFiles, Defaults{Retries: 1, PageSize: 500}))
+
+	if _, err := client.Files.List(context.Background(), nil); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Fatalf("expected 1 retry, got %d attempts", attempts.Load())
+	}
+	if _, err := client.Share.List(context.Background(), nil); err != nil {
+		t.Fatalf("Share.List returned error: %v", err)
+	}
+	if _, err := client.Files.Search(context.Background(), &SearchOptions{Query: "cat"}, WithPageSize(5)); err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+}
+
+func TestAPIErrorCarriesResponseContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(RequestIDHeader, "req-9")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"File not found","code":"file_not_found","details":{"file_id":9}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Files.Delete(context.Background(), 9)
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict) {
+		t.Fatalf("unexpected sentinel mapping for %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Method != http.MethodDelete || apiErr.Path != "/api/files/9" || apiErr.RequestID != "req-9" ||
+		apiErr.Code != CodeFileNotFound || apiErr.Details["file_id"] != float64(9) || !strings.Contains(string(apiErr.Body), "file_not_found") {
+		t.Fatalf("unexpected error context: %+v", apiErr)
+	}
+	if got := err.Error(); got != "f-image API error (DELETE /api/files/9, status 404, request req-9): File not found" {
+		t.Fatalf("unexpected message: %s", got)
+	}
+}
+
+func TestAPIErrorSentinelsForQuotaAndSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err       *APIError
+		quota     bool
+		fileLarge bool
+	}{
+		{&APIError{StatusCode: http.StatusRequestEntityTooLarge}, false, true},
+		{&APIError{StatusCode: http.StatusRequestEntityTooLarge, Code: CodeQuotaExceeded}, true, false},
+		{&APIError{StatusCode: http.StatusPaymentRequired}, true, false},
+		{&APIError{StatusCode: http.StatusPaymentRequired, Code: CodeFileTooLarge}, false, true},
+		{&APIError{StatusCode: http.StatusBadRequest, Code: CodeFileTooLarge}, false, true},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, ErrQuotaExceeded); got != tt.quota {
+			t.Errorf("%d %q: Is(ErrQuotaExceeded) = %v", tt.err.StatusCode, tt.err.Code, got)
+		}
+		if got := errors.Is(tt.err, ErrFileTooLarge); got != tt.fileLarge {
+			t.Errorf("%d %q: Is(ErrFileTooLarge) = %v", tt.err.StatusCode, tt.err.Code, got)
+		}
+	}
+}
+
+// fixedCodec is a Codec that converts every body to the same JSON.
+type fixedCodec struct{ json string }
+
+func (fixedCodec) ContentType() string { return "application/x-test" }
+
+func (c fixedCodec) ToJSON(data []byte) ([]byte, error) { return []byte(c.json), nil }
+
+func TestAPIErrorBodyIsRawWithCodec(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-test")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("opaque"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithCodec(fixedCodec{json: `{"error":"File not found"}`}),
+	)
+
+	_, err := client.Files.Get(context.Background(), 9)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.Message != "File not found" || string(apiErr.Body) != "opaque" {
+		t.Fatalf("unexpected error: message %q, body %q", apiErr.Message, apiErr.Body)
+	}
+}
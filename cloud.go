@@ -0,0 +1,246 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CloudProvider identifies a cloud storage provider.
+type CloudProvider string
+
+const (
+	// CloudProviderS3 is Amazon S3 or an S3-compatible service.
+	CloudProviderS3 CloudProvider = "s3"
+
+	// CloudProviderGCS is Google Cloud Storage.
+	CloudProviderGCS CloudProvider = "gcs"
+)
+
+// CloudCredentials are the credentials the server uses to read from or
+// write to cloud storage. They are sent to the API over TLS and are not
+// stored after the transfer.
+type CloudCredentials struct {
+	// AccessKeyID is the S3 access key ID.
+	AccessKeyID string `json:"access_key_id,omitempty"`
+
+	// SecretAccessKey is the S3 secret access key.
+	SecretAccessKey string `json:"secret_access_key,omitempty"`
+
+	// SessionToken is an optional S3 session token for temporary credentials.
+	SessionToken string `json:"session_token,omitempty"`
+
+	// ServiceAccountJSON is the GCS service account key file contents.
+	ServiceAccountJSON string `json:"service_account_json,omitempty"`
+}
+
+// CloudSource describes an object in cloud storage to import.
+type CloudSource struct {
+	// Provider is the cloud storage provider (required).
+	Provider CloudProvider
+
+	// Bucket is the bucket name (required).
+	Bucket string
+
+	// Key is the object key within the bucket (required).
+	Key string
+
+	// Region is the bucket region (S3 only).
+	Region string
+
+	// Endpoint is a custom endpoint for S3-compatible services.
+	Endpoint string
+
+	// Credentials are used by the server to read the object.
+	// Leave nil for publicly readable objects.
+	Credentials *CloudCredentials
+
+	// Description is an optional description for the file.
+	Description string
+
+	// AlbumID is the optional album to add the file to.
+	AlbumID *int64
+}
+
+// Validate checks the options for invalid fields.
+func (src *CloudSource) Validate() error {
+	var v validator
+	switch src.Provider {
+	case CloudProviderS3, CloudProviderGCS:
+	case "":
+		v.check(false, "Provider", "is required")
+	default:
+		v.check(false, "Provider", "has unsupported value %q", src.Provider)
+	}
+	v.check(strings.TrimSpace(src.Bucket) != "", "Bucket", "is required")
+	v.check(strings.TrimSpace(src.Key) != "", "Key", "is required")
+	return v.err()
+}
+
+// UploadFromCloud asks the server to fetch an object directly from cloud
+// storage, so bytes move cloud-to-cloud without passing through the client.
+//
+// Example:
+//
+//	resp, err := client.Files.UploadFromCloud(ctx, &fimage.CloudSource{
+//	    Provider: fimage.CloudProviderS3,
+//	    Bucket:   "my-archive",
+//	    Key:      "2019/IMG_0001.jpg",
+//	    Region:   "eu-west-1",
+//	    Credentials: &fimage.CloudCredentials{
+//	        AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+//	        SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+//	    },
+//	})
+func (s *FilesService) UploadFromCloud(ctx context.Context, src *CloudSource, callOpts ...CallOption) (*UploadResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if src == nil {
+		src = &CloudSource{}
+	}
+	if err := src.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		Provider    CloudProvider     `json:"provider"`
+		Bucket      string            `json:"bucket"`
+		Key         string            `json:"key"`
+		Region      string            `json:"region,omitempty"`
+		Endpoint    string            `json:"endpoint,omitempty"`
+		Credentials *CloudCredentials `json:"credentials,omitempty"`
+		Description string            `json:"description,omitempty"`
+		AlbumID     *int64            `json:"album_id,omitempty"`
+	}{
+		Provider:    src.Provider,
+		Bucket:      src.Bucket,
+		Key:         src.Key,
+		Region:      src.Region,
+		Endpoint:    src.Endpoint,
+		Credentials: src.Credentials,
+		Description: src.Description,
+		AlbumID:     src.AlbumID,
+	}
+
+	var resp UploadResponse
+	if err := s.client.request(ctx, http.MethodPost, "/api/files/upload_from_cloud", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DestinationType identifies an outbound transfer target.
+type DestinationType string
+
+const (
+	// DestinationS3 is Amazon S3 or an S3-compatible service.
+	DestinationS3 DestinationType = "s3"
+
+	// DestinationGCS is Google Cloud Storage.
+	DestinationGCS DestinationType = "gcs"
+
+	// DestinationFTP is an FTP server.
+	DestinationFTP DestinationType = "ftp"
+
+	// DestinationSFTP is an SFTP server.
+	DestinationSFTP DestinationType = "sftp"
+)
+
+// Destination describes where the server should push file copies.
+type Destination struct {
+	// Type is the destination kind (required).
+	Type DestinationType `json:"type"`
+
+	// Bucket is the target bucket (S3 and GCS).
+	Bucket string `json:"bucket,omitempty"`
+
+	// Region is the bucket region (S3 only).
+	Region string `json:"region,omitempty"`
+
+	// Endpoint is a custom endpoint for S3-compatible services.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Host is the server hostname (FTP and SFTP).
+	Host string `json:"host,omitempty"`
+
+	// Port is the server port (FTP and SFTP). Defaults to the protocol port.
+	Port int `json:"port,omitempty"`
+
+	// Username is the login name (FTP and SFTP).
+	Username string `json:"username,omitempty"`
+
+	// Password is the login password (FTP and SFTP).
+	Password string `json:"password,omitempty"`
+
+	// Path is the key prefix or remote directory for the copies.
+	Path string `json:"path,omitempty"`
+
+	// Credentials are the cloud credentials (S3 and GCS).
+	Credentials *CloudCredentials `json:"credentials,omitempty"`
+
+	// Variant selects which stored variant to copy. Defaults to the original.
+	Variant Variant `json:"variant,omitempty"`
+}
+
+// Validate checks the options for invalid fields.
+func (d *Destination) Validate() error {
+	var v validator
+	switch d.Type {
+	case DestinationS3, DestinationGCS:
+		v.check(strings.TrimSpace(d.Bucket) != "", "Bucket", "is required for %s destinations", d.Type)
+	case DestinationFTP, DestinationSFTP:
+		v.check(strings.TrimSpace(d.Host) != "", "Host", "is required for %s destinations", d.Type)
+		v.check(d.Port >= 0 && d.Port <= 65535, "Port", "must be between 0 and 65535")
+	case "":
+		v.check(false, "Type", "is required")
+	default:
+		v.check(false, "Type", "has unsupported value %q", d.Type)
+	}
+	v.check(d.Variant == "" || d.Variant.Valid(), "Variant", "has unsupported value %q", d.Variant)
+	return v.err()
+}
+
+// ExportToDestination starts a job that pushes copies of files to external
+// storage such as S3 or an FTP server. Use client.Jobs.Wait to follow it.
+//
+// Example:
+//
+//	job, err := client.Files.ExportToDestination(ctx, []int64{1, 2, 3}, &fimage.Destination{
+//	    Type:   fimage.DestinationS3,
+//	    Bucket: "print-lab-inbox",
+//	    Path:   "orders/1042/",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	job, err = client.Jobs.Wait(ctx, job.ID, 0, nil)
+func (s *FilesService) ExportToDestination(ctx context.Context, fileIDs []int64, dest *Destination, callOpts ...CallOption) (*Job, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+	if dest == nil {
+		return nil, fmt.Errorf("destination is required")
+	}
+	if err := dest.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		FileIDs     []int64      `json:"file_ids"`
+		Destination *Destination `json:"destination"`
+	}{
+		FileIDs:     fileIDs,
+		Destination: dest,
+	}
+
+	var job Job
+	if err := s.client.request(ctx, http.MethodPost, "/api/files/export", req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
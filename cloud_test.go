@@ -0,0 +1,164 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// invalidFields returns the comma-separated fields of a *ValidationError,
+// or "" when err is nil.
+func invalidFields(t *testing.T, err error) string {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	fields := make([]string, len(validationErr.Errors))
+	for i, fe := range validationErr.Errors {
+		fields[i] = fe.Field
+	}
+	return strings.Join(fields, ",")
+}
+
+func TestCloudSourceValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  CloudSource
+		want string
+	}{
+		{"s3", CloudSource{Provider: CloudProviderS3, Bucket: "b", Key: "k"}, ""},
+		{"gcs", CloudSource{Provider: CloudProviderGCS, Bucket: "b", Key: "k"}, ""},
+		{"missing provider", CloudSource{Bucket: "b", Key: "k"}, "Provider"},
+		{"unknown provider", CloudSource{Provider: "azure", Bucket: "b", Key: "k"}, "Provider"},
+		{"blank bucket and key", CloudSource{Provider: CloudProviderS3, Bucket: " "}, "Bucket,Key"},
+	}
+	for _, tt := range tests {
+		if got := invalidFields(t, tt.src.Validate()); got != tt.want {
+			t.Errorf("%s: invalid fields = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDestinationValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dest Destination
+		want string
+	}{
+		{"s3", Destination{Type: DestinationS3, Bucket: "b"}, ""},
+		{"gcs without bucket", Destination{Type: DestinationGCS}, "Bucket"},
+		{"ftp", Destination{Type: DestinationFTP, Host: "ftp.example.com", Port: 21}, ""},
+		{"sftp default port", Destination{Type: DestinationSFTP, Host: "sftp.example.com"}, ""},
+		{"sftp highest port", Destination{Type: DestinationSFTP, Host: "sftp.example.com", Port: 65535}, ""},
+		{"sftp port too high", Destination{Type: DestinationSFTP, Host: "sftp.example.com", Port: 65536}, "Port"},
+		{"ftp negative port", Destination{Type: DestinationFTP, Host: "ftp.example.com", Port: -1}, "Port"},
+		{"ftp without host", Destination{Type: DestinationFTP}, "Host"},
+		{"missing type", Destination{}, "Type"},
+		{"unknown type", Destination{Type: "dropbox"}, "Type"},
+		{"unknown variant", Destination{Type: DestinationS3, Bucket: "b", Variant: "huge"}, "Variant"},
+	}
+	for _, tt := range tests {
+		if got := invalidFields(t, tt.dest.Validate()); got != tt.want {
+			t.Errorf("%s: invalid fields = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUploadFromCloudSendsSource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/upload_from_cloud" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		creds, _ := req["credentials"].(map[string]interface{})
+		if req["provider"] != "s3" || req["bucket"] != "my-archive" || req["key"] != "2019/IMG_0001.jpg" ||
+			req["region"] != "eu-west-1" || req["album_id"] != float64(9) || creds["access_key_id"] != "AKIA" {
+			t.Errorf("unexpected request body: %v", req)
+		}
+		if _, ok := req["endpoint"]; ok {
+			t.Errorf("empty endpoint should be omitted: %v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"id":5}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	albumID := int64(9)
+	resp, err := client.Files.UploadFromCloud(context.Background(), &CloudSource{
+		Provider:    CloudProviderS3,
+		Bucket:      "my-archive",
+		Key:         "2019/IMG_0001.jpg",
+		Region:      "eu-west-1",
+		Credentials: &CloudCredentials{AccessKeyID: "AKIA", SecretAccessKey: "secret"},
+		AlbumID:     &albumID,
+	})
+	if err != nil {
+		t.Fatalf("UploadFromCloud returned error: %v", err)
+	}
+	if resp.Data.ID != 5 {
+		t.Fatalf("unexpected file ID: %d", resp.Data.ID)
+	}
+}
+
+func TestExportToDestinationSendsFilesAndDestination(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/export" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			FileIDs     []int64     `json:"file_ids"`
+			Destination Destination `json:"destination"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		want := Destination{Type: DestinationSFTP, Host: "sftp.example.com", Port: 2222, Username: "lab", Path: "orders/1042/"}
+		if len(req.FileIDs) != 3 || req.FileIDs[2] != 3 || req.Destination != want {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"job_1","type":"export","status":"pending","total":3}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	job, err := client.Files.ExportToDestination(context.Background(), []int64{1, 2, 3}, &Destination{
+		Type:     DestinationSFTP,
+		Host:     "sftp.example.com",
+		Port:     2222,
+		Username: "lab",
+		Path:     "orders/1042/",
+	})
+	if err != nil {
+		t.Fatalf("ExportToDestination returned error: %v", err)
+	}
+	if job.ID != "job_1" || job.Total != 3 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	if _, err := client.Files.ExportToDestination(context.Background(), nil, &Destination{Type: DestinationS3, Bucket: "b"}); err == nil {
+		t.Fatal("expected error for no file IDs")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// runAlbum manages albums.
+func runAlbum(ctx context.Context, a *app, args []string) error {
+	action, args, err := a.subcommand("album", []string{"list", "create [-desc TEXT] NAME", "delete ID"}, args)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		albums, err := a.client.Albums.List(ctx)
+		if err != nil {
+			return err
+		}
+		return a.print(albums, func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tNAME\tFILES\tCREATED")
+			for _, album := range albums {
+				fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", album.ID, album.Name, album.FileCount, album.CreatedAt)
+			}
+		})
+
+	case "create":
+		fs := a.newFlagSet("album create", "NAME")
+		description := fs.String("desc", "", "album description")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError(fs, "exactly one album name is required")
+		}
+		album, err := a.client.Albums.Create(ctx, &fimage.CreateAlbumOptions{Name: fs.Arg(0), Description: *description})
+		if err != nil {
+			return err
+		}
+		return a.print(album, func(w io.Writer) {
+			fmt.Fprintf(w, "created album %d\t%s\n", album.ID, album.Name)
+		})
+
+	case "delete":
+		fs := a.newFlagSet("album delete", "ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError(fs, "exactly one album ID is required")
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		resp, err := a.client.Albums.Delete(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.print(resp, func(w io.Writer) {
+			fmt.Fprintf(w, "deleted album %d\n", ids[0])
+		})
+	}
+
+	return fmt.Errorf("unknown action %q", action)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// app is the state shared by the commands.
+type app struct {
+	client *fimage.Client
+	json   bool
+	out    io.Writer
+	err    io.Writer
+}
+
+// print writes v as JSON in JSON mode, or calls text with a tab-aligned
+// writer otherwise.
+func (a *app) print(v interface{}, text func(w io.Writer)) error {
+	if a.json {
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	tw := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	text(tw)
+	return tw.Flush()
+}
+
+// batchJSON is the JSON output of a batch operation.
+type batchJSON[T any] struct {
+	Succeeded []T             `json:"succeeded"`
+	Failed    []batchItemJSON `json:"failed"`
+	Message   string          `json:"message,omitempty"`
+}
+
+// batchItemJSON is a failed item of a batch operation in JSON output. Item
+// errors are printed as their message, since error values have no JSON form.
+type batchItemJSON struct {
+	Index int    `json:"index"`
+	ID    int64  `json:"id,omitempty"`
+	Error string `json:"error"`
+}
+
+// newBatchJSON returns the JSON output of result.
+func newBatchJSON[T any](result *fimage.BatchResult[T]) *batchJSON[T] {
+	out := &batchJSON[T]{
+		Succeeded: result.Succeeded,
+		Failed:    make([]batchItemJSON, len(result.Failed)),
+		Message:   result.Message,
+	}
+	if out.Succeeded == nil {
+		out.Succeeded = []T{}
+	}
+	for i, item := range result.Failed {
+		out.Failed[i] = batchItemJSON{Index: item.Index, ID: item.ID, Error: item.Err.Error()}
+	}
+	return out
+}
+
+// printBatch reports the outcome of a batch operation on ids and returns an
+// error if any item failed.
+func (a *app) printBatch(verb string, result *fimage.BatchResult[int64]) error {
+	err := a.print(newBatchJSON(result), func(w io.Writer) {
+		for _, id := range result.Succeeded {
+			fmt.Fprintf(w, "%s\t%d\n", verb, id)
+		}
+		for _, item := range result.Failed {
+			fmt.Fprintf(w, "failed\t%d\t%v\n", item.ID, item.Err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+// newFlagSet returns the flag set of a subcommand.
+func (a *app) newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.err)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), strings.TrimSpace("Usage: fimage "+name+" [flags] "+args))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// usageError prints the usage of fs and returns errUsage.
+func usageError(fs *flag.FlagSet, format string, args ...interface{}) error {
+	fmt.Fprintf(fs.Output(), format+"\n", args...)
+	fs.Usage()
+	return errUsage
+}
+
+// parseIDs parses positional ID arguments.
+func parseIDs(args []string) ([]int64, error) {
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid ID %q", arg)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// optionalID returns a pointer to id, or nil if it is zero.
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// subcommand splits args into the action and its arguments, printing usage
+// when the action is missing.
+func (a *app) subcommand(name string, actions []string, args []string) (string, []string, error) {
+	if len(args) == 0 {
+		fmt.Fprintf(a.err, "Usage: fimage %s <action> [arguments]\n\nActions:\n", name)
+		for _, action := range actions {
+			fmt.Fprintf(a.err, "  %s\n", action)
+		}
+		return "", nil, errUsage
+	}
+	return args[0], args[1:], nil
+}
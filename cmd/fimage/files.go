@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// runUpload uploads the files named by args.
+func runUpload(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("upload", "FILE...")
+	albumID := fs.Int64("album", 0, "album ID to upload into")
+	description := fs.String("desc", "", "file description")
+	concurrency := fs.Int("concurrency", fimage.DefaultConcurrency, "number of parallel uploads")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageError(fs, "no files to upload")
+	}
+
+	paths := fs.Args()
+	reqs := make([]fimage.UploadRequest, len(paths))
+	for i, path := range paths {
+		path := path
+		reqs[i] = fimage.UploadRequest{
+			Open: func() (io.ReadCloser, error) { return os.Open(path) },
+			Options: &fimage.UploadOptions{
+				Filename:    filepath.Base(path),
+				Description: *description,
+				AlbumID:     optionalID(*albumID),
+			},
+		}
+	}
+
+	result, err := a.client.Files.UploadMany(ctx, reqs, &fimage.UploadManyOptions{Concurrency: *concurrency})
+	if err != nil {
+		return err
+	}
+	err = a.print(newBatchJSON(result), func(w io.Writer) {
+		for _, up := range result.Succeeded {
+			status := "uploaded"
+			if up.SkippedAsDuplicate {
+				status = "duplicate"
+			}
+			if data := up.Response.Data; data != nil {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", status, paths[up.Index], data.ID, data.URL)
+			}
+		}
+		for _, item := range result.Failed {
+			fmt.Fprintf(w, "failed\t%s\t%v\n", paths[item.Index], item.Err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+// runList lists files.
+func runList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("list", "")
+	albumID := fs.Int64("album", 0, "only list files in this album")
+	page := fs.Int("page", 1, "page number")
+	limit := fs.Int("limit", 20, "files per page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := a.client.Files.List(ctx, &fimage.ListOptions{
+		Page:    *page,
+		Limit:   *limit,
+		AlbumID: optionalID(*albumID),
+	})
+	if err != nil {
+		return err
+	}
+	return a.printFiles(resp)
+}
+
+// runSearch searches files.
+func runSearch(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("search", "QUERY")
+	page := fs.Int("page", 1, "page number")
+	limit := fs.Int("limit", 20, "files per page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "exactly one query is required")
+	}
+
+	resp, err := a.client.Files.Search(ctx, &fimage.SearchOptions{
+		Query: fs.Arg(0),
+		Page:  *page,
+		Limit: *limit,
+	})
+	if err != nil {
+		return err
+	}
+	return a.printFiles(resp)
+}
+
+// printFiles prints a page of files.
+func (a *app) printFiles(resp *fimage.FilesListResponse) error {
+	return a.print(resp, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tSIZE\tDIMENSIONS\tURL")
+		for _, f := range resp.Files {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%dx%d\t%s\n", f.ID, f.OriginalName, f.Size, f.Width, f.Height, f.URL)
+		}
+		fmt.Fprintf(w, "\nPage %d, %d of %d files\n", resp.Page, len(resp.Files), resp.Total)
+	})
+}
+
+// runGet shows a file.
+func runGet(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("get", "ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError(fs, "exactly one file ID is required")
+	}
+	ids, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	file, err := a.client.Files.Get(ctx, ids[0])
+	if err != nil {
+		return err
+	}
+	return a.print(file, func(w io.Writer) {
+		fmt.Fprintf(w, "ID:\t%d\n", file.ID)
+		fmt.Fprintf(w, "Name:\t%s\n", file.OriginalName)
+		fmt.Fprintf(w, "Type:\t%s\n", file.MimeType)
+		fmt.Fprintf(w, "Size:\t%d bytes\n", file.Size)
+		fmt.Fprintf(w, "Dimensions:\t%dx%d\n", file.Width, file.Height)
+		fmt.Fprintf(w, "URL:\t%s\n", file.URL)
+		fmt.Fprintf(w, "Created:\t%s\n", file.CreatedAt)
+		if file.Description != "" {
+			fmt.Fprintf(w, "Description:\t%s\n", file.Description)
+		}
+	})
+}
+
+// runDelete moves files to the trash.
+func runDelete(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("delete", "ID...")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageError(fs, "no file IDs given")
+	}
+	ids, err := parseIDs(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	result, err := a.client.Files.BatchDelete(ctx, ids)
+	if err != nil {
+		return err
+	}
+	return a.printBatch("deleted", result)
+}
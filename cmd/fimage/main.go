@@ -0,0 +1,136 @@
+// Command fimage manages F-Image files, albums, tags, share links, and the
+// trash from the command line.
+//
+// Usage:
+//
+//	export FIMAGE_API_TOKEN="your-api-token"
+//	fimage [flags] <command> [arguments]
+//
+// Commands:
+//
+//	upload   upload files
+//	list     list files
+//	search   search files
+//	get      show a file
+//	delete   move files to the trash
+//	album    list, create, or delete albums
+//	tag      list, create, or delete tags, and tag or untag files
+//	share    list, create, or delete share links
+//	trash    list, restore, or permanently delete trashed files
+//
+// Global flags:
+//
+//	-json        print results as JSON
+//	-sandbox     use the sandbox environment
+//	-base-url    API base URL (default: https://f-image.com)
+//
+// Run "fimage <command> -h" for the flags of a command.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// command is a top-level subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, app *app, args []string) error
+}
+
+// commands are the subcommands in usage order.
+var commands = []command{
+	{"upload", "upload files", runUpload},
+	{"list", "list files", runList},
+	{"search", "search files", runSearch},
+	{"get", "show a file", runGet},
+	{"delete", "move files to the trash", runDelete},
+	{"album", "list, create, or delete albums", runAlbum},
+	{"tag", "list, create, or delete tags, and tag or untag files", runTag},
+	{"share", "list, create, or delete share links", runShare},
+	{"trash", "list, restore, or permanently delete trashed files", runTrash},
+}
+
+// errUsage reports invalid arguments; the usage has already been printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line, writing results to stdout and diagnostics
+// to stderr, and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("fimage", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jsonOutput := fs.Bool("json", false, "print results as JSON")
+	sandbox := fs.Bool("sandbox", false, "use the sandbox environment")
+	baseURL := fs.String("base-url", "", "API base URL")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == fs.Arg(0) {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(stderr, "fimage: unknown command %q\n\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	token := os.Getenv("FIMAGE_API_TOKEN")
+	if token == "" {
+		fmt.Fprintln(stderr, "fimage: FIMAGE_API_TOKEN environment variable is required")
+		return 1
+	}
+	opts := []fimage.ClientOption{fimage.WithAppInfo("fimage-cli", fimage.Version)}
+	if *sandbox {
+		opts = append(opts, fimage.WithSandbox())
+	}
+	if *baseURL != "" {
+		opts = append(opts, fimage.WithBaseURL(*baseURL))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{client: fimage.NewClient(token, opts...), json: *jsonOutput, out: stdout, err: stderr}
+	if err := cmd.run(ctx, a, fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "fimage %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// usage prints the top-level usage.
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: fimage [flags] <command> [arguments]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w, "\nRun \"fimage <command> -h\" for the flags of a command.")
+}
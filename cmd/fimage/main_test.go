@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// runWith runs the command line against server and returns the exit code
+// and output.
+func runWith(t *testing.T, server *httptest.Server, args ...string) (int, string, string) {
+	t.Helper()
+	t.Setenv("FIMAGE_API_TOKEN", "test-token")
+	var stdout, stderr bytes.Buffer
+	code := run(append([]string{"-base-url", server.URL}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunDeletePrintsFailedItemsAsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/batch-delete" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("unexpected Authorization header: %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"id":1,"success":true},{"id":2,"success":false,"status":404,"error":"file not found"}]}`))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runWith(t, server, "-json", "delete", "1", "2")
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d (stderr %q)", code, stderr)
+	}
+
+	var out struct {
+		Succeeded []int64 `json:"succeeded"`
+		Failed    []struct {
+			Index int    `json:"index"`
+			ID    int64  `json:"id"`
+			Error string `json:"error"`
+		} `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", stdout, err)
+	}
+	if len(out.Succeeded) != 1 || out.Succeeded[0] != 1 {
+		t.Fatalf("unexpected succeeded items: %+v", out.Succeeded)
+	}
+	if len(out.Failed) != 1 || out.Failed[0].ID != 2 || !strings.Contains(out.Failed[0].Error, "file not found") {
+		t.Fatalf("unexpected failed items: %+v", out.Failed)
+	}
+}
+
+func TestRunTrashEmptyFailsOnFailedDeletions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/trash/empty" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"deleted_count":1,"failed_count":1,"failed_deletions":[{"file_id":4,"reason":"shared"}]}`))
+	}))
+	defer server.Close()
+
+	code, stdout, stderr := runWith(t, server, "trash", "empty", "-yes")
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stdout, "failed  4  shared") {
+		t.Fatalf("unexpected output: %q", stdout)
+	}
+	if !strings.Contains(stderr, "1 files could not be deleted") {
+		t.Fatalf("unexpected error output: %q", stderr)
+	}
+}
+
+func TestRunReportsUsageErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{nil, 2},
+		{[]string{"frobnicate"}, 2},
+		{[]string{"delete"}, 2},
+		{[]string{"trash", "empty"}, 2},
+		{[]string{"get", "abc"}, 1},
+	}
+	for _, tt := range tests {
+		if code, _, _ := runWith(t, server, tt.args...); code != tt.code {
+			t.Errorf("run(%q) = %d, want %d", tt.args, code, tt.code)
+		}
+	}
+
+	t.Setenv("FIMAGE_API_TOKEN", "")
+	var stderr bytes.Buffer
+	if code := run([]string{"list"}, &bytes.Buffer{}, &stderr); code != 1 || !strings.Contains(stderr.String(), "FIMAGE_API_TOKEN") {
+		t.Fatalf("expected missing token error, got %d %q", code, stderr.String())
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// runShare manages share links.
+func runShare(ctx context.Context, a *app, args []string) error {
+	action, args, err := a.subcommand("share", []string{
+		"list", "create (-file ID | -album ID) [-password TEXT] [-expires HOURS] [-max-views N]", "delete ID",
+	}, args)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		fs := a.newFlagSet("share list", "")
+		page := fs.Int("page", 1, "page number")
+		limit := fs.Int("limit", 20, "share links per page")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		resp, err := a.client.Share.List(ctx, &fimage.ShareListOptions{Page: *page, Limit: *limit})
+		if err != nil {
+			return err
+		}
+		return a.print(resp, func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tTARGET\tVIEWS\tACTIVE\tURL")
+			for _, share := range resp.Shares {
+				fmt.Fprintf(w, "%d\t%s\t%d\t%t\t%s\n", share.ID, shareTarget(share), share.ViewCount, share.IsActive, share.ShareURL)
+			}
+			fmt.Fprintf(w, "\nPage %d, %d of %d share links\n", resp.Page, len(resp.Shares), resp.Total)
+		})
+
+	case "create":
+		fs := a.newFlagSet("share create", "")
+		fileID := fs.Int64("file", 0, "file ID to share")
+		albumID := fs.Int64("album", 0, "album ID to share")
+		password := fs.String("password", "", "password required to open the link")
+		expires := fs.Int("expires", 0, "hours until the link expires (default: never)")
+		maxViews := fs.Int("max-views", 0, "maximum number of views (default: unlimited)")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		share, err := a.client.Share.Create(ctx, &fimage.CreateShareOptions{
+			FileID:    optionalID(*fileID),
+			AlbumID:   optionalID(*albumID),
+			Password:  *password,
+			ExpiresIn: *expires,
+			MaxViews:  *maxViews,
+		})
+		if err != nil {
+			return err
+		}
+		return a.print(share, func(w io.Writer) {
+			fmt.Fprintf(w, "created share %d\t%s\n", share.ID, share.ShareURL)
+		})
+
+	case "delete":
+		fs := a.newFlagSet("share delete", "ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError(fs, "exactly one share ID is required")
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		resp, err := a.client.Share.Delete(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.print(resp, func(w io.Writer) {
+			fmt.Fprintf(w, "deleted share %d\n", ids[0])
+		})
+	}
+
+	return fmt.Errorf("unknown action %q", action)
+}
+
+// shareTarget describes what a share link points to.
+func shareTarget(share fimage.ShareLink) string {
+	switch {
+	case share.FileName != nil:
+		return "file " + *share.FileName
+	case share.AlbumName != nil:
+		return "album " + *share.AlbumName
+	case share.FileID != nil:
+		return fmt.Sprintf("file %d", *share.FileID)
+	case share.AlbumID != nil:
+		return fmt.Sprintf("album %d", *share.AlbumID)
+	}
+	return "-"
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// runTag manages tags.
+func runTag(ctx context.Context, a *app, args []string) error {
+	action, args, err := a.subcommand("tag", []string{
+		"list", "create [-color HEX] NAME", "delete ID", "add TAG_ID FILE_ID...", "remove TAG_ID FILE_ID...",
+	}, args)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		tags, err := a.client.Tags.List(ctx)
+		if err != nil {
+			return err
+		}
+		return a.print(tags, func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tNAME\tCOLOR\tFILES")
+			for _, tag := range tags {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", tag.ID, tag.Name, tag.Color, tag.FileCount)
+			}
+		})
+
+	case "create":
+		fs := a.newFlagSet("tag create", "NAME")
+		color := fs.String("color", "", "tag color, e.g. #FF5733")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError(fs, "exactly one tag name is required")
+		}
+		tag, err := a.client.Tags.Create(ctx, &fimage.CreateTagOptions{Name: fs.Arg(0), Color: *color})
+		if err != nil {
+			return err
+		}
+		return a.print(tag, func(w io.Writer) {
+			fmt.Fprintf(w, "created tag %d\t%s\n", tag.ID, tag.Name)
+		})
+
+	case "delete":
+		fs := a.newFlagSet("tag delete", "ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError(fs, "exactly one tag ID is required")
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		resp, err := a.client.Tags.Delete(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.print(resp, func(w io.Writer) {
+			fmt.Fprintf(w, "deleted tag %d\n", ids[0])
+		})
+
+	case "add", "remove":
+		fs := a.newFlagSet("tag "+action, "TAG_ID FILE_ID...")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() < 2 {
+			return usageError(fs, "a tag ID and at least one file ID are required")
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		if action == "add" {
+			result, err := a.client.Tags.TagFiles(ctx, ids[0], ids[1:])
+			if err != nil {
+				return err
+			}
+			return a.printBatch("tagged", result)
+		}
+		result, err := a.client.Tags.UntagFiles(ctx, ids[0], ids[1:])
+		if err != nil {
+			return err
+		}
+		return a.printBatch("untagged", result)
+	}
+
+	return fmt.Errorf("unknown action %q", action)
+}
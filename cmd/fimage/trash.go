@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// runTrash manages the trash.
+func runTrash(ctx context.Context, a *app, args []string) error {
+	action, args, err := a.subcommand("trash", []string{"list", "restore ID...", "purge ID", "empty -yes"}, args)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "list":
+		fs := a.newFlagSet("trash list", "")
+		page := fs.Int("page", 1, "page number")
+		limit := fs.Int("limit", 20, "files per page")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		resp, err := a.client.Trash.List(ctx, &fimage.TrashListOptions{Page: *page, Limit: *limit})
+		if err != nil {
+			return err
+		}
+		return a.print(resp, func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tNAME\tSIZE\tDELETED")
+			for _, f := range resp.Files {
+				fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", f.ID, f.OriginalName, f.Size, f.GetDeletedAt())
+			}
+			fmt.Fprintf(w, "\nPage %d, %d of %d files\n", resp.Page, len(resp.Files), resp.Total)
+		})
+
+	case "restore":
+		fs := a.newFlagSet("trash restore", "ID...")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			return usageError(fs, "no file IDs given")
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		result, err := a.client.Trash.RestoreMany(ctx, ids)
+		if err != nil {
+			return err
+		}
+		return a.printBatch("restored", result)
+
+	case "purge":
+		fs := a.newFlagSet("trash purge", "ID")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usageError(fs, "exactly one file ID is required")
+		}
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+		result, err := a.client.Trash.PermanentDelete(ctx, ids[0])
+		if err != nil {
+			return err
+		}
+		return a.print(result, func(w io.Writer) {
+			fmt.Fprintf(w, "permanently deleted file %d\n", ids[0])
+		})
+
+	case "empty":
+		fs := a.newFlagSet("trash empty", "")
+		yes := fs.Bool("yes", false, "confirm permanently deleting every trashed file")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if !*yes {
+			return usageError(fs, "emptying the trash cannot be undone; pass -yes to confirm")
+		}
+		result, err := a.client.Trash.EmptyInBatches(ctx, nil)
+		if err != nil {
+			return err
+		}
+		err = a.print(result, func(w io.Writer) {
+			fmt.Fprintf(w, "permanently deleted %d files\n", result.DeletedCount)
+			for _, failed := range result.FailedDeletions {
+				fmt.Fprintf(w, "failed\t%d\t%s\n", failed.FileID, failed.Reason)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if n := len(result.FailedDeletions); n > 0 {
+			return fmt.Errorf("%d files could not be deleted", n)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown action %q", action)
+}
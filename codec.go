@@ -0,0 +1,64 @@
+package fimage
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+// Codec converts an alternative response encoding into JSON.
+//
+// Responses are normalized to JSON at the transport boundary, so the SDK's
+// json struct tags, custom unmarshalers, tolerant decoding, and error parsing
+// apply unchanged whatever the wire format. See the msgpack subpackage for a
+// MessagePack codec.
+type Codec interface {
+	// ContentType is the media type requested in the Accept header and
+	// recognized in response Content-Type headers (e.g. "application/msgpack").
+	ContentType() string
+
+	// ToJSON converts a response body in the codec's encoding to JSON.
+	ToJSON(data []byte) ([]byte, error)
+}
+
+// WithCodec requests responses in the codec's encoding. The Accept header
+// prefers codec.ContentType() while still accepting JSON, and each response
+// is decoded according to its Content-Type, so endpoints that only speak
+// JSON keep working.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token",
+//	    fimage.WithCodec(msgpack.Codec{}),
+//	)
+func WithCodec(codec Codec) ClientOption {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// accept returns the Accept header value for API requests.
+func (c *Client) accept() string {
+	if c.codec == nil {
+		return "application/json"
+	}
+	return c.codec.ContentType() + ", application/json;q=0.9"
+}
+
+// normalizeBody converts a response body encoded with the client's codec to
+// JSON. Bodies in any other encoding are returned unchanged.
+func (c *Client) normalizeBody(resp *http.Response, body []byte) ([]byte, error) {
+	if c.codec == nil || len(body) == 0 {
+		return body, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != c.codec.ContentType() {
+		return body, nil
+	}
+
+	data, err := c.codec.ToJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", mediaType, err)
+	}
+	return data, nil
+}
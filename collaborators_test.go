@@ -0,0 +1,65 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCollaborators(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/albums/3/collaborators":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			if body["email"] != "grandma@example.com" || body["role"] != "contributor" {
+				t.Errorf("unexpected body: %v", body)
+			}
+			_, _ = w.Write([]byte(`{"id":9,"album_id":3,"email":"grandma@example.com","role":"contributor","status":"pending"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/albums/3/collaborators":
+			_, _ = w.Write([]byte(`{"collaborators":[{"id":9,"email":"grandma@example.com","role":"contributor","status":"accepted","user_id":5}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/albums/3/collaborators/9":
+			_, _ = w.Write([]byte(`{"message":"removed"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	collab, err := client.Albums.Invite(ctx, 3, "grandma@example.com", RoleContributor)
+	if err != nil {
+		t.Fatalf("Invite returned error: %v", err)
+	}
+	if collab.ID != 9 || collab.Status != CollaboratorPending {
+		t.Fatalf("unexpected collaborator: %+v", collab)
+	}
+
+	if _, err := client.Albums.Invite(ctx, 3, "grandma@example.com", "owner"); err == nil {
+		t.Fatal("expected an error for an unsupported role")
+	}
+	if _, err := client.Albums.Invite(ctx, 3, "grandma", RoleViewer); err == nil {
+		t.Fatal("expected an error for an invalid email")
+	}
+
+	collabs, err := client.Albums.ListCollaborators(ctx, 3)
+	if err != nil {
+		t.Fatalf("ListCollaborators returned error: %v", err)
+	}
+	if len(collabs) != 1 || collabs[0].Status != CollaboratorAccepted || collabs[0].UserID == nil {
+		t.Fatalf("unexpected collaborators: %+v", collabs)
+	}
+
+	if _, err := client.Albums.RemoveCollaborator(ctx, 3, 9); err != nil {
+		t.Fatalf("RemoveCollaborator returned error: %v", err)
+	}
+}
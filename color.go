@@ -0,0 +1,102 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MaxColorTolerance is the largest tolerance accepted by SearchByColor.
+const MaxColorTolerance = 100
+
+// ColorSearchOptions contains options for searching files by color.
+type ColorSearchOptions struct {
+	// Page is the page number (1-indexed).
+	Page int
+
+	// Limit is the number of items per page (max 100).
+	Limit int
+
+	// Sort is the result ordering. Defaults to the closest match first.
+	Sort SortOrder
+
+	// AlbumID limits the search to an album.
+	AlbumID *int64
+
+	// MinCoverage is the minimum fraction (0-1) of the image the color must
+	// cover. Use 0.5 to find images that are mostly that color. Defaults to
+	// any file whose dominant colors include a match.
+	MinCoverage float64
+}
+
+// Validate checks the options for invalid fields.
+func (opts *ColorSearchOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	v.check(opts.Sort == "" || opts.Sort.Valid(), "Sort", "has unsupported value %q", opts.Sort)
+	v.check(opts.MinCoverage >= 0 && opts.MinCoverage <= 1, "MinCoverage", "must be between 0 and 1")
+	return v.err()
+}
+
+// SearchByColor returns the files whose dominant colors are close to
+// hexColor (#RGB or #RRGGBB), using the server-side color index. Tolerance
+// is the perceptual distance allowed from hexColor, from 0 (exact match) to
+// MaxColorTolerance (any color).
+//
+// Example:
+//
+//	// Images that are mostly blue
+//	resp, err := client.Files.SearchByColor(ctx, "#1E90FF", 20, &fimage.ColorSearchOptions{
+//	    MinCoverage: 0.5,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, file := range resp.Files {
+//	    fmt.Println(file.URL)
+//	}
+func (s *FilesService) SearchByColor(ctx context.Context, hexColor string, tolerance int, opts *ColorSearchOptions, callOpts ...CallOption) (*FilesListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if !isHexColor(hexColor) {
+		return nil, fmt.Errorf("invalid color %q: must be a hex color like #1E90FF", hexColor)
+	}
+	if tolerance < 0 || tolerance > MaxColorTolerance {
+		return nil, fmt.Errorf("color tolerance must be between 0 and %d, got %d", MaxColorTolerance, tolerance)
+	}
+
+	query := url.Values{}
+	query.Set("color", hexColor)
+	query.Set("tolerance", strconv.Itoa(tolerance))
+
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Sort != "" {
+			query.Set("sort", string(opts.Sort))
+		}
+		if opts.AlbumID != nil {
+			query.Set("album_id", strconv.FormatInt(*opts.AlbumID, 10))
+		}
+		if opts.MinCoverage > 0 {
+			query.Set("min_coverage", strconv.FormatFloat(opts.MinCoverage, 'f', -1, 64))
+		}
+	}
+
+	setDefaultLimit(ctx, query)
+
+	var resp FilesListResponse
+	if err := s.client.requestWithQuery(ctx, "/api/files/color/search", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,62 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchByColor(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/files/color/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("color") != "#1E90FF" || q.Get("tolerance") != "20" || q.Get("min_coverage") != "0.25" || q.Get("album_id") != "4" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1}],"total":1,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	albumID := int64(4)
+	resp, err := client.Files.SearchByColor(context.Background(), "#1E90FF", 20, &ColorSearchOptions{
+		AlbumID:     &albumID,
+		MinCoverage: 0.25,
+	})
+	if err != nil {
+		t.Fatalf("SearchByColor returned error: %v", err)
+	}
+	if len(resp.Files) != 1 {
+		t.Fatalf("unexpected files: %+v", resp.Files)
+	}
+
+	invalid := []struct {
+		color     string
+		tolerance int
+		opts      *ColorSearchOptions
+	}{
+		{"1E90FF", 20, nil},
+		{"#1E90F", 20, nil},
+		{"#GGGGGG", 20, nil},
+		{"#abc", -1, nil},
+		{"#abc", MaxColorTolerance + 1, nil},
+		{"#abc", 20, &ColorSearchOptions{MinCoverage: 1.5}},
+	}
+	for _, tt := range invalid {
+		if _, err := client.Files.SearchByColor(context.Background(), tt.color, tt.tolerance, tt.opts); err == nil {
+			t.Errorf("SearchByColor(%q, %d, %+v): expected error", tt.color, tt.tolerance, tt.opts)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("invalid searches reached the server: %d requests", requests)
+	}
+}
@@ -0,0 +1,155 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ContributionOptions contains options for creating a contribution link.
+type ContributionOptions struct {
+	// ExpiresIn is the number of hours until the link expires.
+	// Leave as 0 for no expiration.
+	ExpiresIn int
+
+	// MaxUploads is the maximum number of files guests may upload in total.
+	// Leave as 0 for unlimited uploads.
+	MaxUploads int
+
+	// RequireName asks guests for their name before uploading. The name is
+	// stored as the description of their files.
+	RequireName bool
+}
+
+// Validate checks the options for invalid fields.
+func (opts *ContributionOptions) Validate() error {
+	var v validator
+	v.check(opts.ExpiresIn >= 0, "ExpiresIn", "must not be negative")
+	v.check(opts.MaxUploads >= 0, "MaxUploads", "must not be negative")
+	return v.err()
+}
+
+// ContributionLink is a link that lets guests without an account upload
+// photos to an album.
+type ContributionLink struct {
+	// ID is the unique identifier of the link.
+	ID int64 `json:"id"`
+
+	// AlbumID is the ID of the album guests upload to.
+	AlbumID int64 `json:"album_id"`
+
+	// Token is the link token.
+	Token string `json:"token"`
+
+	// URL is the full upload page URL to hand out to guests.
+	URL string `json:"url"`
+
+	// ExpiresAt is when the link expires (if set).
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// MaxUploads is the upload limit, or 0 when unlimited.
+	MaxUploads int `json:"max_uploads"`
+
+	// UploadCount is the number of files uploaded through the link.
+	UploadCount int `json:"upload_count"`
+
+	// RequireName indicates if guests must give their name.
+	RequireName bool `json:"require_name"`
+
+	// IsActive indicates if the link still accepts uploads.
+	IsActive bool `json:"is_active"`
+
+	// CreatedAt is when the link was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateContributionLink creates a link that lets guests upload photos to an
+// album without an account, e.g. for collecting wedding or event photos.
+//
+// Example:
+//
+//	link, err := client.Albums.CreateContributionLink(ctx, 123, &fimage.ContributionOptions{
+//	    ExpiresIn:   72,
+//	    MaxUploads:  500,
+//	    RequireName: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Share with guests: %s\n", link.URL)
+func (s *AlbumsService) CreateContributionLink(ctx context.Context, albumID int64, opts *ContributionOptions, callOpts ...CallOption) (*ContributionLink, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if opts == nil {
+		opts = &ContributionOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		ExpiresIn   int  `json:"expires_in,omitempty"`
+		MaxUploads  int  `json:"max_uploads,omitempty"`
+		RequireName bool `json:"require_name"`
+	}{
+		ExpiresIn:   opts.ExpiresIn,
+		MaxUploads:  opts.MaxUploads,
+		RequireName: opts.RequireName,
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/contribution-links", albumID)
+
+	var link ContributionLink
+	if err := s.client.request(ctx, http.MethodPost, path, req, &link); err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// ListContributionLinks returns the contribution links of an album,
+// including expired and revoked ones.
+//
+// Example:
+//
+//	links, err := client.Albums.ListContributionLinks(ctx, 123)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, l := range links {
+//	    fmt.Printf("%s: %d uploads (active: %v)\n", l.URL, l.UploadCount, l.IsActive)
+//	}
+func (s *AlbumsService) ListContributionLinks(ctx context.Context, albumID int64, callOpts ...CallOption) ([]ContributionLink, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	path := fmt.Sprintf("/api/albums/%d/contribution-links", albumID)
+
+	var resp struct {
+		Links []ContributionLink `json:"links"`
+	}
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Links, nil
+}
+
+// RevokeContributionLink stops a contribution link from accepting uploads.
+// Files already uploaded through it stay in the album.
+//
+// Example:
+//
+//	_, err := client.Albums.RevokeContributionLink(ctx, 123, link.ID)
+func (s *AlbumsService) RevokeContributionLink(ctx context.Context, albumID, linkID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	path := fmt.Sprintf("/api/albums/%d/contribution-links/%d", albumID, linkID)
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodDelete, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,69 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContributionLinks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/albums/3/contribution-links":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			if body["expires_in"] != float64(72) || body["require_name"] != true {
+				t.Errorf("unexpected body: %v", body)
+			}
+			if _, ok := body["max_uploads"]; ok {
+				t.Errorf("unlimited uploads should be omitted: %v", body)
+			}
+			_, _ = w.Write([]byte(`{"id":4,"album_id":3,"url":"https://f-image.com/c/abc","require_name":true,"is_active":true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/albums/3/contribution-links":
+			_, _ = w.Write([]byte(`{"links":[{"id":4,"upload_count":12,"is_active":true}]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/albums/3/contribution-links/4":
+			_, _ = w.Write([]byte(`{"message":"revoked"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	link, err := client.Albums.CreateContributionLink(ctx, 3, &ContributionOptions{ExpiresIn: 72, RequireName: true})
+	if err != nil {
+		t.Fatalf("CreateContributionLink returned error: %v", err)
+	}
+	if link.ID != 4 || link.URL == "" || !link.IsActive {
+		t.Fatalf("unexpected link: %+v", link)
+	}
+
+	if _, err := client.Albums.CreateContributionLink(ctx, 3, &ContributionOptions{MaxUploads: -1}); err == nil {
+		t.Fatal("expected an error for negative MaxUploads")
+	}
+
+	links, err := client.Albums.ListContributionLinks(ctx, 3)
+	if err != nil {
+		t.Fatalf("ListContributionLinks returned error: %v", err)
+	}
+	if len(links) != 1 || links[0].UploadCount != 12 {
+		t.Fatalf("unexpected links: %+v", links)
+	}
+
+	resp, err := client.Albums.RevokeContributionLink(ctx, 3, 4)
+	if err != nil {
+		t.Fatalf("RevokeContributionLink returned error: %v", err)
+	}
+	if resp.Message != "revoked" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
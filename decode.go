@@ -0,0 +1,90 @@
+package fimage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeWarning describes a record skipped by tolerant decoding.
+type DecodeWarning struct {
+	// Index is the position of the skipped record in the response.
+	Index int
+
+	// Err is the decoding error for the record.
+	Err error
+}
+
+// String returns a human-readable description of the warning.
+func (w DecodeWarning) String() string {
+	return fmt.Sprintf("record %d skipped: %v", w.Index, w.Err)
+}
+
+// tolerantDecoder is implemented by list responses that support skipping
+// malformed records.
+type tolerantDecoder interface {
+	decodeTolerant(data []byte) error
+}
+
+// decode decodes a response body into result, honoring tolerant decoding.
+func (c *Client) decode(data []byte, result interface{}) error {
+	if c.tolerantDecoding {
+		if td, ok := result.(tolerantDecoder); ok {
+			return td.decodeTolerant(data)
+		}
+	}
+	return c.unmarshalJSON(data, result)
+}
+
+// decodeItems decodes each raw record individually, skipping the ones that fail.
+func decodeItems[T any](raw []json.RawMessage) ([]T, []DecodeWarning) {
+	items := make([]T, 0, len(raw))
+	var warnings []DecodeWarning
+	for i, r := range raw {
+		var item T
+		if err := json.Unmarshal(r, &item); err != nil {
+			warnings = append(warnings, DecodeWarning{Index: i, Err: err})
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, warnings
+}
+
+func (r *FilesListResponse) decodeTolerant(data []byte) error {
+	type alias FilesListResponse
+	aux := struct {
+		*alias
+		Files []json.RawMessage `json:"files"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Files, r.Warnings = decodeItems[File](aux.Files)
+	return nil
+}
+
+func (r *TrashListResponse) decodeTolerant(data []byte) error {
+	type alias TrashListResponse
+	aux := struct {
+		*alias
+		Files []json.RawMessage `json:"files"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Files, r.Warnings = decodeItems[File](aux.Files)
+	return nil
+}
+
+func (r *SharesListResponse) decodeTolerant(data []byte) error {
+	type alias SharesListResponse
+	aux := struct {
+		*alias
+		Shares []json.RawMessage `json:"shares"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Shares, r.Warnings = decodeItems[ShareLink](aux.Shares)
+	return nil
+}
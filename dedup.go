@@ -0,0 +1,106 @@
+package fimage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// HashCheck is the result of a deduplication pre-check.
+type HashCheck struct {
+	// Exists reports whether a file with the hash is already stored.
+	Exists bool `json:"exists"`
+
+	// File is the existing file when Exists is true.
+	File *File `json:"file,omitempty"`
+}
+
+// validSHA256 reports whether s is a hex-encoded SHA-256 digest.
+func validSHA256(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// CheckHash reports whether a file with the given SHA-256 digest (hex) is
+// already stored, returning the existing file if so. Use it to skip
+// uploading bytes the server already has.
+//
+// Example:
+//
+//	sum := sha256.Sum256(data)
+//	check, err := client.Files.CheckHash(ctx, hex.EncodeToString(sum[:]))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if check.Exists {
+//	    fmt.Println("Already uploaded:", check.File.URL)
+//	}
+func (s *FilesService) CheckHash(ctx context.Context, sha256Hex string, callOpts ...CallOption) (*HashCheck, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	sha256Hex = strings.ToLower(strings.TrimSpace(sha256Hex))
+	if !validSHA256(sha256Hex) {
+		return nil, fmt.Errorf("invalid SHA-256 digest %q", sha256Hex)
+	}
+
+	path := "/api/files/hash/" + sha256Hex
+
+	var check HashCheck
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &check); err != nil {
+		return nil, err
+	}
+
+	return &check, nil
+}
+
+// hashReader returns the hex SHA-256 digest of r's remaining bytes and a
+// reader that yields the same bytes again. Seekable readers are rewound;
+// others are buffered in memory.
+func hashReader(r io.Reader) (string, io.Reader, error) {
+	h := sha256.New()
+
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		if err == nil {
+			if _, err := io.Copy(h, seeker); err != nil {
+				return "", nil, fmt.Errorf("failed to hash file: %w", err)
+			}
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return "", nil, fmt.Errorf("failed to rewind file: %w", err)
+			}
+			return hex.EncodeToString(h.Sum(nil)), r, nil
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(io.MultiWriter(h, &buf), r); err != nil {
+		return "", nil, fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), &buf, nil
+}
+
+// uploadDataFromFile describes an existing file as a flash upload result.
+func uploadDataFromFile(f *File) *UploadData {
+	return &UploadData{
+		ID:           f.ID,
+		URL:          f.URL,
+		MediumURL:    f.MediumURL,
+		ThumbnailURL: f.ThumbnailURL,
+		OriginalName: f.OriginalName,
+		Description:  f.Description,
+		Size:         f.Size,
+		Width:        f.Width,
+		Height:       f.Height,
+		MimeType:     f.MimeType,
+		IsFlash:      true,
+		UploadType:   UploadTypeImage,
+	}
+}
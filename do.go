@@ -0,0 +1,59 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Do calls an API endpoint that has no typed method yet. path is relative to
+// BaseURL and may include a query string. body, if not nil, is sent as JSON,
+// and the response is decoded into out if it is not nil. Requests go through
+// the same authentication, rate limiting, and error handling as typed
+// methods, and failures are returned as *APIError.
+//
+// Example:
+//
+//	var out struct {
+//	    Palette []string `json:"palette"`
+//	}
+//	err := client.Do(ctx, http.MethodGet, "/api/files/123/palette", nil, &out)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("invalid path %q: must start with /", path)
+	}
+	return c.request(ctx, method, path, body, out)
+}
+
+// NewRequest returns an authenticated request for an API endpoint, for
+// endpoints Do cannot express such as multipart or streaming bodies. path is
+// relative to BaseURL. The request carries the client's headers; send it
+// with the client's HTTPClient and check the response status yourself.
+// Middleware added with WithMiddleware does not apply to it.
+//
+// Example:
+//
+//	req, err := client.NewRequest(ctx, http.MethodPut, "/api/files/123/raw", f)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	req.Header.Set("Content-Type", "image/png")
+//	resp, err := client.HTTPClient.Do(req)
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid path %q: must start with /", path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req)
+
+	return req, nil
+}
@@ -29,4 +29,16 @@
 //   - Share: Create and manage share links
 //   - Tags: Tag and categorize images
 //   - Trash: Manage deleted files
+//   - Jobs: Follow long-running server-side jobs
+//   - Account: Manage account-level settings such as upload-by-email
+//   - Gallery: Configure the public gallery page
+//   - Transforms: Apply image transformations in bulk
+//   - Presets: Manage named transform presets
+//   - Encryption: Rotate keys of client-encrypted files
+//   - Admin: Operate self-hosted instances (requires an admin token)
+//   - Pins: Pin albums and files to the top of the UI
+//   - Notifications: List and acknowledge in-app notifications
 package fimage
+
+//go:generate go run gen-accessors.go
+//go:generate go run gen-openapi.go
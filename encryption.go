@@ -0,0 +1,152 @@
+package fimage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// EncryptionAlgorithm is the algorithm used for client-side encryption.
+const EncryptionAlgorithm = "AES-256-GCM"
+
+// Attribute keys recording how a file was encrypted on the client.
+const (
+	// AttrEncryptionAlgorithm holds EncryptionAlgorithm for encrypted files.
+	AttrEncryptionAlgorithm = "fimage.enc.alg"
+
+	// AttrEncryptionKeyID holds the KeyID of the key a file was encrypted with.
+	AttrEncryptionKeyID = "fimage.enc.kid"
+)
+
+// encryptionMagic prefixes every encrypted object.
+var encryptionMagic = []byte("FIMGENC1")
+
+// ErrDecrypt is returned when encrypted data cannot be decrypted, e.g.
+// because it was encrypted with a different key or has been modified.
+var ErrDecrypt = errors.New("fimage: failed to decrypt data")
+
+// WithEncryptionKey enables client-side encryption with a 32-byte AES-256
+// key. Image uploads are encrypted before they leave the process, so the
+// server only stores ciphertext; the algorithm and KeyID are recorded in the
+// file's custom attributes. Because the server cannot read encrypted images,
+// no medium or thumbnail variants are generated for them.
+//
+// Keep the key safe: files cannot be recovered without it.
+func WithEncryptionKey(key []byte) ClientOption {
+	return func(c *Client) {
+		c.encryptionKey = append([]byte(nil), key...)
+	}
+}
+
+// KeyID returns a short fingerprint identifying an encryption key without
+// revealing it.
+func KeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Encrypted reports whether the file was encrypted on the client.
+func (f *File) Encrypted() bool {
+	return f.Attributes[AttrEncryptionAlgorithm] != ""
+}
+
+// newGCM returns an AES-GCM cipher for key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns magic || nonce || ciphertext for plaintext.
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(encryptionMagic)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, encryptionMagic...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, encryptionMagic), nil
+}
+
+// decrypt reverses encrypt.
+func decrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(data, encryptionMagic) || len(data) < len(encryptionMagic)+gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: not an encrypted object", ErrDecrypt)
+	}
+	data = data[len(encryptionMagic):]
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, encryptionMagic)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	return plaintext, nil
+}
+
+// encryptReader reads r fully and returns an encrypted copy.
+func (c *Client) encryptReader(r io.Reader) (io.Reader, error) {
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	ciphertext, err := encrypt(c.encryptionKey, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt file: %w", err)
+	}
+	return bytes.NewReader(ciphertext), nil
+}
+
+// encryptionAttributes returns the attributes recorded on encrypted files.
+func (c *Client) encryptionAttributes() map[string]string {
+	return map[string]string{
+		AttrEncryptionAlgorithm: EncryptionAlgorithm,
+		AttrEncryptionKeyID:     KeyID(c.encryptionKey),
+	}
+}
+
+// Decrypt decrypts the bytes of a file uploaded with client-side encryption
+// using the client's encryption key. It returns ErrDecrypt if the data was
+// encrypted with another key or modified.
+//
+// Example:
+//
+//	resp, err := http.Get(file.URL)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer resp.Body.Close()
+//
+//	image, err := client.Decrypt(resp.Body)
+func (c *Client) Decrypt(r io.Reader) ([]byte, error) {
+	if len(c.encryptionKey) == 0 {
+		return nil, fmt.Errorf("client has no encryption key")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted data: %w", err)
+	}
+	return decrypt(c.encryptionKey, data)
+}
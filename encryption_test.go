@@ -0,0 +1,205 @@
+package fimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUploadEncryptsWithClientKey(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{7}, 32)
+	var stored []byte
+	var attrs map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/files/upload":
+			if r.URL.Query().Get("single_file_only") != "true" {
+				t.Errorf("expected single_file_only for encrypted upload")
+			}
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("FormFile: %v", err)
+			}
+			stored, _ = io.ReadAll(file)
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":5}}`))
+		case "/api/files/5/attributes":
+			var req struct {
+				Attributes map[string]string `json:"attributes"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			attrs = req.Attributes
+			_, _ = w.Write([]byte(`{"attributes":{}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithEncryptionKey(key))
+
+	if _, err := client.Files.Upload(context.Background(), strings.NewReader("secret pixels"), nil); err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+	if bytes.Contains(stored, []byte("secret pixels")) {
+		t.Fatal("server received plaintext")
+	}
+	if attrs[AttrEncryptionAlgorithm] != EncryptionAlgorithm || attrs[AttrEncryptionKeyID] != KeyID(key) {
+		t.Fatalf("unexpected attributes: %v", attrs)
+	}
+
+	plaintext, err := client.Decrypt(bytes.NewReader(stored))
+	if err != nil || string(plaintext) != "secret pixels" {
+		t.Fatalf("Decrypt = %q, %v", plaintext, err)
+	}
+
+	other := NewClient("test-token", WithEncryptionKey(bytes.Repeat([]byte{8}, 32)))
+	if _, err := other.Decrypt(bytes.NewReader(stored)); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("expected ErrDecrypt with wrong key, got %v", err)
+	}
+}
+
+func TestRotateReencryptsAndResumes(t *testing.T) {
+	t.Parallel()
+
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+	oldBlob, _ := encrypt(oldKey, []byte("one"))
+	newBlob, _ := encrypt(newKey, []byte("two")) // replaced before an interruption
+
+	var (
+		mu       sync.Mutex
+		replaced = map[string][]byte{}
+		relabel  = map[string]string{}
+	)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/files/attributes/search":
+			if r.URL.Query().Get("value") != KeyID(oldKey) {
+				t.Errorf("unexpected key ID: %s", r.URL.Query().Get("value"))
+			}
+			_, _ = w.Write([]byte(`{"files":[{"id":1,"url":"` + server.URL + `/cdn/1"},{"id":2,"url":"` + server.URL + `/cdn/2"}]}`))
+		case r.URL.Path == "/cdn/1":
+			_, _ = w.Write(oldBlob)
+		case r.URL.Path == "/cdn/2":
+			_, _ = w.Write(newBlob)
+		case strings.HasSuffix(r.URL.Path, "/replace"):
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("FormFile: %v", err)
+			}
+			data, _ := io.ReadAll(file)
+			mu.Lock()
+			replaced[r.URL.Path] = data
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"success":true}`))
+		case strings.HasSuffix(r.URL.Path, "/attributes"):
+			var req struct {
+				Attributes map[string]string `json:"attributes"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			relabel[r.URL.Path] = req.Attributes[AttrEncryptionKeyID]
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"attributes":{}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var calls int
+	result, err := client.Encryption.Rotate(context.Background(), oldKey, newKey, &RotationScope{
+		OnProgress: func(RotationProgress) { calls++ },
+	})
+	if err != nil {
+		t.Fatalf("Rotate returned error: %v", err)
+	}
+	if result.Total != 2 || result.Rotated != 2 || len(result.Failed) != 0 || calls != 2 {
+		t.Fatalf("unexpected result: %+v (progress calls %d)", result, calls)
+	}
+	if len(replaced) != 1 {
+		t.Fatalf("expected only file 1 to be replaced, got %v", replaced)
+	}
+	if plaintext, err := decrypt(newKey, replaced["/api/files/1/replace"]); err != nil || string(plaintext) != "one" {
+		t.Fatalf("file 1 not re-encrypted with new key: %q, %v", plaintext, err)
+	}
+	for _, path := range []string{"/api/files/1/attributes", "/api/files/2/attributes"} {
+		if relabel[path] != KeyID(newKey) {
+			t.Fatalf("%s not relabelled: %v", path, relabel)
+		}
+	}
+}
+
+func TestDownloadStreamsVariantAndDecrypts(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{7}, 32)
+	plaintext := []byte("\xff\xd8\xff\xe0 fake jpeg")
+	ciphertext, err := encrypt(key, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("download is not authenticated")
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", `attachment; filename="photo.jpg"`)
+		switch r.URL.Path {
+		case "/api/files/1/download":
+			if got := r.URL.Query().Get("variant"); got != "medium" {
+				t.Errorf("unexpected variant: %q", got)
+			}
+			_, _ = w.Write(plaintext)
+		case "/api/files/2/download":
+			_, _ = w.Write(ciphertext)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithEncryptionKey(key))
+
+	for _, tc := range []struct {
+		id        int64
+		opts      *DownloadOptions
+		decrypted bool
+	}{
+		{1, &DownloadOptions{Variant: VariantMedium}, false},
+		{2, nil, true},
+	} {
+		dl, err := client.Files.Download(context.Background(), tc.id, tc.opts)
+		if err != nil {
+			t.Fatalf("file %d: Download returned error: %v", tc.id, err)
+		}
+		got, err := io.ReadAll(dl)
+		dl.Close()
+		if err != nil {
+			t.Fatalf("file %d: read failed: %v", tc.id, err)
+		}
+		if !bytes.Equal(got, plaintext) || dl.Decrypted != tc.decrypted || dl.Filename != "photo.jpg" {
+			t.Fatalf("file %d: unexpected download %q (decrypted=%v, filename=%q)", tc.id, got, dl.Decrypted, dl.Filename)
+		}
+	}
+
+	if _, err := client.Files.Download(context.Background(), 1, &DownloadOptions{Variant: "huge"}); err == nil {
+		t.Fatal("expected validation error for unknown variant")
+	}
+}
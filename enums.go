@@ -0,0 +1,139 @@
+package fimage
+
+// Variant identifies a stored size variant of an image.
+type Variant string
+
+const (
+	// VariantOriginal is the original uploaded image.
+	VariantOriginal Variant = "original"
+
+	// VariantMedium is the medium-sized variant.
+	VariantMedium Variant = "medium"
+
+	// VariantThumbnail is the thumbnail variant.
+	VariantThumbnail Variant = "thumbnail"
+)
+
+// Valid reports whether v is a known variant.
+func (v Variant) Valid() bool {
+	switch v {
+	case VariantOriginal, VariantMedium, VariantThumbnail:
+		return true
+	}
+	return false
+}
+
+// SortOrder selects the ordering of list and search results.
+type SortOrder string
+
+const (
+	// SortCreatedDesc orders newest files first. This is the server default.
+	SortCreatedDesc SortOrder = "created_desc"
+
+	// SortCreatedAsc orders oldest files first.
+	SortCreatedAsc SortOrder = "created_asc"
+
+	// SortNameAsc orders files by name, A to Z.
+	SortNameAsc SortOrder = "name_asc"
+
+	// SortNameDesc orders files by name, Z to A.
+	SortNameDesc SortOrder = "name_desc"
+
+	// SortSizeDesc orders largest files first.
+	SortSizeDesc SortOrder = "size_desc"
+
+	// SortSizeAsc orders smallest files first.
+	SortSizeAsc SortOrder = "size_asc"
+)
+
+// Valid reports whether o is a known sort order.
+func (o SortOrder) Valid() bool {
+	switch o {
+	case SortCreatedDesc, SortCreatedAsc, SortNameAsc, SortNameDesc, SortSizeDesc, SortSizeAsc:
+		return true
+	}
+	return false
+}
+
+// MimeType is an image MIME type supported by F-Image.
+type MimeType string
+
+const (
+	// MimeJPEG is the JPEG image type.
+	MimeJPEG MimeType = "image/jpeg"
+
+	// MimePNG is the PNG image type.
+	MimePNG MimeType = "image/png"
+
+	// MimeGIF is the GIF image type.
+	MimeGIF MimeType = "image/gif"
+
+	// MimeWebP is the WebP image type.
+	MimeWebP MimeType = "image/webp"
+
+	// MimeBMP is the BMP image type.
+	MimeBMP MimeType = "image/bmp"
+
+	// MimeAVIF is the AVIF image type.
+	MimeAVIF MimeType = "image/avif"
+
+	// MimeSVG is the SVG image type.
+	MimeSVG MimeType = "image/svg+xml"
+)
+
+// Valid reports whether m is a supported MIME type.
+func (m MimeType) Valid() bool {
+	switch m {
+	case MimeJPEG, MimePNG, MimeGIF, MimeWebP, MimeBMP, MimeAVIF, MimeSVG:
+		return true
+	}
+	return false
+}
+
+// Visibility controls who can view a file.
+type Visibility string
+
+const (
+	// VisibilityPublic lets anyone with the URL view the file, and lists it
+	// in the public gallery.
+	VisibilityPublic Visibility = "public"
+
+	// VisibilityUnlisted lets anyone with the URL view the file without
+	// listing it in the public gallery.
+	VisibilityUnlisted Visibility = "unlisted"
+
+	// VisibilityPrivate restricts the file to its owner and share links.
+	VisibilityPrivate Visibility = "private"
+)
+
+// Valid reports whether v is a known visibility.
+func (v Visibility) Valid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// Orientation is the shape of an image.
+type Orientation string
+
+const (
+	// OrientationLandscape matches images wider than they are tall.
+	OrientationLandscape Orientation = "landscape"
+
+	// OrientationPortrait matches images taller than they are wide.
+	OrientationPortrait Orientation = "portrait"
+
+	// OrientationSquare matches images whose width equals their height.
+	OrientationSquare Orientation = "square"
+)
+
+// Valid reports whether o is a known orientation.
+func (o Orientation) Valid() bool {
+	switch o {
+	case OrientationLandscape, OrientationPortrait, OrientationSquare:
+		return true
+	}
+	return false
+}
@@ -3,6 +3,9 @@ package fimage
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 )
 
 // Common errors returned by the SDK.
@@ -30,6 +33,47 @@ var (
 
 	// ErrInvalidFormat is returned when the file format is not allowed.
 	ErrInvalidFormat = errors.New("invalid format: file type not allowed")
+
+	// ErrNotSupported matches a *NotSupportedError with errors.Is.
+	ErrNotSupported = errors.New("feature not supported by this instance")
+
+	// ErrInvalidSharePassword is returned when a share password is wrong.
+	// Inspect APIError.AttemptsRemaining and APIError.RetryAfter for lockout details.
+	ErrInvalidSharePassword = errors.New("invalid share password")
+
+	// ErrClientClosed is returned by calls made after Close or Shutdown.
+	ErrClientClosed = errors.New("client is closed")
+)
+
+// Machine-readable error codes returned in APIError.Code.
+const (
+	// CodeFileNotFound is returned when a file does not exist.
+	CodeFileNotFound = "file_not_found"
+
+	// CodeAlbumNotFound is returned when an album does not exist.
+	CodeAlbumNotFound = "album_not_found"
+
+	// CodeShareNotFound is returned when a share link does not exist.
+	CodeShareNotFound = "share_not_found"
+
+	// CodeShareExpired is returned when a share link has expired.
+	CodeShareExpired = "share_expired"
+
+	// CodeSharePasswordInvalid is returned when a share password is wrong.
+	CodeSharePasswordInvalid = "share_password_invalid"
+
+	// CodeQuotaExceeded is returned when the storage quota is exhausted.
+	CodeQuotaExceeded = "quota_exceeded"
+
+	// CodeFileTooLarge is returned when an upload exceeds the size limit.
+	CodeFileTooLarge = "file_too_large"
+
+	// CodeInvalidFormat is returned when the file type is not allowed.
+	CodeInvalidFormat = "invalid_format"
+
+	// CodeAdminScopeRequired is returned when an Admin method is called with
+	// a token that lacks the admin scope.
+	CodeAdminScopeRequired = "admin_scope_required"
 )
 
 // APIError represents an error returned by the F-Image API.
@@ -37,9 +81,19 @@ type APIError struct {
 	// StatusCode is the HTTP status code.
 	StatusCode int
 
-	// Message is the error message from the API.
+	// Message is the error message from the API. It may be localized
+	// according to WithLocale, so prefer Code for programmatic checks.
 	Message string
 
+	// Code is the machine-readable error code (e.g., "file_not_found").
+	// Unlike Message, it is never localized.
+	Code string
+
+	// Details holds additional structured information about the error,
+	// such as the invalid fields of a 400 response. It is nil when the
+	// server sent none.
+	Details map[string]interface{}
+
 	// URL is an existing resource URL returned by the API when relevant.
 	URL string
 
@@ -54,11 +108,110 @@ type APIError struct {
 
 	// ForceUpdateRequired indicates the caller must opt-in to overwrite the resource.
 	ForceUpdateRequired bool
+
+	// AttemptsRemaining is the number of password attempts left before the
+	// share is locked. It is nil when the server did not report it.
+	AttemptsRemaining *int
+
+	// RetryAfter is how long the caller should wait before trying again.
+	// It is zero when the server did not report it.
+	RetryAfter time.Duration
+
+	// RequestID is the X-Request-ID of the failed request. Include it in
+	// support tickets.
+	RequestID string
+
+	// RateLimit is the rate limit state reported with a 429 response. It
+	// is nil for other errors or when the server sent no rate limit headers.
+	RateLimit *RateLimit
+
+	// Method and Path are the HTTP method and URL path of the failed
+	// request, e.g. "GET" and "/api/files/123". They are empty for items
+	// of batch results.
+	Method string
+	Path   string
+
+	// Body is the raw response body as received, before any WithCodec
+	// conversion, for logging responses the SDK could not fully interpret.
+	Body []byte
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
-	return fmt.Sprintf("f-image API error (status %d): %s", e.StatusCode, e.Message)
+	var b strings.Builder
+	b.WriteString("f-image API error (")
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s, ", e.Method, e.Path)
+	}
+	fmt.Fprintf(&b, "status %d", e.StatusCode)
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request %s", e.RequestID)
+	}
+	fmt.Fprintf(&b, "): %s", e.Message)
+	return b.String()
+}
+
+// Is reports whether the API error matches one of the sentinel errors, so
+// errors.Is(err, fimage.ErrNotFound) works like IsNotFound. Without an error
+// code, a 402 matches ErrQuotaExceeded and a 413 matches ErrFileTooLarge.
+//
+// Example:
+//
+//	_, err := client.Files.Get(ctx, 123)
+//	if errors.Is(err, fimage.ErrNotFound) {
+//	    fmt.Println("File is gone")
+//	}
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrQuotaExceeded:
+		return e.Code == CodeQuotaExceeded ||
+			(e.StatusCode == http.StatusPaymentRequired && e.Code != CodeFileTooLarge)
+	case ErrFileTooLarge:
+		return e.Code == CodeFileTooLarge ||
+			(e.StatusCode == http.StatusRequestEntityTooLarge && e.Code != CodeQuotaExceeded)
+	case ErrInvalidFormat:
+		return e.Code == CodeInvalidFormat
+	case ErrInvalidSharePassword:
+		return e.Code == CodeSharePasswordInvalid
+	}
+	return false
+}
+
+// NotSupportedError is returned when the target instance does not support
+// the feature a method needs, either according to Capabilities or because
+// the endpoint is missing (404 without an error code, or 501).
+type NotSupportedError struct {
+	// Feature is the unsupported feature.
+	Feature Feature
+
+	// Err is the API error that revealed the missing endpoint. It is nil
+	// when the cached capabilities ruled the call out before it was made.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("feature %q not supported by this instance", e.Feature)
+}
+
+// Unwrap returns the underlying API error.
+func (e *NotSupportedError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrNotSupported.
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
 }
 
 // IsNotFound returns true if the error is a not found error.
@@ -114,3 +267,19 @@ func IsQuotaExceeded(err error) bool {
 	}
 	return errors.Is(err, ErrQuotaExceeded)
 }
+
+// IsCode returns true if the error is an API error with the given
+// machine-readable code.
+//
+// Example:
+//
+//	if fimage.IsCode(err, fimage.CodeSharePasswordInvalid) {
+//	    fmt.Println("Wrong password")
+//	}
+func IsCode(err error, code string) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code != "" && apiErr.Code == code
+	}
+	return false
+}
@@ -162,14 +162,16 @@ func deleteFile(ctx context.Context, client *fimage.Client, fileID int64) {
 
 // batchDeleteFiles deletes multiple files at once.
 func batchDeleteFiles(ctx context.Context, client *fimage.Client, fileIDs []int64) {
-	resp, err := client.Files.BatchDelete(ctx, fileIDs)
+	result, err := client.Files.BatchDelete(ctx, fileIDs)
 	if err != nil {
 		log.Printf("Error batch deleting files: %v\n", err)
 		return
 	}
 
 	fmt.Printf("Batch delete complete:\n")
-	fmt.Printf("  Deleted: %d\n", resp.Deleted)
-	fmt.Printf("  Failed: %d\n", resp.Failed)
-	fmt.Printf("  Message: %s\n", resp.Message)
+	fmt.Printf("  Deleted: %d\n", len(result.Succeeded))
+	fmt.Printf("  Failed: %d\n", len(result.Failed))
+	for _, failure := range result.Failed {
+		fmt.Printf("    File %d: %v\n", failure.ID, failure.Err)
+	}
 }
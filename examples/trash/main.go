@@ -110,15 +110,18 @@ func restoreFile(ctx context.Context, client *fimage.Client, fileID int64) {
 
 // restoreMultiple restores multiple files from trash.
 func restoreMultiple(ctx context.Context, client *fimage.Client, fileIDs []int64) {
-	resp, err := client.Trash.RestoreMany(ctx, fileIDs)
+	result, err := client.Trash.RestoreMany(ctx, fileIDs)
 	if err != nil {
 		log.Printf("Error restoring files: %v\n", err)
 		return
 	}
 
 	fmt.Printf("Restore complete:\n")
-	fmt.Printf("  Restored: %d\n", resp.Restored)
-	fmt.Printf("  Failed: %d\n", resp.Failed)
+	fmt.Printf("  Restored: %d\n", len(result.Succeeded))
+	fmt.Printf("  Failed: %d\n", len(result.Failed))
+	for _, failure := range result.Failed {
+		fmt.Printf("    File %d: %v\n", failure.ID, failure.Err)
+	}
 }
 
 // permanentDelete permanently deletes a file from trash.
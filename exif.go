@@ -0,0 +1,214 @@
+package fimage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// EXIF is the camera metadata embedded in an image.
+type EXIF struct {
+	// Make is the camera manufacturer.
+	Make string `json:"make,omitempty"`
+
+	// Model is the camera model.
+	Model string `json:"model,omitempty"`
+
+	// LensModel is the lens model.
+	LensModel string `json:"lens_model,omitempty"`
+
+	// ExposureTime is the shutter speed as a fraction (e.g. "1/250").
+	ExposureTime string `json:"exposure_time,omitempty"`
+
+	// FNumber is the aperture f-number.
+	FNumber float64 `json:"f_number,omitempty"`
+
+	// ISO is the ISO sensitivity.
+	ISO int `json:"iso,omitempty"`
+
+	// FocalLength is the focal length in millimeters.
+	FocalLength float64 `json:"focal_length,omitempty"`
+
+	// Orientation is the EXIF orientation (1-8), or 0 if not set.
+	Orientation int `json:"orientation,omitempty"`
+
+	// Software is the software that produced the image.
+	Software string `json:"software,omitempty"`
+
+	// TakenAt is when the photo was taken.
+	TakenAt *time.Time `json:"taken_at,omitempty"`
+
+	// Location is the GPS position the photo was taken at.
+	Location *GeoPoint `json:"location,omitempty"`
+
+	// Raw holds every tag the server extracted, keyed by tag name.
+	Raw map[string]string `json:"raw,omitempty"`
+}
+
+// GetEXIF returns the EXIF metadata of a file's stored original. Files
+// uploaded with StripEXIF or StripModeAll have none; StripModeGPS removes
+// Location.
+//
+// Example:
+//
+//	exif, err := client.Files.GetEXIF(ctx, 123)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s %s, ISO %d\n", exif.Make, exif.Model, exif.ISO)
+//	if exif.Location != nil {
+//	    fmt.Println("Contains GPS data!")
+//	}
+func (s *FilesService) GetEXIF(ctx context.Context, fileID int64, callOpts ...CallOption) (*EXIF, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	path := fmt.Sprintf("/api/files/%d/exif", fileID)
+
+	var exif EXIF
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &exif); err != nil {
+		return nil, err
+	}
+
+	return &exif, nil
+}
+
+var (
+	jpegMagic = []byte{0xFF, 0xD8}
+	pngMagic  = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+)
+
+// JPEG markers of segments removed by stripEXIF: APP1 (EXIF and XMP),
+// APP13 (Photoshop IPTC), and comments. APP2 (ICC color profiles) is kept.
+const (
+	jpegSOS   = 0xDA
+	jpegAPP1  = 0xE1
+	jpegAPP13 = 0xED
+	jpegCOM   = 0xFE
+)
+
+// strippedPNGChunks are the PNG chunks removed by stripEXIF.
+var strippedPNGChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripEXIF returns a reader that yields r's image with embedded metadata
+// removed. JPEG and PNG images are filtered while streaming; other formats
+// are passed through unchanged.
+func stripEXIF(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(pngMagic))
+	switch {
+	case bytes.Equal(magic, pngMagic):
+		return &pngStripper{r: br, cur: io.LimitReader(br, int64(len(pngMagic)))}, nil
+	case bytes.HasPrefix(magic, jpegMagic):
+		return stripJPEG(br)
+	}
+	return br, nil
+}
+
+// stripJPEG reads the segments before the first scan, drops metadata
+// segments, and returns the remaining header followed by the image data.
+func stripJPEG(br *bufio.Reader) (io.Reader, error) {
+	var header bytes.Buffer
+	if _, err := io.CopyN(&header, br, int64(len(jpegMagic))); err != nil {
+		return nil, fmt.Errorf("failed to read JPEG: %w", err)
+	}
+
+	for {
+		marker, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JPEG: %w", err)
+		}
+		if marker != 0xFF {
+			return nil, fmt.Errorf("failed to read JPEG: invalid marker 0x%02X", marker)
+		}
+		kind, err := br.ReadByte()
+		for err == nil && kind == 0xFF { // fill bytes
+			kind, err = br.ReadByte()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JPEG: %w", err)
+		}
+
+		// Markers without a payload
+		if kind == 0x01 || (kind >= 0xD0 && kind <= 0xD7) {
+			header.Write([]byte{0xFF, kind})
+			continue
+		}
+
+		var size [2]byte
+		if _, err := io.ReadFull(br, size[:]); err != nil {
+			return nil, fmt.Errorf("failed to read JPEG: %w", err)
+		}
+		length := int(binary.BigEndian.Uint16(size[:]))
+		if length < 2 {
+			return nil, fmt.Errorf("failed to read JPEG: invalid segment length %d", length)
+		}
+
+		switch kind {
+		case jpegAPP1, jpegAPP13, jpegCOM:
+			if _, err := br.Discard(length - 2); err != nil {
+				return nil, fmt.Errorf("failed to read JPEG: %w", err)
+			}
+			continue
+		}
+
+		header.Write([]byte{0xFF, kind})
+		header.Write(size[:])
+		if _, err := io.CopyN(&header, br, int64(length-2)); err != nil {
+			return nil, fmt.Errorf("failed to read JPEG: %w", err)
+		}
+		if kind == jpegSOS {
+			return io.MultiReader(&header, br), nil
+		}
+	}
+}
+
+// pngStripper streams a PNG image, skipping metadata chunks.
+type pngStripper struct {
+	r   *bufio.Reader
+	cur io.Reader
+}
+
+// Read implements io.Reader.
+func (p *pngStripper) Read(b []byte) (int, error) {
+	for {
+		if p.cur != nil {
+			n, err := p.cur.Read(b)
+			if err == io.EOF {
+				p.cur = nil
+				if n > 0 {
+					return n, nil
+				}
+				continue
+			}
+			return n, err
+		}
+
+		var hdr [8]byte
+		if _, err := io.ReadFull(p.r, hdr[:]); err != nil {
+			if err == io.EOF {
+				return 0, io.EOF
+			}
+			return 0, fmt.Errorf("failed to read PNG: %w", err)
+		}
+		// Chunk data is followed by a 4-byte CRC.
+		remaining := int64(binary.BigEndian.Uint32(hdr[:4])) + 4
+		if strippedPNGChunks[string(hdr[4:8])] {
+			if _, err := io.CopyN(io.Discard, p.r, remaining); err != nil {
+				return 0, fmt.Errorf("failed to read PNG: %w", err)
+			}
+			continue
+		}
+		p.cur = io.MultiReader(bytes.NewReader(hdr[:]), io.LimitReader(p.r, remaining))
+	}
+}
@@ -0,0 +1,77 @@
+package fimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func jpegSegment(kind byte, payload string) []byte {
+	seg := []byte{0xFF, kind, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+func pngChunk(kind, data string) []byte {
+	chunk := make([]byte, 4, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, data...)
+	return append(chunk, "CRC!"...)
+}
+
+func TestStripEXIF(t *testing.T) {
+	t.Parallel()
+
+	var jpeg, wantJPEG bytes.Buffer
+	jpeg.Write(jpegMagic)
+	jpeg.Write(jpegSegment(0xE0, "JFIF\x00"))
+	jpeg.Write(jpegSegment(jpegAPP1, "Exif\x00\x00GPS"))
+	jpeg.Write(jpegSegment(0xE2, "ICC_PROFILE"))
+	jpeg.Write(jpegSegment(jpegCOM, "secret"))
+	jpeg.Write(jpegSegment(jpegSOS, "scan"))
+	jpeg.WriteString("\x12\x34\xFF\xE1image data\xFF\xD9")
+
+	wantJPEG.Write(jpegMagic)
+	wantJPEG.Write(jpegSegment(0xE0, "JFIF\x00"))
+	wantJPEG.Write(jpegSegment(0xE2, "ICC_PROFILE"))
+	wantJPEG.Write(jpegSegment(jpegSOS, "scan"))
+	wantJPEG.WriteString("\x12\x34\xFF\xE1image data\xFF\xD9")
+
+	var png, wantPNG bytes.Buffer
+	png.Write(pngMagic)
+	png.Write(pngChunk("IHDR", "header"))
+	png.Write(pngChunk("eXIf", "gps"))
+	png.Write(pngChunk("IDAT", "pixels"))
+	png.Write(pngChunk("tEXt", "Author\x00me"))
+	png.Write(pngChunk("IEND", ""))
+
+	wantPNG.Write(pngMagic)
+	wantPNG.Write(pngChunk("IHDR", "header"))
+	wantPNG.Write(pngChunk("IDAT", "pixels"))
+	wantPNG.Write(pngChunk("IEND", ""))
+
+	tests := []struct {
+		name string
+		in   []byte
+		want []byte
+	}{
+		{"jpeg", jpeg.Bytes(), wantJPEG.Bytes()},
+		{"png", png.Bytes(), wantPNG.Bytes()},
+		{"other", []byte("GIF89a..."), []byte("GIF89a...")},
+	}
+	for _, tt := range tests {
+		r, err := stripEXIF(bytes.NewReader(tt.in))
+		if err != nil {
+			t.Fatalf("%s: stripEXIF returned error: %v", tt.name, err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s: read failed: %v", tt.name, err)
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Fatalf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
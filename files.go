@@ -2,7 +2,6 @@ package fimage
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // FilesService handles file operations.
@@ -28,6 +28,20 @@ const (
 	UploadTypeLogo UploadType = "logo"
 )
 
+// StripMode selects which embedded metadata is removed from images.
+type StripMode string
+
+const (
+	// StripModeNone keeps all embedded metadata.
+	StripModeNone StripMode = ""
+
+	// StripModeGPS removes only location data (GPS EXIF tags).
+	StripModeGPS StripMode = "gps"
+
+	// StripModeAll removes all EXIF metadata.
+	StripModeAll StripMode = "all"
+)
+
 // UploadOptions contains options for uploading a file.
 type UploadOptions struct {
 	// Filename is the name to use for the uploaded file.
@@ -37,7 +51,8 @@ type UploadOptions struct {
 	// Description is an optional description for the file.
 	Description string
 
-	// AlbumID is the optional album to add the file to.
+	// AlbumID is the optional album to add the file to. It is sent as the
+	// album_id form field, so the file is in the album once Upload returns.
 	AlbumID *int64
 
 	// Type selects the upload behavior. Defaults to image.
@@ -51,6 +66,76 @@ type UploadOptions struct {
 
 	// SingleFileOnly skips medium and thumbnail generation for normal image uploads.
 	SingleFileOnly bool
+
+	// EmbedMetadata writes IPTC/XMP fields into the stored original.
+	EmbedMetadata *IPTC
+
+	// StripMetadata removes GPS or all EXIF metadata before the original is
+	// stored, so location data never persists on the server.
+	StripMetadata StripMode
+
+	// StripEXIF removes EXIF, XMP, IPTC, and comment metadata from JPEG and
+	// PNG images on the client, so it never leaves the machine. Other
+	// formats are uploaded unchanged. The EXIF orientation is removed too,
+	// so rotate images upright first if they depend on it. Size is ignored
+	// when it is set.
+	StripEXIF bool
+
+	// Size is the file length in bytes. When it is set, or when the reader
+	// is an *os.File, *bytes.Reader, *bytes.Buffer, or *strings.Reader, the
+	// upload is sent with a Content-Length header instead of chunked
+	// transfer encoding. It must match the number of bytes the reader
+	// yields.
+	Size int64
+
+	// Tags are tag names to add to the file. Missing tags are created.
+	Tags []string
+
+	// WatermarkPreset is the name of a transform preset applied to the
+	// stored file as a watermark.
+	WatermarkPreset string
+
+	// Visibility is the visibility of the file (default: the account
+	// default).
+	Visibility Visibility
+
+	// IgnoreAlbumDefaults uploads into AlbumID without applying the album's
+	// default settings (see AlbumsService.GetSettings). Otherwise album
+	// settings fill in StripMetadata, WatermarkPreset, and Visibility when
+	// they are unset, and add the album's auto tags to Tags.
+	IgnoreAlbumDefaults bool
+
+	// ComputeHash hashes the file before uploading and asks the server
+	// whether it is already stored (see FilesService.CheckHash). If it is,
+	// no bytes are sent and the existing file is returned as a flash upload;
+	// Description and AlbumID are not applied to it. Seekable readers are
+	// rewound after hashing; other readers are buffered in memory. Ignored
+	// for logo and encrypted uploads.
+	ComputeHash bool
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UploadOptions) Validate() error {
+	var v validator
+	switch opts.Type {
+	case "", UploadTypeImage:
+	case UploadTypeLogo:
+		v.check(strings.TrimSpace(opts.Domain) != "", "Domain", "is required for logo uploads")
+	default:
+		v.check(false, "Type", "has unsupported value %q", opts.Type)
+	}
+	switch opts.StripMetadata {
+	case StripModeNone, StripModeGPS, StripModeAll:
+	default:
+		v.check(false, "StripMetadata", "has unsupported value %q", opts.StripMetadata)
+	}
+	v.check(opts.Size >= 0, "Size", "must not be negative")
+	v.check(opts.WatermarkPreset == "" || validPresetName(opts.WatermarkPreset), "WatermarkPreset", "is not a valid preset name")
+	v.check(opts.Visibility == "" || opts.Visibility.Valid(), "Visibility", "has unsupported value %q", opts.Visibility)
+	for i, tag := range opts.Tags {
+		v.check(strings.TrimSpace(tag) != "", fmt.Sprintf("Tags[%d]", i), "must not be empty")
+	}
+	return v.err()
 }
 
 // Upload uploads an image file.
@@ -68,10 +153,15 @@ type UploadOptions struct {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Uploaded: %s\n", resp.Data.URL)
-func (s *FilesService) Upload(ctx context.Context, reader io.Reader, opts *UploadOptions) (*UploadResponse, error) {
+func (s *FilesService) Upload(ctx context.Context, reader io.Reader, opts *UploadOptions, callOpts ...CallOption) (*UploadResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	if opts == nil {
 		opts = &UploadOptions{}
 	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 
 	filename := opts.Filename
 	if filename == "" {
@@ -85,20 +175,45 @@ func (s *FilesService) Upload(ctx context.Context, reader io.Reader, opts *Uploa
 		uploadType = UploadTypeImage
 	}
 
-	switch uploadType {
-	case UploadTypeImage, UploadTypeLogo:
-	default:
-		return nil, fmt.Errorf("unsupported upload type: %s", uploadType)
+	if opts.AlbumID != nil && !opts.IgnoreAlbumDefaults && uploadType == UploadTypeImage {
+		settings, err := s.client.Albums.uploadDefaults(ctx, *opts.AlbumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load album settings: %w", err)
+		}
+		opts = opts.withAlbumDefaults(settings)
 	}
 
+	if opts.StripMetadata != StripModeNone {
+		fields["strip_metadata"] = string(opts.StripMetadata)
+	}
 	if opts.Description != "" {
 		fields["description"] = opts.Description
 	}
+	if opts.AlbumID != nil {
+		fields["album_id"] = strconv.FormatInt(*opts.AlbumID, 10)
+	}
+	if len(opts.Tags) > 0 {
+		tags, err := s.client.marshalJSON(opts.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tags: %w", err)
+		}
+		fields["tags"] = string(tags)
+	}
+	if opts.WatermarkPreset != "" {
+		fields["watermark_preset"] = opts.WatermarkPreset
+	}
+	if opts.Visibility != "" {
+		fields["visibility"] = string(opts.Visibility)
+	}
+	if opts.EmbedMetadata != nil {
+		embedded, err := s.client.marshalJSON(opts.EmbedMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal embedded metadata: %w", err)
+		}
+		fields["embed_metadata"] = string(embedded)
+	}
 	if uploadType == UploadTypeLogo {
 		domain := strings.TrimSpace(opts.Domain)
-		if domain == "" {
-			return nil, fmt.Errorf("domain is required for logo uploads")
-		}
 		query := url.Values{}
 		query.Set("type", string(uploadType))
 		query.Set("domain", domain)
@@ -106,22 +221,70 @@ func (s *FilesService) Upload(ctx context.Context, reader io.Reader, opts *Uploa
 			query.Set("force_update", "true")
 		}
 		path = path + "?" + query.Encode()
-	} else if opts.SingleFileOnly {
+	} else if opts.SingleFileOnly || s.client.encryptionKey != nil {
+		// Variants cannot be generated from client-encrypted bytes.
 		query := url.Values{}
 		query.Set("single_file_only", "true")
 		path = path + "?" + query.Encode()
 	}
 
-	respBody, err := s.client.uploadMultipart(ctx, path, reader, filename, fields)
+	size := opts.Size
+	if size <= 0 {
+		size = -1
+	}
+
+	encrypted := s.client.encryptionKey != nil && uploadType == UploadTypeImage
+
+	if opts.StripEXIF && uploadType == UploadTypeImage {
+		stripped, err := stripEXIF(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to strip EXIF: %w", err)
+		}
+		reader = stripped
+		size = -1
+	}
+
+	if opts.ComputeHash && uploadType == UploadTypeImage && !encrypted {
+		digest, r, err := hashReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		reader = r
+
+		check, err := s.CheckHash(ctx, digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check hash: %w", err)
+		}
+		if check.Exists && check.File != nil {
+			return &UploadResponse{Success: true, Status: http.StatusOK, Data: uploadDataFromFile(check.File)}, nil
+		}
+		fields["sha256"] = digest
+	}
+
+	if encrypted {
+		var err error
+		if reader, err = s.client.encryptReader(reader); err != nil {
+			return nil, err
+		}
+		size = -1
+	}
+
+	respBody, err := s.client.uploadMultipart(ctx, path, reader, filename, fields, size)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp UploadResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
+	if err := s.client.unmarshalJSON(respBody, &resp); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	if encrypted && resp.Data != nil {
+		if _, err := s.SetAttributes(ctx, resp.Data.ID, s.client.encryptionAttributes()); err != nil {
+			return &resp, fmt.Errorf("failed to record encryption attributes: %w", err)
+		}
+	}
+
 	return &resp, nil
 }
 
@@ -129,7 +292,9 @@ func (s *FilesService) Upload(ctx context.Context, reader io.Reader, opts *Uploa
 //
 // The returned Logo always includes the normalized domain. If a logo already
 // exists, the upload is skipped and the existing public URL is returned.
-func (s *FilesService) UploadLogoOrGetURL(ctx context.Context, reader io.Reader, opts *UploadOptions) (*Logo, error) {
+func (s *FilesService) UploadLogoOrGetURL(ctx context.Context, reader io.Reader, opts *UploadOptions, callOpts ...CallOption) (*Logo, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	if opts == nil {
 		opts = &UploadOptions{}
 	}
@@ -196,6 +361,18 @@ func (s *FilesService) UploadLogoOrGetURL(ctx context.Context, reader io.Reader,
 type UploadFromURLOptions struct {
 	// URL is the URL to download and upload from.
 	URL string
+
+	// SignSource, if set, is called with URL right before the request is
+	// sent and returns the URL the server should fetch. Use it to generate
+	// short-lived pre-signed S3/GCS URLs for private storage lazily.
+	SignSource func(url string) (string, error)
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UploadFromURLOptions) Validate() error {
+	var v validator
+	v.check(strings.TrimSpace(opts.URL) != "", "URL", "is required")
+	return v.err()
 }
 
 // UploadFromURL uploads an image from a public URL.
@@ -207,11 +384,45 @@ type UploadFromURLOptions struct {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Uploaded: %s\n", resp.Data.URL)
-func (s *FilesService) UploadFromURL(ctx context.Context, imageURL string) (*UploadResponse, error) {
+func (s *FilesService) UploadFromURL(ctx context.Context, imageURL string, callOpts ...CallOption) (*UploadResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	return s.UploadFromURLWithOptions(ctx, &UploadFromURLOptions{URL: imageURL})
+}
+
+// UploadFromURLWithOptions uploads an image from a URL with additional options.
+//
+// Example:
+//
+//	resp, err := client.Files.UploadFromURLWithOptions(ctx, &fimage.UploadFromURLOptions{
+//	    URL: "s3://my-bucket/photos/1.jpg",
+//	    SignSource: func(u string) (string, error) {
+//	        return presignS3(u, 5*time.Minute)
+//	    },
+//	})
+func (s *FilesService) UploadFromURLWithOptions(ctx context.Context, opts *UploadFromURLOptions, callOpts ...CallOption) (*UploadResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		opts = &UploadFromURLOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	sourceURL := opts.URL
+	if opts.SignSource != nil {
+		signed, err := opts.SignSource(sourceURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign source URL: %w", err)
+		}
+		sourceURL = signed
+	}
+
 	req := struct {
 		URL string `json:"url"`
 	}{
-		URL: imageURL,
+		URL: sourceURL,
 	}
 
 	var resp UploadResponse
@@ -230,8 +441,54 @@ type ListOptions struct {
 	// Limit is the number of items per page (max 100).
 	Limit int
 
-	// AlbumID filters files by album. Use 0 for files without an album.
+	// AlbumID filters files by album. Older servers also treat 0 as files
+	// without an album; prefer Unfiled for that.
 	AlbumID *int64
+
+	// Unfiled lists only files that are not in any album, such as an
+	// inbox of files still to be organized. It cannot be combined with
+	// AlbumID.
+	Unfiled bool
+
+	// TagID filters files by tag.
+	TagID *int64
+
+	// IncludeTags populates File.Tags, saving a Get per file when rendering
+	// tagged galleries.
+	IncludeTags bool
+
+	// IncludeAggregates asks the server to compute TotalSize for all
+	// matching files, not just the current page.
+	IncludeAggregates bool
+
+	// Sort is the result ordering. Defaults to SortCreatedDesc.
+	Sort SortOrder
+
+	// Attributes filters files whose custom attributes match every
+	// key/value pair.
+	Attributes map[string]string
+
+	// Orientation limits results to landscape, portrait, or square images.
+	Orientation Orientation
+
+	// MinAspectRatio limits results to images whose width divided by height
+	// is at least this value (e.g. 1.5).
+	MinAspectRatio float64
+
+	// MaxAspectRatio limits results to images whose width divided by height
+	// is at most this value.
+	MaxAspectRatio float64
+}
+
+// Validate checks the options for invalid fields.
+func (opts *ListOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	v.check(!opts.Unfiled || opts.AlbumID == nil, "Unfiled", "cannot be combined with AlbumID")
+	v.attributes("Attributes", opts.Attributes)
+	v.check(opts.Sort == "" || opts.Sort.Valid(), "Sort", "has unsupported value %q", opts.Sort)
+	v.shape(opts.Orientation, opts.MinAspectRatio, opts.MaxAspectRatio)
+	return v.err()
 }
 
 // List returns a paginated list of files.
@@ -248,10 +505,15 @@ type ListOptions struct {
 //	    Page:    1,
 //	    Limit:   50,
 //	})
-func (s *FilesService) List(ctx context.Context, opts *ListOptions) (*FilesListResponse, error) {
+func (s *FilesService) List(ctx context.Context, opts *ListOptions, callOpts ...CallOption) (*FilesListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	query := url.Values{}
 
 	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
 		}
@@ -261,8 +523,27 @@ func (s *FilesService) List(ctx context.Context, opts *ListOptions) (*FilesListR
 		if opts.AlbumID != nil {
 			query.Set("album_id", strconv.FormatInt(*opts.AlbumID, 10))
 		}
+		if opts.Unfiled {
+			query.Set("unfiled", "true")
+		}
+		if opts.TagID != nil {
+			query.Set("tag_id", strconv.FormatInt(*opts.TagID, 10))
+		}
+		if opts.IncludeTags {
+			query.Set("include", "tags")
+		}
+		if opts.IncludeAggregates {
+			query.Set("include_aggregates", "true")
+		}
+		if opts.Sort != "" {
+			query.Set("sort", string(opts.Sort))
+		}
+		setAttributeQuery(query, opts.Attributes)
+		setShapeQuery(query, opts.Orientation, opts.MinAspectRatio, opts.MaxAspectRatio)
 	}
 
+	setDefaultLimit(ctx, query)
+
 	var resp FilesListResponse
 	if err := s.client.requestWithQuery(ctx, "/api/files", query, &resp); err != nil {
 		return nil, err
@@ -281,9 +562,83 @@ type SearchOptions struct {
 
 	// Limit is the number of items per page (max 100).
 	Limit int
+
+	// IncludeAggregates asks the server to compute TotalSize for all
+	// matching files, not just the current page.
+	IncludeAggregates bool
+
+	// IncludeTags populates File.Tags.
+	IncludeTags bool
+
+	// Sort is the result ordering. Defaults to SortCreatedDesc.
+	Sort SortOrder
+
+	// Orientation limits results to landscape, portrait, or square images.
+	Orientation Orientation
+
+	// MinAspectRatio limits results to images whose width divided by height
+	// is at least this value (e.g. 1.5).
+	MinAspectRatio float64
+
+	// MaxAspectRatio limits results to images whose width divided by height
+	// is at most this value.
+	MaxAspectRatio float64
+
+	// MimeTypes limits results to files of any of these types.
+	MimeTypes []MimeType
+
+	// MinSize limits results to files of at least this many bytes.
+	MinSize int64
+
+	// MaxSize limits results to files of at most this many bytes.
+	MaxSize int64
+
+	// CreatedAfter limits results to files uploaded at or after this time.
+	CreatedAfter time.Time
+
+	// CreatedBefore limits results to files uploaded before this time.
+	CreatedBefore time.Time
+
+	// MinWidth limits results to images at least this many pixels wide.
+	MinWidth int
+
+	// MinHeight limits results to images at least this many pixels tall.
+	MinHeight int
+
+	// TagIDs limits results to files with all of these tags.
+	TagIDs []int64
+}
+
+// hasFilters reports whether any filter besides Query is set.
+func (opts *SearchOptions) hasFilters() bool {
+	return opts.Orientation != "" || opts.MinAspectRatio > 0 || opts.MaxAspectRatio > 0 ||
+		len(opts.MimeTypes) > 0 || opts.MinSize > 0 || opts.MaxSize > 0 ||
+		!opts.CreatedAfter.IsZero() || !opts.CreatedBefore.IsZero() ||
+		opts.MinWidth > 0 || opts.MinHeight > 0 || len(opts.TagIDs) > 0
+}
+
+// Validate checks the options for invalid fields.
+func (opts *SearchOptions) Validate() error {
+	var v validator
+	v.check(strings.TrimSpace(opts.Query) != "" || opts.hasFilters(), "Query", "or a filter is required")
+	v.paging(opts.Page, opts.Limit)
+	v.check(opts.Sort == "" || opts.Sort.Valid(), "Sort", "has unsupported value %q", opts.Sort)
+	v.shape(opts.Orientation, opts.MinAspectRatio, opts.MaxAspectRatio)
+	for i, m := range opts.MimeTypes {
+		v.check(m.Valid(), fmt.Sprintf("MimeTypes[%d]", i), "has unsupported value %q", m)
+	}
+	v.check(opts.MinSize >= 0, "MinSize", "must not be negative")
+	v.check(opts.MaxSize >= 0, "MaxSize", "must not be negative")
+	v.check(opts.MaxSize == 0 || opts.MinSize <= opts.MaxSize, "MaxSize", "must not be less than MinSize")
+	v.check(opts.CreatedAfter.IsZero() || opts.CreatedBefore.IsZero() || opts.CreatedAfter.Before(opts.CreatedBefore),
+		"CreatedAfter", "must be before CreatedBefore")
+	v.check(opts.MinWidth >= 0, "MinWidth", "must not be negative")
+	v.check(opts.MinHeight >= 0, "MinHeight", "must not be negative")
+	return v.err()
 }
 
-// Search searches for files by filename or description.
+// Search searches for files by filename or description, narrowed by the
+// filters in opts. Query may be empty when a filter is set.
 //
 // Example:
 //
@@ -295,20 +650,73 @@ type SearchOptions struct {
 //	for _, file := range resp.Files {
 //	    fmt.Println(file.OriginalName)
 //	}
-func (s *FilesService) Search(ctx context.Context, opts *SearchOptions) (*FilesListResponse, error) {
-	if opts == nil || opts.Query == "" {
-		return nil, fmt.Errorf("search query is required")
+//
+//	// Large PNG and WebP files uploaded this year
+//	resp, err = client.Files.Search(ctx, &fimage.SearchOptions{
+//	    MimeTypes:    []fimage.MimeType{fimage.MimePNG, fimage.MimeWebP},
+//	    MinSize:      5 << 20,
+//	    CreatedAfter: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+//	})
+func (s *FilesService) Search(ctx context.Context, opts *SearchOptions, callOpts ...CallOption) (*FilesListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	query := url.Values{}
-	query.Set("q", opts.Query)
-
+	if opts.Query != "" {
+		query.Set("q", opts.Query)
+	}
 	if opts.Page > 0 {
 		query.Set("page", strconv.Itoa(opts.Page))
 	}
 	if opts.Limit > 0 {
 		query.Set("limit", strconv.Itoa(opts.Limit))
 	}
+	if opts.IncludeAggregates {
+		query.Set("include_aggregates", "true")
+	}
+	if opts.IncludeTags {
+		query.Set("include", "tags")
+	}
+	if opts.Sort != "" {
+		query.Set("sort", string(opts.Sort))
+	}
+	setShapeQuery(query, opts.Orientation, opts.MinAspectRatio, opts.MaxAspectRatio)
+	if len(opts.MimeTypes) > 0 {
+		types := make([]string, len(opts.MimeTypes))
+		for i, m := range opts.MimeTypes {
+			types[i] = string(m)
+		}
+		query.Set("mime_types", strings.Join(types, ","))
+	}
+	if opts.MinSize > 0 {
+		query.Set("min_size", strconv.FormatInt(opts.MinSize, 10))
+	}
+	if opts.MaxSize > 0 {
+		query.Set("max_size", strconv.FormatInt(opts.MaxSize, 10))
+	}
+	if !opts.CreatedAfter.IsZero() {
+		query.Set("created_after", opts.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if !opts.CreatedBefore.IsZero() {
+		query.Set("created_before", opts.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+	if opts.MinWidth > 0 {
+		query.Set("min_width", strconv.Itoa(opts.MinWidth))
+	}
+	if opts.MinHeight > 0 {
+		query.Set("min_height", strconv.Itoa(opts.MinHeight))
+	}
+	if len(opts.TagIDs) > 0 {
+		query.Set("tag_ids", joinIDs(opts.TagIDs))
+	}
+
+	setDefaultLimit(ctx, query)
 
 	var resp FilesListResponse
 	if err := s.client.requestWithQuery(ctx, "/api/files/search", query, &resp); err != nil {
@@ -318,6 +726,226 @@ func (s *FilesService) Search(ctx context.Context, opts *SearchOptions) (*FilesL
 	return &resp, nil
 }
 
+// Validate checks the box for coordinates out of range. East may be below
+// West for boxes that cross the antimeridian.
+func (b BoundingBox) Validate() error {
+	var v validator
+	v.check(b.North >= -90 && b.North <= 90, "North", "must be a latitude between -90 and 90")
+	v.check(b.South >= -90 && b.South <= 90, "South", "must be a latitude between -90 and 90")
+	v.check(b.East >= -180 && b.East <= 180, "East", "must be a longitude between -180 and 180")
+	v.check(b.West >= -180 && b.West <= 180, "West", "must be a longitude between -180 and 180")
+	v.check(b.North >= b.South, "North", "must not be below South")
+	return v.err()
+}
+
+// ListByBounds returns the located files within a geographic area, grouped
+// into clusters by the server for the given map zoom level (0-22). Higher
+// zoom levels produce smaller clusters and more individual files.
+//
+// Example:
+//
+//	resp, err := client.Files.ListByBounds(ctx, fimage.BoundingBox{
+//	    North: 48.90, South: 48.81,
+//	    East:  2.42, West: 2.25,
+//	}, 12)
+//	for _, c := range resp.Clusters {
+//	    fmt.Printf("%d photos near %.4f,%.4f\n", c.Count, c.Center.Latitude, c.Center.Longitude)
+//	}
+func (s *FilesService) ListByBounds(ctx context.Context, bounds BoundingBox, zoom int, callOpts ...CallOption) (*GeoListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if err := bounds.Validate(); err != nil {
+		return nil, err
+	}
+	if zoom < 0 || zoom > 22 {
+		return nil, fmt.Errorf("zoom must be between 0 and 22")
+	}
+
+	query := url.Values{}
+	query.Set("north", strconv.FormatFloat(bounds.North, 'f', -1, 64))
+	query.Set("south", strconv.FormatFloat(bounds.South, 'f', -1, 64))
+	query.Set("east", strconv.FormatFloat(bounds.East, 'f', -1, 64))
+	query.Set("west", strconv.FormatFloat(bounds.West, 'f', -1, 64))
+	query.Set("zoom", strconv.Itoa(zoom))
+
+	var resp GeoListResponse
+	if err := s.client.requestWithQuery(ctx, "/api/files/geo", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// TimelineGranularity selects how timeline buckets are grouped.
+type TimelineGranularity string
+
+const (
+	// TimelineDay groups files by calendar day.
+	TimelineDay TimelineGranularity = "day"
+
+	// TimelineMonth groups files by calendar month.
+	TimelineMonth TimelineGranularity = "month"
+
+	// TimelineYear groups files by calendar year.
+	TimelineYear TimelineGranularity = "year"
+)
+
+// TimelineOptions contains options for the timeline view.
+type TimelineOptions struct {
+	// Granularity is the bucket size. Defaults to month.
+	Granularity TimelineGranularity
+
+	// AlbumID limits the timeline to an album.
+	AlbumID *int64
+
+	// Samples is the number of representative files per bucket.
+	Samples int
+}
+
+// Validate checks the options for invalid fields.
+func (opts *TimelineOptions) Validate() error {
+	var v validator
+	switch opts.Granularity {
+	case "", TimelineDay, TimelineMonth, TimelineYear:
+	default:
+		v.check(false, "Granularity", "has unsupported value %q", opts.Granularity)
+	}
+	v.check(opts.Samples >= 0, "Samples", "must not be negative")
+	return v.err()
+}
+
+// Timeline returns files grouped into date buckets with counts and
+// representative thumbnails, for building "Photos"-style timelines
+// without fetching every record.
+//
+// Example:
+//
+//	resp, err := client.Files.Timeline(ctx, &fimage.TimelineOptions{
+//	    Granularity: fimage.TimelineMonth,
+//	    Samples:     4,
+//	})
+//	for _, b := range resp.Buckets {
+//	    fmt.Printf("%s: %d photos\n", b.Period, b.Count)
+//	}
+func (s *FilesService) Timeline(ctx context.Context, opts *TimelineOptions, callOpts ...CallOption) (*TimelineResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	query := url.Values{}
+	query.Set("granularity", string(TimelineMonth))
+
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+		if opts.Granularity != "" {
+			query.Set("granularity", string(opts.Granularity))
+		}
+		if opts.AlbumID != nil {
+			query.Set("album_id", strconv.FormatInt(*opts.AlbumID, 10))
+		}
+		if opts.Samples > 0 {
+			query.Set("samples", strconv.Itoa(opts.Samples))
+		}
+	}
+
+	var resp TimelineResponse
+	if err := s.client.requestWithQuery(ctx, "/api/files/timeline", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Get returns a single file by ID, including its tags and album.
+//
+// Example:
+//
+//	file, err := client.Files.Get(ctx, 123)
+//	if err != nil {
+//	    if fimage.IsNotFound(err) {
+//	        fmt.Println("File not found")
+//	        return
+//	    }
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s in %s, tags: %d\n", file.OriginalName, file.GetAlbumName(), len(file.Tags))
+func (s *FilesService) Get(ctx context.Context, fileID int64, callOpts ...CallOption) (*File, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	path := fmt.Sprintf("/api/files/%d", fileID)
+
+	query := url.Values{}
+	query.Set("include", "tags,album")
+
+	var file File
+	if err := s.client.requestWithQuery(ctx, path, query, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
+// UpdateFileOptions contains options for updating a file. Nil fields are
+// left unchanged.
+type UpdateFileOptions struct {
+	// Name sets the file name (OriginalName).
+	Name *string
+
+	// Description sets the file description. Point to an empty string to
+	// clear it.
+	Description *string
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UpdateFileOptions) Validate() error {
+	var v validator
+	v.check(opts.Name != nil || opts.Description != nil, "Name", "or Description is required")
+	v.check(opts.Name == nil || strings.TrimSpace(*opts.Name) != "", "Name", "must not be empty")
+	v.check(opts.Name == nil || !strings.ContainsAny(*opts.Name, "/\\"), "Name", "must not contain path separators")
+	return v.err()
+}
+
+// Update renames a file or edits its description.
+//
+// Example:
+//
+//	name, caption := "sunset.jpg", "Sunset over the bay"
+//	file, err := client.Files.Update(ctx, 123, &fimage.UpdateFileOptions{
+//	    Name:        &name,
+//	    Description: &caption,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(file.OriginalName)
+func (s *FilesService) Update(ctx context.Context, fileID int64, opts *UpdateFileOptions, callOpts ...CallOption) (*File, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		return nil, fmt.Errorf("file options are required")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/files/%d", fileID)
+
+	req := struct {
+		OriginalName *string `json:"original_name,omitempty"`
+		Description  *string `json:"description,omitempty"`
+	}{
+		OriginalName: opts.Name,
+		Description:  opts.Description,
+	}
+
+	var file File
+	if err := s.client.request(ctx, http.MethodPatch, path, req, &file); err != nil {
+		return nil, err
+	}
+
+	return &file, nil
+}
+
 // Delete moves a file to trash (soft delete).
 //
 // Example:
@@ -326,7 +954,9 @@ func (s *FilesService) Search(ctx context.Context, opts *SearchOptions) (*FilesL
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (s *FilesService) Delete(ctx context.Context, fileID int64) (*MessageResponse, error) {
+func (s *FilesService) Delete(ctx context.Context, fileID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	path := fmt.Sprintf("/api/files/%d", fileID)
 
 	var resp MessageResponse
@@ -337,29 +967,37 @@ func (s *FilesService) Delete(ctx context.Context, fileID int64) (*MessageRespon
 	return &resp, nil
 }
 
-// BatchDelete moves multiple files to trash.
+// BatchDelete moves multiple files to trash. Files that could not be deleted
+// are listed in the result's Failed items.
 //
 // Example:
 //
-//	resp, err := client.Files.BatchDelete(ctx, []int64{1, 2, 3})
-//	fmt.Printf("Deleted: %d, Failed: %d\n", resp.Deleted, resp.Failed)
-func (s *FilesService) BatchDelete(ctx context.Context, fileIDs []int64) (*BatchDeleteResponse, error) {
+//	result, err := client.Files.BatchDelete(ctx, []int64{1, 2, 3})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Deleted: %d, Failed: %d\n", len(result.Succeeded), len(result.Failed))
+func (s *FilesService) BatchDelete(ctx context.Context, fileIDs []int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	req := struct {
 		FileIDs []int64 `json:"file_ids"`
 	}{
 		FileIDs: fileIDs,
 	}
 
-	var resp BatchDeleteResponse
+	var resp batchResponse
 	if err := s.client.request(ctx, http.MethodPost, "/api/files/batch-delete", req, &resp); err != nil {
 		return nil, err
 	}
 
-	return &resp, nil
+	return resp.idResult(fileIDs), nil
 }
 
 // Move moves a single file to an album.
 // Set albumID to nil to remove the file from its current album.
+// Moving replaces all of the file's album memberships, as in single-album
+// mode; use AlbumsService.LinkFiles to add a file to another album.
 //
 // Example:
 //
@@ -369,7 +1007,9 @@ func (s *FilesService) BatchDelete(ctx context.Context, fileIDs []int64) (*Batch
 //
 //	// Remove from album
 //	err = client.Files.Move(ctx, 456, nil)
-func (s *FilesService) Move(ctx context.Context, fileID int64, albumID *int64) (*MessageResponse, error) {
+func (s *FilesService) Move(ctx context.Context, fileID int64, albumID *int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	path := fmt.Sprintf("/api/files/%d/move", fileID)
 
 	query := url.Values{}
@@ -391,12 +1031,21 @@ func (s *FilesService) Move(ctx context.Context, fileID int64, albumID *int64) (
 
 // MoveMany moves multiple files to an album.
 // Set albumID to nil to remove the files from their current album.
+// Like Move, it replaces the files' album memberships.
 //
 // Example:
 //
 //	albumID := int64(123)
-//	err := client.Files.MoveMany(ctx, []int64{1, 2, 3}, &albumID)
-func (s *FilesService) MoveMany(ctx context.Context, fileIDs []int64, albumID *int64) (*MessageResponse, error) {
+//	result, err := client.Files.MoveMany(ctx, []int64{1, 2, 3}, &albumID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := result.Err(); err != nil {
+//	    log.Printf("some files were not moved: %v", err)
+//	}
+func (s *FilesService) MoveMany(ctx context.Context, fileIDs []int64, albumID *int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
 	req := struct {
 		FileIDs []int64 `json:"file_ids"`
 		AlbumID *int64  `json:"album_id,omitempty"`
@@ -405,10 +1054,212 @@ func (s *FilesService) MoveMany(ctx context.Context, fileIDs []int64, albumID *i
 		AlbumID: albumID,
 	}
 
-	var resp MessageResponse
+	var resp batchResponse
 	if err := s.client.request(ctx, http.MethodPut, "/api/files/move", req, &resp); err != nil {
 		return nil, err
 	}
 
+	return resp.idResult(fileIDs), nil
+}
+
+// SetEmbeddedMetadata rewrites the IPTC/XMP metadata embedded in a stored
+// original. Empty fields are removed from the file.
+//
+// Example:
+//
+//	_, err := client.Files.SetEmbeddedMetadata(ctx, 123, &fimage.IPTC{
+//	    Creator:   "Jane Doe",
+//	    Copyright: "© 2024 Jane Doe",
+//	    Keywords:  []string{"sunset", "beach"},
+//	})
+func (s *FilesService) SetEmbeddedMetadata(ctx context.Context, fileID int64, iptc *IPTC, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if iptc == nil {
+		return nil, fmt.Errorf("metadata is required")
+	}
+
+	path := fmt.Sprintf("/api/files/%d/embedded-metadata", fileID)
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodPut, path, iptc, &resp); err != nil {
+		return nil, err
+	}
+
 	return &resp, nil
 }
+
+// StripMetadata removes GPS or all EXIF metadata from stored originals.
+//
+// Example:
+//
+//	result, err := client.Files.StripMetadata(ctx, []int64{1, 2, 3}, fimage.StripModeGPS)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Stripped: %d, Failed: %d\n", len(result.Succeeded), len(result.Failed))
+func (s *FilesService) StripMetadata(ctx context.Context, fileIDs []int64, mode StripMode, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+
+	switch mode {
+	case StripModeGPS, StripModeAll:
+	default:
+		return nil, fmt.Errorf("unsupported strip mode: %q", mode)
+	}
+
+	req := struct {
+		FileIDs []int64   `json:"file_ids"`
+		Mode    StripMode `json:"mode"`
+	}{
+		FileIDs: fileIDs,
+		Mode:    mode,
+	}
+
+	var resp batchResponse
+	if err := s.client.request(ctx, http.MethodPost, "/api/files/strip-metadata", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.idResult(fileIDs), nil
+}
+
+// SetAltText sets the accessibility alt text of a file.
+// An empty string clears it.
+//
+// Example:
+//
+//	_, err := client.Files.SetAltText(ctx, 123, "A red T-shirt on a wooden hanger")
+func (s *FilesService) SetAltText(ctx context.Context, fileID int64, altText string, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	path := fmt.Sprintf("/api/files/%d/alt-text", fileID)
+
+	req := struct {
+		AltText string `json:"alt_text"`
+	}{
+		AltText: altText,
+	}
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodPut, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GenerateAltText asks the server to suggest alt text for a file in the
+// given language (e.g., "en" or "zh-CN"). The suggestion is not saved;
+// review it and call SetAltText to store it.
+//
+// Example:
+//
+//	suggestion, err := client.Files.GenerateAltText(ctx, 123, "en")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if suggestion.Confidence > 0.8 {
+//	    _, err = client.Files.SetAltText(ctx, 123, suggestion.Text)
+//	}
+func (s *FilesService) GenerateAltText(ctx context.Context, fileID int64, language string, callOpts ...CallOption) (*AltTextSuggestion, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	path := fmt.Sprintf("/api/files/%d/alt-text/generate", fileID)
+
+	req := struct {
+		Language string `json:"language,omitempty"`
+	}{
+		Language: strings.TrimSpace(language),
+	}
+
+	var suggestion AltTextSuggestion
+	if err := s.client.featureRequest(ctx, FeatureAltText, http.MethodPost, path, req, &suggestion); err != nil {
+		return nil, err
+	}
+
+	return &suggestion, nil
+}
+
+// SetFocalPoint sets the subject position of a file, used by GravityFocal
+// crops. x and y are relative to the image size, from 0 (left/top) to 1
+// (right/bottom).
+//
+// Example:
+//
+//	// The face is slightly left of center, in the upper third
+//	_, err := client.Files.SetFocalPoint(ctx, 123, 0.4, 0.3)
+//
+//	u := file.TransformURL(fimage.Transform{
+//	    Width:   400,
+//	    Height:  400,
+//	    Fit:     fimage.FitCover,
+//	    Gravity: fimage.GravityFocal,
+//	}).String()
+func (s *FilesService) SetFocalPoint(ctx context.Context, fileID int64, x, y float64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if x < 0 || x > 1 || y < 0 || y > 1 {
+		return nil, fmt.Errorf("focal point (%g, %g) must be between 0 and 1", x, y)
+	}
+
+	path := fmt.Sprintf("/api/files/%d/focal-point", fileID)
+
+	req := FocalPoint{X: x, Y: y}
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodPut, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Compare compares two images and returns their structural similarity and
+// pixel difference. Images of different sizes are scaled to the smaller one
+// before comparison.
+//
+// Example:
+//
+//	result, err := client.Files.Compare(ctx, baselineID, screenshotID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if result.DiffPercent > 0.5 {
+//	    fmt.Printf("Visual change detected: %s\n", result.GetDiffURL())
+//	}
+func (s *FilesService) Compare(ctx context.Context, fileIDA, fileIDB int64, callOpts ...CallOption) (*ComparisonResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	req := struct {
+		FileIDA int64 `json:"file_id_a"`
+		FileIDB int64 `json:"file_id_b"`
+	}{
+		FileIDA: fileIDA,
+		FileIDB: fileIDB,
+	}
+
+	var result ComparisonResult
+	if err := s.client.request(ctx, http.MethodPost, "/api/files/compare", req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// InAlbum reports whether the file belongs to the album, either as its
+// primary album or through a link.
+func (f *File) InAlbum(albumID int64) bool {
+	if f.AlbumID != nil && *f.AlbumID == albumID {
+		return true
+	}
+	for _, id := range f.AlbumIDs {
+		if id == albumID {
+			return true
+		}
+	}
+	return false
+}
@@ -2,11 +2,18 @@ package fimage
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"mime"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestUploadLogoOrGetURLReturnsExistingLogoWithoutUpload(t *testing.T) {
@@ -137,3 +144,1087 @@ func TestUploadLogoOrGetURLReturnsConflictURLAsSuccess(t *testing.T) {
 		t.Fatalf("unexpected url: %s", logo.URL)
 	}
 }
+
+func TestListRequestsAggregates(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/files" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include_aggregates"); got != "true" {
+			t.Fatalf("unexpected include_aggregates query: %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[],"total":42,"page":1,"limit":20,"total_size":123456789}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Files.List(context.Background(), &ListOptions{IncludeAggregates: true})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if resp.TotalSize != 123456789 {
+		t.Fatalf("unexpected total size: %d", resp.TotalSize)
+	}
+}
+
+func TestListRejectsUnknownSortOrder(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test-token", WithBaseURL("http://127.0.0.1:0"))
+
+	if _, err := client.Files.List(context.Background(), &ListOptions{Sort: "newest"}); err == nil {
+		t.Fatal("expected error for unknown sort order")
+	}
+}
+
+func TestSearchValidationListsEveryInvalidField(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("test-token", WithBaseURL("http://127.0.0.1:0"))
+
+	_, err := client.Files.Search(context.Background(), &SearchOptions{Limit: 500, Sort: "newest"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+
+	fields := make([]string, 0, len(validationErr.Errors))
+	for _, fe := range validationErr.Errors {
+		fields = append(fields, fe.Field)
+	}
+	if got := strings.Join(fields, ","); got != "Query,Limit,Sort" {
+		t.Fatalf("unexpected invalid fields: %s", got)
+	}
+}
+
+func TestUploadFromURLWithOptionsSignsSource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			URL string `json:"url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.URL != "https://bucket.example.com/a.jpg?sig=abc" {
+			t.Fatalf("unexpected source url: %s", req.URL)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"status":200,"data":{"id":5,"url":"https://i.f-image.com/a.jpg"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Files.UploadFromURLWithOptions(context.Background(), &UploadFromURLOptions{
+		URL: "https://bucket.example.com/a.jpg",
+		SignSource: func(u string) (string, error) {
+			return u + "?sig=abc", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("UploadFromURLWithOptions returned error: %v", err)
+	}
+	if resp.Data.ID != 5 {
+		t.Fatalf("unexpected id: %d", resp.Data.ID)
+	}
+}
+
+func TestListFiltersByAttributes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("attr.sku"); got != "TSHIRT-RED-M" {
+			t.Fatalf("unexpected attr.sku query: %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1,"attributes":{"sku":"TSHIRT-RED-M"}}],"total":1,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Files.List(context.Background(), &ListOptions{
+		Attributes: map[string]string{"sku": "TSHIRT-RED-M"},
+	})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got := resp.Files[0].Attributes["sku"]; got != "TSHIRT-RED-M" {
+		t.Fatalf("unexpected attribute: %q", got)
+	}
+
+	if _, err := client.Files.List(context.Background(), &ListOptions{
+		Attributes: map[string]string{"bad key": "x"},
+	}); err == nil {
+		t.Fatal("expected error for invalid attribute key")
+	}
+}
+
+func TestBestURLFallsBackToLargerVariants(t *testing.T) {
+	t.Parallel()
+
+	medium := "https://cdn.f-image.com/m.jpg"
+	file := &File{URL: "https://cdn.f-image.com/o.jpg", MediumURL: &medium}
+
+	if got := file.BestURL(); got != medium {
+		t.Fatalf("BestURL() = %q, want medium", got)
+	}
+	if got := file.BestURL(VariantMedium); got != medium {
+		t.Fatalf("BestURL(medium) = %q, want medium", got)
+	}
+
+	file.MediumURL = nil
+	if got := file.BestURL(VariantThumbnail); got != file.URL {
+		t.Fatalf("BestURL(thumbnail) = %q, want original", got)
+	}
+}
+
+func TestGetIncludesTags(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/files/123" || !strings.Contains(r.URL.Query().Get("include"), "tags") {
+			t.Fatalf("unexpected request: %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":123,"album_name":"Trips","tags":[{"id":1,"name":"Nature"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	file, err := client.Files.Get(context.Background(), 123)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if file.GetAlbumName() != "Trips" || len(file.Tags) != 1 || file.Tags[0].Name != "Nature" {
+		t.Fatalf("unexpected file: %+v", file)
+	}
+}
+
+func TestBatchDeleteReportsPerItemResults(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/files/batch-delete" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":1,"success":true},
+			{"id":2,"success":false,"status":404,"code":"file_not_found","error":"File not found"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	result, err := client.Files.BatchDelete(context.Background(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("BatchDelete returned error: %v", err)
+	}
+	if len(result.Succeeded) != 1 || result.Succeeded[0] != 1 {
+		t.Fatalf("unexpected successes: %v", result.Succeeded)
+	}
+	if len(result.Failed) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(result.Failed))
+	}
+	if f := result.Failed[0]; f.ID != 2 || f.Index != 1 || !IsNotFound(f.Err) {
+		t.Fatalf("unexpected failure for file 2: %+v", f)
+	}
+	if f := result.Failed[1]; f.ID != 3 || f.Err == nil {
+		t.Fatalf("unreported file 3 should fail: %+v", f)
+	}
+
+	err = result.Err()
+	var bErr *BatchError
+	if !errors.As(err, &bErr) || bErr.Total != 3 {
+		t.Fatalf("expected BatchError with total 3, got %v", err)
+	}
+	if !IsCode(err, CodeFileNotFound) {
+		t.Fatalf("expected BatchError to match file_not_found: %v", err)
+	}
+}
+
+func TestUploadStreamsWithOptionalContentLength(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat("x", 1<<20)
+	var contentLength atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength.Store(r.ContentLength)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		if header.Size != int64(len(data)) || r.FormValue("description") != "big" {
+			t.Errorf("unexpected upload: size %d, description %q", header.Size, r.FormValue("description"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	tests := []struct {
+		name       string
+		reader     io.Reader
+		size       int64
+		wantLength bool
+	}{
+		{"detected", strings.NewReader(data), 0, true},
+		{"explicit", io.MultiReader(strings.NewReader(data)), int64(len(data)), true},
+		{"unknown", io.MultiReader(strings.NewReader(data)), 0, false},
+	}
+	for _, tt := range tests {
+		_, err := client.Files.Upload(context.Background(), tt.reader, &UploadOptions{
+			Filename:    "big.jpg",
+			Description: "big",
+			Size:        tt.size,
+		})
+		if err != nil {
+			t.Fatalf("%s: Upload returned error: %v", tt.name, err)
+		}
+		if got := contentLength.Load() > int64(len(data)); got != tt.wantLength {
+			t.Fatalf("%s: Content-Length %d, want set = %v", tt.name, contentLength.Load(), tt.wantLength)
+		}
+	}
+}
+
+func TestListAllFetchesEveryPage(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("unexpected limit: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"files":[{"id":1},{"id":2}],"total":5,"page":1,"limit":2}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"files":[{"id":3},{"id":4}],"total":5,"page":2,"limit":2}`))
+		case "3":
+			_, _ = w.Write([]byte(`{"files":[{"id":5}],"total":5,"page":3,"limit":2}`))
+		default:
+			t.Errorf("unexpected page: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	files, err := client.Files.ListAll(context.Background(), &ListOptions{Limit: 2}).Collect()
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(files) != 5 || files[4].ID != 5 {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestListAllContinuesPastSkippedRecords(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"files":[{"id":1},{"id":"bad"}],"page":1,"limit":2}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"files":[{"id":3}],"page":2,"limit":2}`))
+		default:
+			t.Errorf("unexpected page: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithTolerantDecoding())
+
+	it := client.Files.ListAll(context.Background(), &ListOptions{Limit: 2})
+	files, err := it.Collect()
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(files) != 2 || files[1].ID != 3 {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+	if warnings := it.Warnings(); len(warnings) != 1 || warnings[0].Index != 1 {
+		t.Fatalf("unexpected warnings: %+v", warnings)
+	}
+}
+
+func TestUploadComputeHashSkipsExistingFiles(t *testing.T) {
+	t.Parallel()
+
+	existing := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9" // sha256("hello world")
+	var uploads atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/files/hash/"+existing:
+			_, _ = w.Write([]byte(`{"exists":true,"file":{"id":9,"url":"https://i.f-image.com/a.jpg"}}`))
+		case strings.HasPrefix(r.URL.Path, "/api/files/hash/"):
+			_, _ = w.Write([]byte(`{"exists":false}`))
+		case r.URL.Path == "/api/files/upload":
+			uploads.Add(1)
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("FormFile failed: %v", err)
+				return
+			}
+			defer file.Close()
+			data, _ := io.ReadAll(file)
+			if string(data) != "other bytes" || r.FormValue("sha256") == "" {
+				t.Errorf("unexpected upload: %q sha256=%q", data, r.FormValue("sha256"))
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":10}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Files.Upload(context.Background(), io.MultiReader(strings.NewReader("hello world")), &UploadOptions{ComputeHash: true})
+	if err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+	if resp.Data.ID != 9 || !resp.Data.IsFlash || uploads.Load() != 0 {
+		t.Fatalf("expected existing file without upload, got %+v (uploads=%d)", resp.Data, uploads.Load())
+	}
+
+	resp, err = client.Files.Upload(context.Background(), strings.NewReader("other bytes"), &UploadOptions{ComputeHash: true})
+	if err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+	if resp.Data.ID != 10 || uploads.Load() != 1 {
+		t.Fatalf("expected a new upload, got %+v (uploads=%d)", resp.Data, uploads.Load())
+	}
+}
+
+func TestUploadManyReportsPerFileResults(t *testing.T) {
+	t.Parallel()
+
+	var inflight, peak atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("description") == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"success":false,"message":"unsupported format"}`))
+			return
+		}
+		if r.FormValue("description") == "5" {
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":1,"is_flash":true}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"id":` + r.FormValue("description") + `}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var opened atomic.Int64
+	reqs := make([]UploadRequest, 6)
+	for i := range reqs {
+		desc := strconv.Itoa(i + 1)
+		if i == 2 {
+			desc = "bad"
+		}
+		reqs[i] = UploadRequest{
+			Open: func() (io.ReadCloser, error) {
+				opened.Add(1)
+				return io.NopCloser(strings.NewReader("data")), nil
+			},
+			Options: &UploadOptions{Filename: "a.jpg", Description: desc},
+		}
+	}
+
+	result, err := client.Files.UploadMany(context.Background(), reqs, &UploadManyOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("UploadMany returned error: %v", err)
+	}
+	if len(result.Succeeded) != 5 || result.Succeeded[2].Index != 3 || result.Succeeded[2].Response.Data.ID != 4 {
+		t.Fatalf("unexpected successes: %+v", result.Succeeded)
+	}
+	if dup := result.Succeeded[3]; !dup.SkippedAsDuplicate || dup.ExistingFileID != 1 || result.Succeeded[2].SkippedAsDuplicate {
+		t.Fatalf("expected file 4 to be reported as a duplicate of file 1: %+v", result.Succeeded)
+	}
+	var apiErr *APIError
+	if len(result.Failed) != 1 || result.Failed[0].Index != 2 || !errors.As(result.Err(), &apiErr) {
+		t.Fatalf("unexpected failures: %+v", result.Failed)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("expected at most 2 concurrent uploads, got %d", p)
+	}
+	if n := opened.Load(); n != 6 {
+		t.Fatalf("expected 6 files opened, got %d", n)
+	}
+
+	result, err = client.Files.UploadMany(context.Background(), reqs, &UploadManyOptions{Concurrency: 1, FailFast: true})
+	if err != nil {
+		t.Fatalf("UploadMany returned error: %v", err)
+	}
+	if len(result.Succeeded) != 2 || len(result.Failed) != 4 {
+		t.Fatalf("expected 2 successes and 4 failures, got %+v", result)
+	}
+	for _, f := range result.Failed[1:] {
+		if !errors.Is(f.Err, ErrBatchAborted) {
+			t.Fatalf("item %d: expected ErrBatchAborted, got %v", f.Index, f.Err)
+		}
+	}
+}
+
+func TestUploadSendsAlbumID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/upload" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		if got := r.FormValue("album_id"); got != "42" {
+			t.Errorf("unexpected album_id: %q", got)
+		}
+		if got := r.FormValue("description"); got != "Beach" {
+			t.Errorf("unexpected description: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"id":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	albumID := int64(42)
+	_, err := client.Files.Upload(context.Background(), strings.NewReader("data"), &UploadOptions{
+		Description:         "Beach",
+		AlbumID:             &albumID,
+		IgnoreAlbumDefaults: true,
+	})
+	if err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+}
+
+func TestUploadAppliesAlbumDefaults(t *testing.T) {
+	t.Parallel()
+
+	var settingsRequests atomic.Int64
+	var sent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/albums/7/settings":
+			settingsRequests.Add(1)
+			_, _ = w.Write([]byte(`{"auto_tags":["press","2024"],"strip_metadata":"gps","watermark_preset":"logo","visibility":"unlisted"}`))
+		case "/api/albums/8/settings":
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		case "/api/files/upload":
+			sent.Store(r.FormValue("tags") + " " + r.FormValue("strip_metadata") + " " +
+				r.FormValue("watermark_preset") + " " + r.FormValue("visibility"))
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":1}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	album7, album8 := int64(7), int64(8)
+	tests := []struct {
+		name string
+		opts *UploadOptions
+		want string
+	}{
+		{"defaults", &UploadOptions{AlbumID: &album7}, `["press","2024"] gps logo unlisted`},
+		{"explicit", &UploadOptions{AlbumID: &album7, Tags: []string{"press", "cover"}, Visibility: VisibilityPublic}, `["press","cover","2024"] gps logo public`},
+		{"ignored", &UploadOptions{AlbumID: &album7, IgnoreAlbumDefaults: true}, "   "},
+		{"no settings", &UploadOptions{AlbumID: &album8}, "   "},
+	}
+	for _, tt := range tests {
+		if _, err := client.Files.Upload(context.Background(), strings.NewReader("data"), tt.opts); err != nil {
+			t.Fatalf("%s: Upload returned error: %v", tt.name, err)
+		}
+		if got := sent.Load(); got != tt.want {
+			t.Fatalf("%s: sent %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if n := settingsRequests.Load(); n != 1 {
+		t.Fatalf("expected album settings to be fetched once, got %d", n)
+	}
+}
+
+func TestUpdateSendsOnlySetFields(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/files/5" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+		if _, ok := body["original_name"]; ok || body["description"] != "" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":5,"original_name":"a.jpg","description":""}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	empty := ""
+	file, err := client.Files.Update(context.Background(), 5, &UpdateFileOptions{Description: &empty})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	if file.ID != 5 {
+		t.Fatalf("unexpected file: %+v", file)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Files.Update(context.Background(), 5, &UpdateFileOptions{}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError for empty options, got %v", err)
+	}
+}
+
+func TestListUnfiled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query(); got.Get("unfiled") != "true" || got.Has("album_id") {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1}],"total":1,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	if _, err := client.Files.List(context.Background(), &ListOptions{Unfiled: true}); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+
+	albumID := int64(0)
+	var verr *ValidationError
+	if _, err := client.Files.List(context.Background(), &ListOptions{Unfiled: true, AlbumID: &albumID}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError for Unfiled with AlbumID, got %v", err)
+	}
+}
+
+func TestListShapeFilters(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.URL.Query()
+		if got.Get("orientation") != "landscape" || got.Get("min_aspect_ratio") != "1.5" || got.Has("max_aspect_ratio") {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1,"width":1600,"height":900}],"total":1,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Files.List(context.Background(), &ListOptions{
+		Orientation:    OrientationLandscape,
+		MinAspectRatio: 1.5,
+	})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if got := resp.Files[0].Orientation(); got != OrientationLandscape {
+		t.Errorf("Orientation() = %q, want %q", got, OrientationLandscape)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Files.List(context.Background(), &ListOptions{MinAspectRatio: 2, MaxAspectRatio: 1}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError for MinAspectRatio > MaxAspectRatio, got %v", err)
+	}
+}
+
+func TestSearchFiltersWithoutQuery(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := url.Values{
+			"mime_types":    {"image/png,image/webp"},
+			"min_size":      {"1024"},
+			"created_after": {"2026-01-01T00:00:00Z"},
+			"min_width":     {"800"},
+			"tag_ids":       {"3,4"},
+		}
+		if got := r.URL.Query(); got.Encode() != want.Encode() {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[],"total":0,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Files.Search(context.Background(), &SearchOptions{
+		MimeTypes:    []MimeType{MimePNG, MimeWebP},
+		MinSize:      1024,
+		CreatedAfter: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		MinWidth:     800,
+		TagIDs:       []int64{3, 4},
+	})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Files.Search(context.Background(), &SearchOptions{MinSize: 10, MaxSize: 5}); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError for MinSize > MaxSize, got %v", err)
+	}
+}
+
+func TestListIncludeTags(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query(); got.Get("include") != "tags" || got.Get("tag_id") != "7" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1,"tags":[{"id":7,"name":"Nature"}]}],"total":1,"page":1,"limit":20}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	tagID := int64(7)
+	resp, err := client.Files.List(context.Background(), &ListOptions{TagID: &tagID, IncludeTags: true})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if tags := resp.Files[0].Tags; len(tags) != 1 || tags[0].Name != "Nature" {
+		t.Fatalf("unexpected tags: %+v", tags)
+	}
+}
+
+func TestCompareDecodesIdenticalAndDifferingImages(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/compare" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			FileIDA int64 `json:"file_id_a"`
+			FileIDB int64 `json:"file_id_b"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.FileIDB == 2 {
+			_, _ = w.Write([]byte(`{"file_id_a":1,"file_id_b":2,"similarity":1,"diff_percent":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"file_id_a":1,"file_id_b":3,"similarity":0.82,"diff_percent":4.5,"diff_url":"https://cdn.example.com/diff.png"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	identical, err := client.Files.Compare(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("Compare returned error: %v", err)
+	}
+	if identical.FileIDA != 1 || identical.FileIDB != 2 || identical.Similarity != 1 || identical.DiffURL != nil {
+		t.Fatalf("unexpected identical result: %+v", identical)
+	}
+
+	differing, err := client.Files.Compare(context.Background(), 1, 3)
+	if err != nil {
+		t.Fatalf("Compare returned error: %v", err)
+	}
+	if differing.DiffPercent != 4.5 || differing.GetDiffURL() != "https://cdn.example.com/diff.png" {
+		t.Fatalf("unexpected differing result: %+v", differing)
+	}
+}
+
+func TestBoundingBoxValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		bounds BoundingBox
+		want   string
+	}{
+		{"paris", BoundingBox{North: 48.90, South: 48.81, East: 2.42, West: 2.25}, ""},
+		{"whole world", BoundingBox{North: 90, South: -90, East: 180, West: -180}, ""},
+		{"antimeridian", BoundingBox{North: 10, South: -10, East: -170, West: 170}, ""},
+		{"latitude out of range", BoundingBox{North: 91, South: -91}, "North,South"},
+		{"longitude out of range", BoundingBox{East: 180.5, West: -181}, "East,West"},
+		{"north below south", BoundingBox{North: 10, South: 20}, "North"},
+	}
+	for _, tt := range tests {
+		if got := invalidFields(t, tt.bounds.Validate()); got != tt.want {
+			t.Errorf("%s: invalid fields = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestListByBoundsSendsBoxAndZoom(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodGet || r.URL.Path != "/api/files/geo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Encode(); got != "east=2.42&north=48.9&south=48.81&west=2.25&zoom=12" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"clusters":[{"center":{"latitude":48.85,"longitude":2.35},"count":14,"cover":{"id":3}}],"files":[{"id":9}],"total":15}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	resp, err := client.Files.ListByBounds(ctx, BoundingBox{North: 48.90, South: 48.81, East: 2.42, West: 2.25}, 12)
+	if err != nil {
+		t.Fatalf("ListByBounds returned error: %v", err)
+	}
+	if resp.Total != 15 || len(resp.Clusters) != 1 || resp.Clusters[0].Count != 14 || resp.Clusters[0].Center.Longitude != 2.35 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(resp.Files) != 1 || resp.Files[0].ID != 9 {
+		t.Fatalf("unexpected files: %+v", resp.Files)
+	}
+
+	if _, err := client.Files.ListByBounds(ctx, BoundingBox{North: 95}, 12); invalidFields(t, err) != "North" {
+		t.Fatalf("expected North to be rejected, got %v", err)
+	}
+	if _, err := client.Files.ListByBounds(ctx, BoundingBox{}, -1); err == nil {
+		t.Fatal("expected error for negative zoom")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected invalid calls to make no request, got %d requests", n)
+	}
+}
+
+func TestEmbeddedMetadataIsSentOnUploadAndWriteBack(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/files/upload":
+			if got := r.FormValue("embed_metadata"); got != `{"creator":"Jane Doe","keywords":["sunset"]}` {
+				t.Errorf("unexpected embed_metadata: %s", got)
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":3}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/files/3/embedded-metadata":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"title":"Beach","copyright":"© 2024 Jane Doe"}` {
+				t.Errorf("unexpected body: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"message":"Metadata updated"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	_, err := client.Files.Upload(ctx, strings.NewReader("image"), &UploadOptions{
+		EmbedMetadata: &IPTC{Creator: "Jane Doe", Keywords: []string{"sunset"}},
+	})
+	if err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+
+	resp, err := client.Files.SetEmbeddedMetadata(ctx, 3, &IPTC{Title: "Beach", Copyright: "© 2024 Jane Doe"})
+	if err != nil {
+		t.Fatalf("SetEmbeddedMetadata returned error: %v", err)
+	}
+	if resp.Message != "Metadata updated" {
+		t.Fatalf("unexpected message: %q", resp.Message)
+	}
+
+	if _, err := client.Files.SetEmbeddedMetadata(ctx, 3, nil); err == nil {
+		t.Fatal("expected error for nil metadata")
+	}
+}
+
+func TestStripMetadataReportsPerFileResults(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/strip-metadata" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"file_ids":[1,2],"mode":"gps"}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"id":1,"success":true},{"id":2,"success":false,"status":415,"error":"not an image"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	result, err := client.Files.StripMetadata(ctx, []int64{1, 2}, StripModeGPS)
+	if err != nil {
+		t.Fatalf("StripMetadata returned error: %v", err)
+	}
+	if len(result.Succeeded) != 1 || result.Succeeded[0] != 1 || len(result.Failed) != 1 || result.Failed[0].ID != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if _, err := client.Files.StripMetadata(ctx, []int64{1}, StripModeNone); err == nil {
+		t.Fatal("expected error for StripModeNone")
+	}
+	if _, err := client.Files.StripMetadata(ctx, nil, StripModeAll); err == nil {
+		t.Fatal("expected error for no file IDs")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected invalid calls to make no request, got %d requests", n)
+	}
+}
+
+func TestTimelineSendsGranularityAndDecodesBuckets(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/files/timeline" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		queries = append(queries, r.URL.Query().Encode())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"granularity":"day","total":5,"buckets":[
+			{"period":"2024-06-02","start":"2024-06-02T00:00:00Z","count":3,"samples":[{"id":7}]},
+			{"period":"2024-06-01","start":"2024-06-01T00:00:00Z","count":2}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	albumID := int64(4)
+	resp, err := client.Files.Timeline(ctx, &TimelineOptions{Granularity: TimelineDay, AlbumID: &albumID, Samples: 1})
+	if err != nil {
+		t.Fatalf("Timeline returned error: %v", err)
+	}
+	if resp.Granularity != TimelineDay || resp.Total != 5 || len(resp.Buckets) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if b := resp.Buckets[0]; b.Period != "2024-06-02" || b.Count != 3 || len(b.Samples) != 1 || b.Samples[0].ID != 7 {
+		t.Fatalf("unexpected bucket: %+v", b)
+	}
+
+	if _, err := client.Files.Timeline(ctx, nil); err != nil {
+		t.Fatalf("Timeline returned error: %v", err)
+	}
+	want := []string{"album_id=4&granularity=day&samples=1", "granularity=month"}
+	if strings.Join(queries, " ") != strings.Join(want, " ") {
+		t.Fatalf("unexpected queries: %q", queries)
+	}
+}
+
+func TestAltTextRequests(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/files/5/alt-text/generate":
+			if string(body) != `{"language":"zh-CN"}` {
+				t.Errorf("unexpected generate body: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"text":"木衣架上的红色T恤","language":"zh-CN","confidence":0.91}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/files/5/alt-text":
+			if string(body) != `{"alt_text":""}` {
+				t.Errorf("unexpected set body: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"message":"Alt text updated"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	suggestion, err := client.Files.GenerateAltText(ctx, 5, " zh-CN ")
+	if err != nil {
+		t.Fatalf("GenerateAltText returned error: %v", err)
+	}
+	if suggestion.Language != "zh-CN" || suggestion.Confidence != 0.91 || suggestion.Text == "" {
+		t.Fatalf("unexpected suggestion: %+v", suggestion)
+	}
+
+	resp, err := client.Files.SetAltText(ctx, 5, "")
+	if err != nil {
+		t.Fatalf("SetAltText returned error: %v", err)
+	}
+	if resp.Message != "Alt text updated" {
+		t.Fatalf("unexpected message: %q", resp.Message)
+	}
+}
+
+func TestSetFocalPointValidatesFractions(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodPut || r.URL.Path != "/api/files/8/focal-point" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"x":0.4,"y":0.3}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"Focal point updated"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	resp, err := client.Files.SetFocalPoint(ctx, 8, 0.4, 0.3)
+	if err != nil {
+		t.Fatalf("SetFocalPoint returned error: %v", err)
+	}
+	if resp.Message != "Focal point updated" {
+		t.Fatalf("unexpected message: %q", resp.Message)
+	}
+
+	for _, p := range [][2]float64{{-0.1, 0.5}, {0.5, 1.1}, {640, 480}} {
+		if _, err := client.Files.SetFocalPoint(ctx, 8, p[0], p[1]); err == nil {
+			t.Errorf("SetFocalPoint(%g, %g): expected error", p[0], p[1])
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected out-of-range points to make no request, got %d requests", n)
+	}
+
+	var file File
+	if err := json.Unmarshal([]byte(`{"id":8,"focal_point":{"x":0.25,"y":0.75}}`), &file); err != nil {
+		t.Fatalf("failed to decode file: %v", err)
+	}
+	if file.FocalPoint == nil || file.FocalPoint.X != 0.25 || file.FocalPoint.Y != 0.75 {
+		t.Fatalf("unexpected focal point: %+v", file.FocalPoint)
+	}
+}
+
+func TestEnhanceStartsJob(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/12/enhance" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"upscale":2,"denoise":true}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"job_9","type":"enhance","status":"queued","total":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	job, err := client.Files.Enhance(ctx, 12, &EnhanceOptions{Upscale: 2, Denoise: true})
+	if err != nil {
+		t.Fatalf("Enhance returned error: %v", err)
+	}
+	if job.ID != "job_9" || job.Type != "enhance" || job.Status != JobQueued {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	tests := []struct {
+		name   string
+		opts   EnhanceOptions
+		fields string
+	}{
+		{"bad upscale", EnhanceOptions{Upscale: 3}, "Upscale"},
+		{"nothing enabled", EnhanceOptions{ReplaceOriginal: true}, "EnhanceOptions"},
+	}
+	for _, tt := range tests {
+		_, err := client.Files.Enhance(ctx, 12, &tt.opts)
+		if got := invalidFields(t, err); got != tt.fields {
+			t.Errorf("%s: invalid fields = %q, want %q", tt.name, got, tt.fields)
+		}
+	}
+	if _, err := client.Files.Enhance(ctx, 12, nil); err == nil {
+		t.Error("expected error for nil options")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected invalid options to make no request, got %d requests", n)
+	}
+}
+
+func TestEnhanceReportsNotSupported(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotImplemented)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Files.Enhance(context.Background(), 12, &EnhanceOptions{Sharpen: true})
+	var notSupported *NotSupportedError
+	if !errors.As(err, &notSupported) || notSupported.Feature != FeatureEnhance {
+		t.Fatalf("expected NotSupportedError for enhance, got %v", err)
+	}
+}
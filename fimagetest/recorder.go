@@ -0,0 +1,290 @@
+// Package fimagetest provides helpers for testing code built on the F-Image SDK.
+package fimagetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RecordEnv forces the recorder into ModeRecord when set to a non-empty value.
+const RecordEnv = "FIMAGE_RECORD"
+
+// Mode selects how a Recorder handles requests.
+type Mode int
+
+const (
+	// ModeAuto replays when the golden file exists and records otherwise.
+	ModeAuto Mode = iota
+
+	// ModeRecord sends requests to the real API and records them.
+	ModeRecord
+
+	// ModeReplay serves responses from the golden file without network access.
+	ModeReplay
+)
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction
+// matches a request.
+var ErrNoInteraction = errors.New("fimagetest: no recorded interaction matches request")
+
+// redactedHeaders are never written to golden files.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Interaction is a single recorded request/response pair.
+type Interaction struct {
+	// Request is the recorded request.
+	Request RecordedRequest `json:"request"`
+
+	// Response is the recorded response.
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the stored form of an HTTP request.
+type RecordedRequest struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+
+	// URL is the request path and query, without scheme and host.
+	URL string `json:"url"`
+
+	// Header contains the request headers, with credentials removed.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the request body.
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the stored form of an HTTP response.
+type RecordedResponse struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int `json:"status_code"`
+
+	// Header contains the response headers.
+	Header http.Header `json:"header,omitempty"`
+
+	// Body is the response body.
+	Body string `json:"body,omitempty"`
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithMode sets the recorder mode. It overrides RecordEnv.
+func WithMode(mode Mode) RecorderOption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithTransport sets the transport used to reach the real API in record mode.
+// Defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// Recorder is an http.RoundTripper that records real API interactions to a
+// golden file and replays them deterministically.
+//
+// Example:
+//
+//	rec, err := fimagetest.NewRecorder("testdata/upload.json")
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := fimage.NewClient(token, fimage.WithHTTPClient(rec.Client()))
+//
+// Run once with FIMAGE_RECORD=1 and a real token to create the golden file;
+// later runs replay it without network access.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder creates a Recorder backed by the golden file at path.
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      ModeAuto,
+		transport: http.DefaultTransport,
+	}
+	if os.Getenv(RecordEnv) != "" {
+		r.mode = ModeRecord
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read golden file: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.interactions); err != nil {
+			return nil, fmt.Errorf("failed to decode golden file: %w", err)
+		}
+		r.used = make([]bool, len(r.interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that uses the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: redact(req.Header),
+			Body:   string(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redact(resp.Header),
+			Body:       string(respBody),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching the request method and URL.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	uri := req.URL.RequestURI()
+	for i, in := range r.interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.URL != uri {
+			continue
+		}
+		r.used[i] = true
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(in.Response.Body))),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, uri)
+}
+
+// Stop writes recorded interactions to the golden file. It is a no-op in
+// replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode golden file: %w", err)
+	}
+
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create golden file directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write golden file: %w", err)
+	}
+
+	return nil
+}
+
+// readBody drains body and replaces it with an equivalent in-memory reader.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+
+	return data, nil
+}
+
+// redact returns a copy of header without credentials.
+func redact(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	cloned := header.Clone()
+	for _, key := range redactedHeaders {
+		cloned.Del(key)
+	}
+	return cloned
+}
@@ -0,0 +1,72 @@
+package fimagetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"albums":[{"id":1,"name":"Vacation"}]}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "albums.json")
+
+	rec, err := NewRecorder(path, WithMode(ModeRecord))
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client := fimage.NewClient("secret-token", fimage.WithBaseURL(server.URL), fimage.WithHTTPClient(rec.Client()))
+	if _, err := client.Albums.List(context.Background()); err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if strings.Contains(string(golden), "secret-token") {
+		t.Fatal("golden file contains the API token")
+	}
+
+	rec, err = NewRecorder(path)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	if rec.Mode() != ModeReplay {
+		t.Fatalf("expected replay mode, got %v", rec.Mode())
+	}
+	client = fimage.NewClient("secret-token", fimage.WithBaseURL("http://replay.invalid"), fimage.WithHTTPClient(rec.Client()))
+
+	albums, err := client.Albums.List(context.Background())
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(albums) != 1 || albums[0].Name != "Vacation" {
+		t.Fatalf("unexpected albums: %+v", albums)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 real call, got %d", calls)
+	}
+
+	if _, err := client.Albums.List(context.Background()); !errors.Is(err, ErrNoInteraction) {
+		t.Fatalf("expected ErrNoInteraction, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+)
+
+// GalleryService handles public gallery page settings.
+type GalleryService struct {
+	client *Client
+}
+
+// GalleryTheme is the visual theme of the public gallery.
+type GalleryTheme string
+
+const (
+	// GalleryThemeLight is a light theme.
+	GalleryThemeLight GalleryTheme = "light"
+
+	// GalleryThemeDark is a dark theme.
+	GalleryThemeDark GalleryTheme = "dark"
+
+	// GalleryThemeMinimal is a borderless, image-first theme.
+	GalleryThemeMinimal GalleryTheme = "minimal"
+)
+
+// DomainStatus describes the verification state of a custom domain.
+type DomainStatus string
+
+const (
+	// DomainPending means DNS records have not been verified yet.
+	DomainPending DomainStatus = "pending"
+
+	// DomainActive means the domain is verified and serving the gallery.
+	DomainActive DomainStatus = "active"
+
+	// DomainFailed means verification failed.
+	DomainFailed DomainStatus = "failed"
+)
+
+// GallerySEO contains search engine and social sharing metadata.
+type GallerySEO struct {
+	// Title is the page title.
+	Title string `json:"title,omitempty"`
+
+	// Description is the meta description.
+	Description string `json:"description,omitempty"`
+
+	// Keywords are the meta keywords.
+	Keywords []string `json:"keywords,omitempty"`
+
+	// ImageURL is the Open Graph preview image.
+	ImageURL string `json:"image_url,omitempty"`
+
+	// NoIndex asks search engines not to index the gallery.
+	NoIndex bool `json:"no_index,omitempty"`
+}
+
+// Gallery represents the account's public gallery page.
+type Gallery struct {
+	// Enabled indicates if the public gallery is published.
+	Enabled bool `json:"enabled"`
+
+	// URL is the public gallery URL.
+	URL string `json:"url"`
+
+	// AlbumIDs are the albums shown in the gallery, in display order.
+	AlbumIDs []int64 `json:"album_ids"`
+
+	// Theme is the visual theme.
+	Theme GalleryTheme `json:"theme"`
+
+	// CustomDomain is the custom domain serving the gallery (if any).
+	CustomDomain string `json:"custom_domain,omitempty"`
+
+	// DomainStatus is the custom domain verification state.
+	DomainStatus DomainStatus `json:"domain_status,omitempty"`
+
+	// DNSTarget is the CNAME target to configure for the custom domain.
+	DNSTarget string `json:"dns_target,omitempty"`
+
+	// SEO is the search engine metadata.
+	SEO GallerySEO `json:"seo"`
+}
+
+// UpdateGalleryOptions contains options for updating the public gallery.
+// Nil fields are left unchanged.
+type UpdateGalleryOptions struct {
+	// Enabled publishes or unpublishes the gallery.
+	Enabled *bool
+
+	// AlbumIDs replaces the albums shown, in display order.
+	AlbumIDs *[]int64
+
+	// Theme sets the visual theme.
+	Theme *GalleryTheme
+
+	// CustomDomain sets the custom domain. Point to "" to remove it.
+	CustomDomain *string
+
+	// SEO replaces the search engine metadata.
+	SEO *GallerySEO
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UpdateGalleryOptions) Validate() error {
+	var v validator
+	if opts.Theme != nil {
+		switch *opts.Theme {
+		case GalleryThemeLight, GalleryThemeDark, GalleryThemeMinimal:
+		default:
+			v.check(false, "Theme", "has unsupported value %q", *opts.Theme)
+		}
+	}
+	return v.err()
+}
+
+// Get returns the public gallery settings.
+//
+// Example:
+//
+//	gallery, err := client.Gallery.Get(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(gallery.URL)
+func (s *GalleryService) Get(ctx context.Context, callOpts ...CallOption) (*Gallery, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceGallery, callOpts)
+
+	var gallery Gallery
+	if err := s.client.request(ctx, http.MethodGet, "/api/gallery", nil, &gallery); err != nil {
+		return nil, err
+	}
+
+	return &gallery, nil
+}
+
+// Update updates the public gallery settings.
+//
+// Example:
+//
+//	enabled := true
+//	domain := "photos.example.com"
+//	gallery, err := client.Gallery.Update(ctx, &fimage.UpdateGalleryOptions{
+//	    Enabled:      &enabled,
+//	    AlbumIDs:     &[]int64{12, 13},
+//	    CustomDomain: &domain,
+//	    SEO: &fimage.GallerySEO{
+//	        Title:       "Jane Doe Photography",
+//	        Description: "Portraits and landscapes",
+//	    },
+//	})
+func (s *GalleryService) Update(ctx context.Context, opts *UpdateGalleryOptions, callOpts ...CallOption) (*Gallery, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceGallery, callOpts)
+
+	if opts == nil {
+		opts = &UpdateGalleryOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		Enabled      *bool         `json:"enabled,omitempty"`
+		AlbumIDs     *[]int64      `json:"album_ids,omitempty"`
+		Theme        *GalleryTheme `json:"theme,omitempty"`
+		CustomDomain *string       `json:"custom_domain,omitempty"`
+		SEO          *GallerySEO   `json:"seo,omitempty"`
+	}{
+		Enabled:      opts.Enabled,
+		AlbumIDs:     opts.AlbumIDs,
+		Theme:        opts.Theme,
+		CustomDomain: opts.CustomDomain,
+		SEO:          opts.SEO,
+	}
+
+	var gallery Gallery
+	if err := s.client.request(ctx, http.MethodPut, "/api/gallery", req, &gallery); err != nil {
+		return nil, err
+	}
+
+	return &gallery, nil
+}
+
+// VerifyDomain re-checks the DNS records of the custom domain.
+//
+// Example:
+//
+//	gallery, err := client.Gallery.VerifyDomain(ctx)
+//	if err == nil && gallery.DomainStatus != fimage.DomainActive {
+//	    fmt.Printf("Point a CNAME to %s\n", gallery.DNSTarget)
+//	}
+func (s *GalleryService) VerifyDomain(ctx context.Context, callOpts ...CallOption) (*Gallery, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceGallery, callOpts)
+
+	var gallery Gallery
+	if err := s.client.request(ctx, http.MethodPost, "/api/gallery/domain/verify", nil, &gallery); err != nil {
+		return nil, err
+	}
+
+	return &gallery, nil
+}
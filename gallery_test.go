@@ -0,0 +1,71 @@
+package fimage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGalleryRequests(t *testing.T) {
+	t.Parallel()
+
+	var updates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/gallery":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/gallery/domain/verify":
+		case r.Method == http.MethodPut && r.URL.Path == "/api/gallery":
+			body, _ := io.ReadAll(r.Body)
+			updates = append(updates, string(body))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"enabled":true,"url":"https://photos.example.com","album_ids":[12,13],"theme":"dark",
+			"custom_domain":"photos.example.com","domain_status":"pending","dns_target":"galleries.example.net",
+			"seo":{"title":"Jane Doe Photography","no_index":true}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	gallery, err := client.Gallery.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if !gallery.Enabled || gallery.Theme != GalleryThemeDark || len(gallery.AlbumIDs) != 2 || !gallery.SEO.NoIndex {
+		t.Fatalf("unexpected gallery: %+v", gallery)
+	}
+
+	gallery, err = client.Gallery.VerifyDomain(ctx)
+	if err != nil {
+		t.Fatalf("VerifyDomain returned error: %v", err)
+	}
+	if gallery.DomainStatus != DomainPending || gallery.DNSTarget != "galleries.example.net" {
+		t.Fatalf("unexpected domain state: %+v", gallery)
+	}
+
+	theme := GalleryThemeMinimal
+	domain := ""
+	if _, err := client.Gallery.Update(ctx, &UpdateGalleryOptions{
+		AlbumIDs:     &[]int64{13, 12},
+		Theme:        &theme,
+		CustomDomain: &domain,
+		SEO:          &GallerySEO{Title: "Portfolio"},
+	}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	want := `{"album_ids":[13,12],"theme":"minimal","custom_domain":"","seo":{"title":"Portfolio"}}`
+	if len(updates) != 1 || updates[0] != want {
+		t.Fatalf("unexpected update body: %q", updates)
+	}
+
+	unknown := GalleryTheme("neon")
+	_, err = client.Gallery.Update(ctx, &UpdateGalleryOptions{Theme: &unknown})
+	if got := invalidFields(t, err); got != "Theme" {
+		t.Fatalf("invalid fields = %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+//go:build ignore
+
+// gen-openapi generates the request and response types of the gen package
+// from the OpenAPI specification in gen/openapi.json.
+//
+// It is run by go generate from the repository root:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	specFile   = "gen/openapi.json"
+	outputFile = "gen/types.go"
+)
+
+// initialisms are name parts with a fixed spelling, following Go naming.
+var initialisms = map[string]string{
+	"api": "API", "id": "ID", "ids": "IDs", "ip": "IP", "json": "JSON",
+	"sha256": "SHA256", "uri": "URI", "url": "URL", "urls": "URLs", "uuid": "UUID",
+}
+
+// schema is the subset of an OpenAPI schema object used by the generator.
+type schema struct {
+	Ref                  string          `json:"$ref"`
+	Type                 string          `json:"type"`
+	Format               string          `json:"format"`
+	Description          string          `json:"description"`
+	Nullable             bool            `json:"nullable"`
+	Required             []string        `json:"required"`
+	Items                *schema         `json:"items"`
+	AdditionalProperties *schema         `json:"additionalProperties"`
+	Properties           json.RawMessage `json:"properties"`
+}
+
+// property is a named schema property, in specification order.
+type property struct {
+	name   string
+	schema *schema
+}
+
+func main() {
+	data, err := os.ReadFile(specFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var spec struct {
+		Components struct {
+			Schemas map[string]*schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		log.Fatalf("failed to parse %s: %v", specFile, err)
+	}
+
+	names := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var decls bytes.Buffer
+	for _, name := range names {
+		if err := writeType(&decls, name, spec.Components.Schemas[name]); err != nil {
+			log.Fatalf("schema %s: %v", name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen-openapi; DO NOT EDIT.\n\n")
+	buf.WriteString("package gen\n")
+	if bytes.Contains(decls.Bytes(), []byte("time.Time")) {
+		buf.WriteString("\nimport \"time\"\n")
+	}
+	buf.Write(decls.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(outputFile, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeType writes the Go declaration of a named schema.
+func writeType(buf *bytes.Buffer, name string, s *schema) error {
+	buf.WriteString("\n")
+	writeComment(buf, "", s.Description)
+
+	if s.Type != "object" || s.AdditionalProperties != nil {
+		typ, err := goType(s)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "type %s %s\n", name, typ)
+		return nil
+	}
+
+	props, err := properties(s.Properties)
+	if err != nil {
+		return err
+	}
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for i, p := range props {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		typ, err := goType(p.schema)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", p.name, err)
+		}
+		if p.schema.Nullable {
+			typ = "*" + typ
+		}
+		tag := p.name
+		if !required[p.name] {
+			tag += ",omitempty"
+		}
+		writeComment(buf, "\t", p.schema.Description)
+		fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`\n", fieldName(p.name), typ, tag)
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// goType returns the Go type of a schema.
+func goType(s *schema) (string, error) {
+	if s.Ref != "" {
+		const prefix = "#/components/schemas/"
+		if !strings.HasPrefix(s.Ref, prefix) {
+			return "", fmt.Errorf("unsupported reference %q", s.Ref)
+		}
+		return strings.TrimPrefix(s.Ref, prefix), nil
+	}
+
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "object":
+		if s.AdditionalProperties == nil {
+			return "map[string]interface{}", nil
+		}
+		elem, err := goType(s.AdditionalProperties)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + elem, nil
+	}
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+// properties decodes a properties object, keeping the specification order
+// so generated fields read in the order the API documents them.
+func properties(raw json.RawMessage) ([]property, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var props []property
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var s schema
+		if err := dec.Decode(&s); err != nil {
+			return nil, err
+		}
+		props = append(props, property{name: tok.(string), schema: &s})
+	}
+	return props, nil
+}
+
+// fieldName converts a snake_case property name to an exported Go name.
+func fieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if initialism, ok := initialisms[part]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// writeComment writes text as a doc comment wrapped at 80 columns.
+func writeComment(buf *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+	line := indent + "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 80 && line != indent+"//" {
+			buf.WriteString(line + "\n")
+			line = indent + "//"
+		}
+		line += " " + word
+	}
+	buf.WriteString(line + "\n")
+}
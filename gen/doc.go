@@ -0,0 +1,13 @@
+// Package gen contains request and response types generated from the F-Image
+// OpenAPI specification in openapi.json.
+//
+// The types mirror the wire format exactly and carry no behavior. Most
+// programs should use the ergonomic wrappers in the fimage package, which
+// re-export these types where they are used; import gen directly only to
+// build requests for endpoints that have no wrapper yet (see Client.Do).
+//
+// Do not edit types.go by hand. Update openapi.json and regenerate from the
+// repository root:
+//
+//	go generate ./...
+package gen
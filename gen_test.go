@@ -0,0 +1,100 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lpg-it/f-image-go/gen"
+)
+
+func TestGeneratedTypesMatchSpec(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("gen/openapi.json")
+	if err != nil {
+		t.Fatalf("failed to read spec: %v", err)
+	}
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Required []string `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("failed to decode spec: %v", err)
+	}
+
+	// Marshaling a zero value keeps exactly the required properties: the
+	// optional ones are omitempty.
+	types := map[string]interface{}{
+		"Notification":                 gen.Notification{},
+		"NotificationsListResponse":    gen.NotificationsListResponse{},
+		"UnreadCounts":                 gen.UnreadCounts{},
+		"MarkNotificationsReadRequest": gen.MarkNotificationsReadRequest{},
+		"MessageResponse":              gen.MessageResponse{},
+	}
+	if len(types) != len(spec.Components.Schemas) {
+		t.Fatalf("spec has %d schemas, test covers %d", len(spec.Components.Schemas), len(types))
+	}
+	for name, v := range types {
+		schema, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("%s: not in spec", name)
+			continue
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("%s: failed to marshal: %v", name, err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(out, &fields); err != nil {
+			t.Fatalf("%s: failed to decode: %v", name, err)
+		}
+		var got []string
+		for k := range fields {
+			got = append(got, k)
+		}
+		want := append([]string(nil), schema.Required...)
+		sort.Strings(got)
+		sort.Strings(want)
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: required properties = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestDoWithGeneratedTypes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/notifications/read" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"ids":[4,5]}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"Notifications marked as read","info":"2 updated"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var resp gen.MessageResponse
+	err := client.Do(context.Background(), http.MethodPost, "/api/notifications/read", gen.MarkNotificationsReadRequest{IDs: []int64{4, 5}}, &resp)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if resp.Message != "Notifications marked as read" || resp.Info != "2 updated" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
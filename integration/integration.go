@@ -0,0 +1,175 @@
+// Package integration provides a harness for running end-to-end tests
+// against the F-Image sandbox environment.
+//
+// Tests using the harness are skipped unless the FIMAGE_SANDBOX_TOKEN
+// environment variable is set, so they can live next to unit tests:
+//
+//	func TestUpload(t *testing.T) {
+//	    client := integration.NewClient(t)
+//	    file := integration.UploadFixture(t, client, nil)
+//	    // ...
+//	}
+//
+// Every resource created through the harness is removed when the test ends.
+package integration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+	"time"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+const (
+	// TokenEnv is the environment variable holding the sandbox API token.
+	TokenEnv = "FIMAGE_SANDBOX_TOKEN"
+
+	// BaseURLEnv optionally overrides the sandbox base URL.
+	BaseURLEnv = "FIMAGE_SANDBOX_URL"
+)
+
+// NewClient returns a client for the sandbox environment.
+// The test is skipped when TokenEnv is not set.
+func NewClient(tb testing.TB, opts ...fimage.ClientOption) *fimage.Client {
+	tb.Helper()
+
+	token := os.Getenv(TokenEnv)
+	if token == "" {
+		tb.Skipf("%s is not set; skipping sandbox integration test", TokenEnv)
+	}
+
+	clientOpts := []fimage.ClientOption{fimage.WithSandbox()}
+	if baseURL := os.Getenv(BaseURLEnv); baseURL != "" {
+		clientOpts = append(clientOpts, fimage.WithBaseURL(baseURL))
+	}
+	clientOpts = append(clientOpts, opts...)
+
+	return fimage.NewClient(token, clientOpts...)
+}
+
+// Name returns a unique name for a test resource.
+func Name(tb testing.TB, prefix string) string {
+	tb.Helper()
+	return fmt.Sprintf("%s-%s-%d", prefix, tb.Name(), time.Now().UnixNano())
+}
+
+// FixtureImage returns a small PNG image encoded in memory.
+// The fill color is derived from seed so that distinct seeds do not
+// get deduplicated by the server.
+func FixtureImage(seed int64) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	c := color.RGBA{R: uint8(seed), G: uint8(seed >> 8), B: uint8(seed >> 16), A: 255}
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		panic(fmt.Sprintf("integration: encode fixture image: %v", err))
+	}
+	return buf.Bytes()
+}
+
+// UploadFixture uploads a generated image and permanently deletes it when
+// the test ends.
+func UploadFixture(tb testing.TB, client *fimage.Client, opts *fimage.UploadOptions) *fimage.UploadData {
+	tb.Helper()
+
+	uploadOpts := fimage.UploadOptions{}
+	if opts != nil {
+		uploadOpts = *opts
+	}
+	if uploadOpts.Filename == "" {
+		uploadOpts.Filename = Name(tb, "fixture") + ".png"
+	}
+
+	data := FixtureImage(time.Now().UnixNano())
+	resp, err := client.Files.Upload(context.Background(), bytes.NewReader(data), &uploadOpts)
+	if err != nil {
+		tb.Fatalf("upload fixture: %v", err)
+	}
+	if resp.Data == nil {
+		tb.Fatal("upload fixture: response missing data")
+	}
+
+	fileID := resp.Data.ID
+	tb.Cleanup(func() {
+		ctx := context.Background()
+		if _, err := client.Files.Delete(ctx, fileID); err != nil && !fimage.IsNotFound(err) {
+			tb.Logf("cleanup: delete file %d: %v", fileID, err)
+			return
+		}
+		if _, err := client.Trash.PermanentDelete(ctx, fileID); err != nil && !fimage.IsNotFound(err) {
+			tb.Logf("cleanup: purge file %d: %v", fileID, err)
+		}
+	})
+
+	return resp.Data
+}
+
+// CreateAlbum creates an album and deletes it when the test ends.
+func CreateAlbum(tb testing.TB, client *fimage.Client) *fimage.Album {
+	tb.Helper()
+
+	album, err := client.Albums.Create(context.Background(), &fimage.CreateAlbumOptions{
+		Name: Name(tb, "album"),
+	})
+	if err != nil {
+		tb.Fatalf("create album: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		if _, err := client.Albums.Delete(context.Background(), album.ID); err != nil && !fimage.IsNotFound(err) {
+			tb.Logf("cleanup: delete album %d: %v", album.ID, err)
+		}
+	})
+
+	return album
+}
+
+// CreateTag creates a tag and deletes it when the test ends.
+func CreateTag(tb testing.TB, client *fimage.Client) *fimage.Tag {
+	tb.Helper()
+
+	tag, err := client.Tags.Create(context.Background(), &fimage.CreateTagOptions{
+		Name: Name(tb, "tag"),
+	})
+	if err != nil {
+		tb.Fatalf("create tag: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		if _, err := client.Tags.Delete(context.Background(), tag.ID); err != nil && !fimage.IsNotFound(err) {
+			tb.Logf("cleanup: delete tag %d: %v", tag.ID, err)
+		}
+	})
+
+	return tag
+}
+
+// CreateShare creates a share link and deletes it when the test ends.
+func CreateShare(tb testing.TB, client *fimage.Client, opts *fimage.CreateShareOptions) *fimage.ShareLink {
+	tb.Helper()
+
+	share, err := client.Share.Create(context.Background(), opts)
+	if err != nil {
+		tb.Fatalf("create share: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		if _, err := client.Share.Delete(context.Background(), share.ID); err != nil && !fimage.IsNotFound(err) {
+			tb.Logf("cleanup: delete share %d: %v", share.ID, err)
+		}
+	})
+
+	return share
+}
@@ -0,0 +1,99 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+	"github.com/lpg-it/f-image-go/integration"
+)
+
+func TestFilesLifecycle(t *testing.T) {
+	client := integration.NewClient(t)
+	ctx := context.Background()
+
+	album := integration.CreateAlbum(t, client)
+	file := integration.UploadFixture(t, client, nil)
+
+	if _, err := client.Files.Move(ctx, file.ID, &album.ID); err != nil {
+		t.Fatalf("Move returned error: %v", err)
+	}
+
+	resp, err := client.Files.List(ctx, &fimage.ListOptions{AlbumID: &album.ID})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	found := false
+	for _, f := range resp.Files {
+		if f.ID == file.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("uploaded file %d not listed in album %d", file.ID, album.ID)
+	}
+
+	if _, err := client.Files.Move(ctx, file.ID, nil); err != nil {
+		t.Fatalf("Move returned error: %v", err)
+	}
+}
+
+func TestTagsLifecycle(t *testing.T) {
+	client := integration.NewClient(t)
+	ctx := context.Background()
+
+	tag := integration.CreateTag(t, client)
+	file := integration.UploadFixture(t, client, nil)
+
+	if _, err := client.Tags.TagFile(ctx, file.ID, tag.ID); err != nil {
+		t.Fatalf("TagFile returned error: %v", err)
+	}
+
+	resp, err := client.Tags.GetFiles(ctx, tag.ID, nil)
+	if err != nil {
+		t.Fatalf("GetFiles returned error: %v", err)
+	}
+	if resp.Total < 1 {
+		t.Fatalf("expected tagged files, got %d", resp.Total)
+	}
+
+	if _, err := client.Tags.UntagFile(ctx, file.ID, tag.ID); err != nil {
+		t.Fatalf("UntagFile returned error: %v", err)
+	}
+}
+
+func TestShareLifecycle(t *testing.T) {
+	client := integration.NewClient(t)
+	ctx := context.Background()
+
+	file := integration.UploadFixture(t, client, nil)
+	share := integration.CreateShare(t, client, fimage.ShareFile(file.ID).WithPassword("secret123"))
+
+	content, err := client.Share.Access(ctx, share.Token)
+	if err != nil {
+		t.Fatalf("Access returned error: %v", err)
+	}
+	if !content.RequiresPassword {
+		t.Fatal("expected share to require a password")
+	}
+
+	if _, err := client.Share.VerifyPassword(ctx, share.Token, "secret123"); err != nil {
+		t.Fatalf("VerifyPassword returned error: %v", err)
+	}
+}
+
+func TestTrashLifecycle(t *testing.T) {
+	client := integration.NewClient(t)
+	ctx := context.Background()
+
+	file := integration.UploadFixture(t, client, nil)
+
+	if _, err := client.Files.Delete(ctx, file.ID); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := client.Trash.Restore(ctx, file.ID); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+}
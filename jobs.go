@@ -0,0 +1,186 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultJobPollInterval is the default interval between job status checks.
+const DefaultJobPollInterval = 2 * time.Second
+
+// JobsService handles asynchronous job operations.
+type JobsService struct {
+	client *Client
+}
+
+// JobStatus describes the state of an asynchronous job.
+type JobStatus string
+
+const (
+	// JobQueued means the job is waiting to start.
+	JobQueued JobStatus = "queued"
+
+	// JobRunning means the job is in progress.
+	JobRunning JobStatus = "running"
+
+	// JobSucceeded means the job finished successfully.
+	JobSucceeded JobStatus = "succeeded"
+
+	// JobFailed means the job finished with an error.
+	JobFailed JobStatus = "failed"
+
+	// JobCanceled means the job was canceled before it finished.
+	JobCanceled JobStatus = "canceled"
+)
+
+// Done reports whether the status is terminal.
+func (s JobStatus) Done() bool {
+	switch s {
+	case JobSucceeded, JobFailed, JobCanceled:
+		return true
+	}
+	return false
+}
+
+// Job represents an asynchronous server-side job.
+type Job struct {
+	// ID is the unique identifier of the job.
+	ID string `json:"id"`
+
+	// Type is the kind of job (e.g., "export").
+	Type string `json:"type"`
+
+	// Status is the job state.
+	Status JobStatus `json:"status"`
+
+	// Total is the number of items the job will process.
+	Total int `json:"total"`
+
+	// Completed is the number of items processed successfully.
+	Completed int `json:"completed"`
+
+	// Failed is the number of items that failed.
+	Failed int `json:"failed"`
+
+	// Error describes why the job failed (if it did).
+	Error string `json:"error,omitempty"`
+
+	// Result holds job-specific output once the job succeeds.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// CreatedAt is the job creation timestamp.
+	CreatedAt time.Time `json:"created_at"`
+
+	// FinishedAt is when the job reached a terminal status.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Progress returns the fraction of items processed, between 0 and 1.
+func (j *Job) Progress() float64 {
+	if j.Total <= 0 {
+		if j.Status.Done() {
+			return 1
+		}
+		return 0
+	}
+	return float64(j.Completed+j.Failed) / float64(j.Total)
+}
+
+// DecodeResult decodes the job result into v with encoding/json, since a
+// Job does not know the client's WithJSON functions.
+func (j *Job) DecodeResult(v interface{}) error {
+	if len(j.Result) == 0 {
+		return fmt.Errorf("job %s has no result", j.ID)
+	}
+	return json.Unmarshal(j.Result, v)
+}
+
+// Get returns the current state of a job.
+//
+// Example:
+//
+//	job, err := client.Jobs.Get(ctx, "job_123")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s: %.0f%%\n", job.Status, job.Progress()*100)
+func (s *JobsService) Get(ctx context.Context, jobID string, callOpts ...CallOption) (*Job, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceJobs, callOpts)
+
+	if jobID == "" {
+		return nil, fmt.Errorf("job ID is required")
+	}
+
+	path := fmt.Sprintf("/api/jobs/%s", url.PathEscape(jobID))
+
+	var job Job
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Cancel requests cancellation of a running job.
+func (s *JobsService) Cancel(ctx context.Context, jobID string, callOpts ...CallOption) (*Job, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceJobs, callOpts)
+
+	if jobID == "" {
+		return nil, fmt.Errorf("job ID is required")
+	}
+
+	path := fmt.Sprintf("/api/jobs/%s/cancel", url.PathEscape(jobID))
+
+	var job Job
+	if err := s.client.request(ctx, http.MethodPost, path, nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Wait polls a job until it reaches a terminal status or ctx is done. It
+// returns ErrClientClosed if the client shuts down while waiting.
+// A pollInterval of zero uses DefaultJobPollInterval. The onProgress
+// callback, if non-nil, is called after every poll.
+//
+// Example:
+//
+//	job, err := client.Jobs.Wait(ctx, job.ID, 0, func(j *fimage.Job) {
+//	    fmt.Printf("%d/%d\n", j.Completed, j.Total)
+//	})
+func (s *JobsService) Wait(ctx context.Context, jobID string, pollInterval time.Duration, onProgress func(*Job), callOpts ...CallOption) (*Job, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceJobs, callOpts)
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultJobPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := s.Get(ctx, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if onProgress != nil {
+			onProgress(job)
+		}
+		if job.Status.Done() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-s.client.Done():
+			return job, ErrClientClosed
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobsWaitPollsUntilDone(t *testing.T) {
+	t.Parallel()
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/jobs/job_1" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&polls, 1) < 3 {
+			_, _ = w.Write([]byte(`{"id":"job_1","status":"running","total":4,"completed":2}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"job_1","status":"succeeded","total":4,"completed":4}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var progress []float64
+	job, err := client.Jobs.Wait(context.Background(), "job_1", time.Millisecond, func(j *Job) {
+		progress = append(progress, j.Progress())
+	})
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if job.Status != JobSucceeded {
+		t.Fatalf("unexpected status: %s", job.Status)
+	}
+	if len(progress) != 3 || progress[0] != 0.5 || progress[2] != 1 {
+		t.Fatalf("unexpected progress: %v", progress)
+	}
+}
@@ -0,0 +1,32 @@
+package fimage
+
+// MarshalFunc encodes v as JSON, like json.Marshal.
+type MarshalFunc func(v interface{}) ([]byte, error)
+
+// UnmarshalFunc decodes JSON data into v, like json.Unmarshal.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+// WithJSON replaces encoding/json for request and response bodies, e.g. with
+// goccy/go-json or sonic for high-QPS workloads. The functions must honor
+// json struct tags and custom (un)marshalers like encoding/json does. A nil
+// function keeps the encoding/json default for that direction. Error
+// responses, tolerant decoding of list records, and Job.DecodeResult always
+// use encoding/json.
+//
+// Example:
+//
+//	import gojson "github.com/goccy/go-json"
+//
+//	client := fimage.NewClient("your-api-token",
+//	    fimage.WithJSON(gojson.Marshal, gojson.Unmarshal),
+//	)
+func WithJSON(marshal MarshalFunc, unmarshal UnmarshalFunc) ClientOption {
+	return func(c *Client) {
+		if marshal != nil {
+			c.marshalJSON = marshal
+		}
+		if unmarshal != nil {
+			c.unmarshalJSON = unmarshal
+		}
+	}
+}
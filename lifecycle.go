@@ -0,0 +1,134 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// lifecycle tracks in-flight work so the client can shut down gracefully.
+type lifecycle struct {
+	mu       sync.Mutex
+	inflight int
+	closed   bool
+
+	// done is closed when shutdown begins.
+	done chan struct{}
+
+	// idle is closed once shutdown has begun and no work is in flight.
+	idle chan struct{}
+
+	// abortCtx is canceled when a shutdown deadline expires, interrupting
+	// the work still in flight.
+	abortCtx context.Context
+	abort    context.CancelFunc
+}
+
+// newLifecycle returns the lifecycle of an open client.
+func newLifecycle() *lifecycle {
+	abortCtx, abort := context.WithCancel(context.Background())
+	return &lifecycle{
+		done:     make(chan struct{}),
+		idle:     make(chan struct{}),
+		abortCtx: abortCtx,
+		abort:    abort,
+	}
+}
+
+// begin registers a unit of in-flight work. It returns a context that is
+// canceled if a shutdown deadline expires, and a function that must be
+// called when the work finishes.
+func (l *lifecycle) begin(ctx context.Context) (context.Context, func(), error) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil, nil, ErrClientClosed
+	}
+	l.inflight++
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(l.abortCtx, cancel)
+
+	var once sync.Once
+	end := func() {
+		once.Do(func() {
+			stop()
+			cancel()
+
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.inflight--
+			if l.closed && l.inflight == 0 {
+				close(l.idle)
+			}
+		})
+	}
+	return ctx, end, nil
+}
+
+// Done returns a channel that is closed when the client starts shutting
+// down. Background components built on the client (pollers, subscribers,
+// watchers) should stop when it is closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.life.done
+}
+
+// Shutdown gracefully stops the client: new calls fail with ErrClientClosed,
+// pollers such as Jobs.Wait return, in-flight requests are allowed to finish,
+// and idle connections are released. If ctx expires first, the requests
+// still in flight are canceled and Shutdown returns ctx's error. Calling
+// Shutdown again waits for the same drain.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	if err := client.Shutdown(ctx); err != nil {
+//	    log.Printf("forced shutdown: %v", err)
+//	}
+func (c *Client) Shutdown(ctx context.Context) error {
+	l := c.life
+
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.done)
+		if l.inflight == 0 {
+			close(l.idle)
+		}
+	}
+	l.mu.Unlock()
+
+	var err error
+	select {
+	case <-l.idle:
+	case <-ctx.Done():
+		l.abort()
+		err = fmt.Errorf("shutdown interrupted: %w", ctx.Err())
+	}
+
+	c.HTTPClient.CloseIdleConnections()
+	return err
+}
+
+// Close shuts the client down, waiting for in-flight requests to finish.
+// It is equivalent to Shutdown with a context that never expires.
+func (c *Client) Close() error {
+	return c.Shutdown(context.Background())
+}
+
+// lifecycleBody ends a unit of in-flight work when a streamed response body
+// is closed.
+type lifecycleBody struct {
+	io.ReadCloser
+	end func()
+}
+
+// Close closes the body and ends the work.
+func (b *lifecycleBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.end()
+	return err
+}
@@ -0,0 +1,257 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces API requests. Wait blocks until a request may be sent
+// or ctx is done.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter paces every API request through limiter, taking a token
+// for each retry as well as for the first attempt. Use a TokenBucket for a
+// single process, or a DistributedLimiter to share one budget across a fleet
+// of workers using the same API token.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token",
+//	    fimage.WithRateLimiter(fimage.NewTokenBucket(10, 20)), // 10 req/s, bursts of 20
+//	)
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// start registers a request with the client's lifecycle, fixes its request
+// ID, and applies the deadline of the call it belongs to. The returned
+// function must be called when the request ends.
+func (c *Client) start(ctx context.Context) (context.Context, func(), error) {
+	ctx, end, err := c.life.begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx = withCallRequestID(withCallDeadline(ctx))
+	if deadline, ok := ctx.Value(callDeadlineKey{}).(time.Time); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		endLife := end
+		end = func() {
+			cancel()
+			endLife()
+		}
+	}
+	return ctx, end, nil
+}
+
+// pace waits for the rate limiter and, with WithRateLimitWait, for an
+// exhausted server rate limit to reset. It is called before every attempt,
+// so retries take their own token.
+func (c *Client) pace(ctx context.Context) error {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+	if err := c.waitRateLimit(ctx); err != nil {
+		return fmt.Errorf("waiting for rate limit reset: %w", err)
+	}
+	return nil
+}
+
+// TokenBucket is an in-process RateLimiter that allows rate requests per
+// second on average with bursts of up to burst requests. It is safe for
+// concurrent use.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket returns a full token bucket. A burst below 1 is treated as 1.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait takes a token, blocking until one is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := b.take()
+		if wait <= 0 {
+			return nil
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// take takes a token if one is available and otherwise returns how long to
+// wait before trying again.
+func (b *TokenBucket) take() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// BucketStore holds token buckets shared by several processes, such as a
+// fleet of workers behind one API token. Take must atomically refill the
+// bucket at key, take one token if available, and otherwise report how long
+// to wait before trying again.
+type BucketStore interface {
+	Take(ctx context.Context, key string, rate float64, burst int) (wait time.Duration, err error)
+}
+
+// DistributedLimiter is a RateLimiter whose token bucket lives in a
+// BucketStore, so every process sharing the store and key collectively
+// respects one budget.
+type DistributedLimiter struct {
+	store BucketStore
+	key   string
+	rate  float64
+	burst int
+}
+
+// NewDistributedLimiter returns a limiter allowing rate requests per second
+// with bursts of up to burst requests across all processes that use the
+// same store and key. Use a key per API token, e.g. "fimage:ratelimit:" +
+// fimage.KeyID([]byte(token)).
+//
+// Example:
+//
+//	store := &fimage.RedisBucketStore{
+//	    Eval: func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+//	        return rdb.Eval(ctx, script, keys, args...).Result() // github.com/redis/go-redis/v9
+//	    },
+//	}
+//	client := fimage.NewClient(token, fimage.WithRateLimiter(
+//	    fimage.NewDistributedLimiter(store, "fimage:ratelimit:prod", 50, 100),
+//	))
+func NewDistributedLimiter(store BucketStore, key string, rate float64, burst int) *DistributedLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &DistributedLimiter{store: store, key: key, rate: rate, burst: burst}
+}
+
+// Wait takes a token from the shared bucket, blocking until one is
+// available or ctx is done.
+func (l *DistributedLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, err := l.store.Take(ctx, l.key, l.rate, l.burst)
+		if err != nil {
+			return fmt.Errorf("failed to take token: %w", err)
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// RedisTokenBucketScript is the Lua script RedisBucketStore runs. It uses
+// the Redis server clock, so workers with skewed clocks still agree, and
+// returns the wait in microseconds (0 when a token was taken).
+const RedisTokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+tokens = math.min(burst, tokens + (now - ts) * rate / 1000000)
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+elseif rate > 0 then
+  wait = math.ceil((1 - tokens) * 1000000 / rate)
+else
+  wait = 1000000
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+if rate > 0 then
+  redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+end
+return wait
+`
+
+// RedisBucketStore is a BucketStore backed by Redis. It has no Redis
+// dependency of its own: Eval runs a Lua script with the Redis client of
+// your choice.
+type RedisBucketStore struct {
+	// Eval runs script with keys and args and returns its result, e.g.
+	// rdb.Eval(ctx, script, keys, args...).Result() with go-redis.
+	Eval func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
+
+// Take implements BucketStore.
+func (s *RedisBucketStore) Take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error) {
+	res, err := s.Eval(ctx, RedisTokenBucketScript, []string{key}, rate, burst)
+	if err != nil {
+		return 0, err
+	}
+
+	var micros int64
+	switch v := res.(type) {
+	case int64:
+		micros = v
+	case int:
+		micros = int64(v)
+	case float64:
+		micros = int64(v)
+	default:
+		return 0, fmt.Errorf("unexpected script result %T", res)
+	}
+	return time.Duration(micros) * time.Microsecond, nil
+}
+
+// sleepCtx sleeps for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
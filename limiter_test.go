@@ -0,0 +1,155 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketRefillsAtRate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	b := NewTokenBucket(2, 2)
+	b.now = func() time.Time { return now }
+
+	if b.take() != 0 || b.take() != 0 {
+		t.Fatal("burst tokens should be available immediately")
+	}
+	if wait := b.take(); wait != 500*time.Millisecond {
+		t.Fatalf("expected 500ms wait, got %s", wait)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if wait := b.take(); wait != 0 {
+		t.Fatalf("expected a refilled token, got wait %s", wait)
+	}
+}
+
+// memoryBucketStore is a BucketStore shared by goroutines standing in for
+// separate processes.
+type memoryBucketStore struct {
+	mu      sync.Mutex
+	buckets map[string]*TokenBucket
+}
+
+func (s *memoryBucketStore) Take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error) {
+	s.mu.Lock()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = NewTokenBucket(rate, burst)
+		s.buckets[key] = b
+	}
+	s.mu.Unlock()
+	return b.take(), nil
+}
+
+func TestDistributedLimiterSharesBudgetAcrossClients(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	store := &memoryBucketStore{buckets: map[string]*TokenBucket{}}
+	newWorker := func() *Client {
+		return NewClient("test-token",
+			WithBaseURL(server.URL),
+			WithHTTPClient(server.Client()),
+			WithRateLimiter(NewDistributedLimiter(store, "fleet", 0.001, 3)),
+		)
+	}
+	workers := []*Client{newWorker(), newWorker()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, c := range workers {
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func(c *Client) {
+				defer wg.Done()
+				_, _ = c.Tags.List(ctx)
+			}(c)
+		}
+	}
+	wg.Wait()
+
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("fleet sent %d requests, want the shared burst of 3", n)
+	}
+}
+
+func TestRedisBucketStoreRunsScript(t *testing.T) {
+	t.Parallel()
+
+	var gotKeys []string
+	store := &RedisBucketStore{
+		Eval: func(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+			if script != RedisTokenBucketScript || len(args) != 2 {
+				t.Fatalf("unexpected eval: %d args", len(args))
+			}
+			gotKeys = keys
+			return int64(250000), nil
+		},
+	}
+
+	wait, err := store.Take(context.Background(), "fleet", 4, 8)
+	if err != nil {
+		t.Fatalf("Take returned error: %v", err)
+	}
+	if wait != 250*time.Millisecond || len(gotKeys) != 1 || gotKeys[0] != "fleet" {
+		t.Fatalf("unexpected result: wait %s, keys %v", wait, gotKeys)
+	}
+}
+
+// countingLimiter is a RateLimiter that counts the tokens taken.
+type countingLimiter struct {
+	waits atomic.Int64
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return nil
+}
+
+func TestRateLimiterPacesEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithRateLimiter(limiter),
+	)
+
+	if _, err := client.Files.Get(context.Background(), 1, WithRetries(1)); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+	if n := limiter.waits.Load(); n != 2 {
+		t.Fatalf("expected a token per attempt, got %d", n)
+	}
+}
@@ -17,7 +17,9 @@ type LogosService struct {
 //
 // The returned Logo always includes the normalized domain. When no logo exists,
 // the returned Logo has an empty URL and no error.
-func (s *LogosService) Get(ctx context.Context, domain string) (*Logo, error) {
+func (s *LogosService) Get(ctx context.Context, domain string, callOpts ...CallOption) (*Logo, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceLogos, callOpts)
+
 	normalizedDomain := normalizeLogoLookupDomain(domain)
 	if normalizedDomain == "" {
 		return nil, fmt.Errorf("domain is required")
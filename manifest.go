@@ -0,0 +1,130 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ManifestOptions contains options for building a slideshow manifest.
+type ManifestOptions struct {
+	// Variant is the size variant the URLs point to (default: VariantMedium).
+	Variant Variant
+
+	// Shuffle returns the files in random order instead of album order.
+	Shuffle bool
+
+	// SlideDuration is how long each image is shown (default: 10 seconds).
+	SlideDuration time.Duration
+
+	// URLTTL is how long the signed URLs stay valid (default: 24 hours).
+	URLTTL time.Duration
+}
+
+// Validate checks the options for invalid fields.
+func (opts *ManifestOptions) Validate() error {
+	var v validator
+	v.check(opts.Variant == "" || opts.Variant.Valid(), "Variant", "has unsupported value %q", opts.Variant)
+	v.check(opts.SlideDuration == 0 || opts.SlideDuration >= time.Second, "SlideDuration", "must be at least 1s")
+	v.check(opts.URLTTL == 0 || opts.URLTTL >= time.Minute, "URLTTL", "must be at least 1m")
+	return v.err()
+}
+
+// ManifestItem is one slide of a manifest.
+type ManifestItem struct {
+	// FileID is the ID of the file.
+	FileID int64 `json:"file_id"`
+
+	// URL is the signed URL of the image.
+	URL string `json:"url"`
+
+	// Width is the image width in pixels.
+	Width int `json:"width"`
+
+	// Height is the image height in pixels.
+	Height int `json:"height"`
+
+	// DurationMS is how long the slide is shown, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// Duration returns how long the slide is shown.
+func (i *ManifestItem) Duration() time.Duration {
+	return time.Duration(i.DurationMS) * time.Millisecond
+}
+
+// Manifest is an ordered playlist of an album's images.
+type Manifest struct {
+	// AlbumID is the ID of the album.
+	AlbumID int64 `json:"album_id"`
+
+	// Items are the slides in playback order.
+	Items []ManifestItem `json:"items"`
+
+	// ExpiresAt is when the signed URLs expire. Fetch a new manifest
+	// before then.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Duration returns the total playback time of the manifest.
+func (m *Manifest) Duration() time.Duration {
+	var total time.Duration
+	for i := range m.Items {
+		total += m.Items[i].Duration()
+	}
+	return total
+}
+
+// StreamManifest returns an ordered playlist of signed image URLs with
+// display durations, for slideshows and digital signage players. Files that
+// are not images are skipped.
+//
+// Example:
+//
+//	manifest, err := client.Albums.StreamManifest(ctx, 123, &fimage.ManifestOptions{
+//	    Variant:       fimage.VariantOriginal,
+//	    Shuffle:       true,
+//	    SlideDuration: 15 * time.Second,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range manifest.Items {
+//	    player.Show(item.URL)
+//	    time.Sleep(item.Duration())
+//	}
+func (s *AlbumsService) StreamManifest(ctx context.Context, albumID int64, opts *ManifestOptions, callOpts ...CallOption) (*Manifest, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceAlbums, callOpts)
+
+	if opts == nil {
+		opts = &ManifestOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if opts.Variant != "" {
+		query.Set("variant", string(opts.Variant))
+	}
+	if opts.Shuffle {
+		query.Set("shuffle", "true")
+	}
+	if opts.SlideDuration > 0 {
+		query.Set("slide_duration_ms", strconv.FormatInt(opts.SlideDuration.Milliseconds(), 10))
+	}
+	if opts.URLTTL > 0 {
+		query.Set("ttl", strconv.FormatInt(int64(opts.URLTTL/time.Second), 10))
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/manifest", albumID)
+
+	var manifest Manifest
+	if err := s.client.requestWithQuery(ctx, path, query, &manifest); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
@@ -0,0 +1,51 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStreamManifestMapsOptionsToQuery(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/albums/3/manifest" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		want := "shuffle=true&slide_duration_ms=1500&ttl=7200&variant=original"
+		if got := r.URL.RawQuery; got != want {
+			t.Errorf("unexpected query: %q, want %q", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"album_id":3,"items":[
+			{"file_id":1,"url":"https://cdn/1.jpg","duration_ms":1500},
+			{"file_id":2,"url":"https://cdn/2.jpg","duration_ms":2500}
+		],"expires_at":"2024-01-02T03:04:05Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	manifest, err := client.Albums.StreamManifest(context.Background(), 3, &ManifestOptions{
+		Variant:       VariantOriginal,
+		Shuffle:       true,
+		SlideDuration: 1500 * time.Millisecond,
+		URLTTL:        2 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("StreamManifest returned error: %v", err)
+	}
+	if len(manifest.Items) != 2 || manifest.Items[0].Duration() != 1500*time.Millisecond {
+		t.Fatalf("unexpected items: %+v", manifest.Items)
+	}
+	if got := manifest.Duration(); got != 4*time.Second {
+		t.Fatalf("unexpected total duration: %v", got)
+	}
+
+	if _, err := client.Albums.StreamManifest(context.Background(), 3, &ManifestOptions{SlideDuration: time.Millisecond}); err == nil {
+		t.Fatal("expected an error for a slide duration under 1s")
+	}
+}
@@ -0,0 +1,44 @@
+package fimage
+
+import "net/http"
+
+// RoundTripFunc sends an HTTP request and returns its response, like
+// http.RoundTripper.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of requests, e.g. to refresh credentials,
+// add headers, log, cache, or inject faults in tests. It receives requests
+// with every SDK header already set and must call next to send them, unless
+// it answers them itself.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware adds middleware around every HTTP request the client sends,
+// without replacing its HTTP client. Retried requests pass through the
+// middleware once per attempt. The first middleware is the outermost: it sees
+// the request first and the response last. Repeated use appends to the chain.
+//
+// Example:
+//
+//	audit := func(next fimage.RoundTripFunc) fimage.RoundTripFunc {
+//	    return func(req *http.Request) (*http.Response, error) {
+//	        start := time.Now()
+//	        resp, err := next(req)
+//	        log.Printf("%s %s took %s", req.Method, req.URL.Path, time.Since(start))
+//	        return resp, err
+//	    }
+//	}
+//	client := fimage.NewClient("your-api-token", fimage.WithMiddleware(audit))
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// do sends req with the HTTP client through the middleware chain.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.HTTPClient.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next(req)
+}
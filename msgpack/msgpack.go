@@ -0,0 +1,344 @@
+// Package msgpack provides a MessagePack response codec for the F-Image SDK.
+//
+// MessagePack responses are smaller and cheaper to produce than JSON for large
+// listings and sync workloads. The codec converts them to JSON before the SDK
+// decodes them, so the SDK's types need no extra struct tags.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token",
+//	    fimage.WithCodec(msgpack.Codec{}),
+//	)
+package msgpack
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ContentType is the MessagePack media type.
+const ContentType = "application/msgpack"
+
+// maxDepth bounds the nesting of arrays and maps in a document.
+const maxDepth = 1000
+
+// timestampExt is the MessagePack extension type for timestamps.
+const timestampExt = -1
+
+// ErrTruncated is returned when a document ends in the middle of a value.
+var ErrTruncated = errors.New("msgpack: unexpected end of data")
+
+// Codec is a fimage.Codec for MessagePack responses.
+//
+// Values map to JSON as follows: binary data becomes a base64 string (as
+// encoding/json expects for []byte), timestamps become RFC 3339 strings (as
+// time.Time expects), and integer map keys become strings. Other extension
+// types and non-finite floats are rejected.
+type Codec struct{}
+
+// ContentType returns ContentType.
+func (Codec) ContentType() string {
+	return ContentType
+}
+
+// ToJSON converts a MessagePack document to JSON.
+func (Codec) ToJSON(data []byte) ([]byte, error) {
+	return ToJSON(data)
+}
+
+// ToJSON converts a single MessagePack document to JSON.
+func ToJSON(data []byte) ([]byte, error) {
+	t := transcoder{data: data, out: bytes.NewBuffer(make([]byte, 0, len(data)*2))}
+	if err := t.value(0); err != nil {
+		return nil, err
+	}
+	if t.pos != len(t.data) {
+		return nil, fmt.Errorf("msgpack: %d trailing bytes", len(t.data)-t.pos)
+	}
+	return t.out.Bytes(), nil
+}
+
+// transcoder writes the JSON form of a MessagePack document.
+type transcoder struct {
+	data []byte
+	pos  int
+	out  *bytes.Buffer
+}
+
+// next returns the next n bytes of input.
+func (t *transcoder) next(n int) ([]byte, error) {
+	if n < 0 || len(t.data)-t.pos < n {
+		return nil, ErrTruncated
+	}
+	b := t.data[t.pos : t.pos+n]
+	t.pos += n
+	return b, nil
+}
+
+// uint reads a big-endian unsigned integer of size bytes.
+func (t *transcoder) uint(size int) (uint64, error) {
+	b, err := t.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// length reads a container or payload length of size bytes.
+func (t *transcoder) length(size int) (int, error) {
+	n, err := t.uint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(t.data)) {
+		return 0, ErrTruncated
+	}
+	return int(n), nil
+}
+
+// value transcodes one value.
+func (t *transcoder) value(depth int) error {
+	if depth > maxDepth {
+		return fmt.Errorf("msgpack: nesting exceeds %d levels", maxDepth)
+	}
+
+	b, err := t.next(1)
+	if err != nil {
+		return err
+	}
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		t.out.WriteString(strconv.FormatUint(uint64(c), 10))
+		return nil
+	case c >= 0xe0:
+		t.out.WriteString(strconv.FormatInt(int64(int8(c)), 10))
+		return nil
+	case c >= 0x80 && c <= 0x8f:
+		return t.mapBody(int(c&0x0f), depth)
+	case c >= 0x90 && c <= 0x9f:
+		return t.arrayBody(int(c&0x0f), depth)
+	case c >= 0xa0 && c <= 0xbf:
+		return t.str(int(c & 0x1f))
+	}
+
+	switch c {
+	case 0xc0:
+		t.out.WriteString("null")
+	case 0xc2:
+		t.out.WriteString("false")
+	case 0xc3:
+		t.out.WriteString("true")
+	case 0xc4, 0xc5, 0xc6:
+		n, err := t.length(1 << (c - 0xc4))
+		if err != nil {
+			return err
+		}
+		return t.bin(n)
+	case 0xc7, 0xc8, 0xc9:
+		n, err := t.length(1 << (c - 0xc7))
+		if err != nil {
+			return err
+		}
+		return t.ext(n)
+	case 0xca:
+		bits, err := t.uint(4)
+		if err != nil {
+			return err
+		}
+		return t.float(float64(math.Float32frombits(uint32(bits))), 32)
+	case 0xcb:
+		bits, err := t.uint(8)
+		if err != nil {
+			return err
+		}
+		return t.float(math.Float64frombits(bits), 64)
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := t.uint(1 << (c - 0xcc))
+		if err != nil {
+			return err
+		}
+		t.out.WriteString(strconv.FormatUint(n, 10))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		n, err := t.uint(size)
+		if err != nil {
+			return err
+		}
+		t.out.WriteString(strconv.FormatInt(signExtend(n, size), 10))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return t.ext(1 << (c - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := t.length(1 << (c - 0xd9))
+		if err != nil {
+			return err
+		}
+		return t.str(n)
+	case 0xdc, 0xdd:
+		n, err := t.length(2 << (c - 0xdc))
+		if err != nil {
+			return err
+		}
+		return t.arrayBody(n, depth)
+	case 0xde, 0xdf:
+		n, err := t.length(2 << (c - 0xde))
+		if err != nil {
+			return err
+		}
+		return t.mapBody(n, depth)
+	default:
+		return fmt.Errorf("msgpack: invalid type byte 0x%02x at offset %d", c, t.pos-1)
+	}
+	return nil
+}
+
+// signExtend interprets the low size bytes of n as a signed integer.
+func signExtend(n uint64, size int) int64 {
+	shift := 64 - 8*uint(size)
+	return int64(n<<shift) >> shift
+}
+
+// arrayBody transcodes the n elements of an array.
+func (t *transcoder) arrayBody(n, depth int) error {
+	t.out.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			t.out.WriteByte(',')
+		}
+		if err := t.value(depth + 1); err != nil {
+			return err
+		}
+	}
+	t.out.WriteByte(']')
+	return nil
+}
+
+// mapBody transcodes the n key/value pairs of a map.
+func (t *transcoder) mapBody(n, depth int) error {
+	t.out.WriteByte('{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			t.out.WriteByte(',')
+		}
+		if err := t.key(depth + 1); err != nil {
+			return err
+		}
+		t.out.WriteByte(':')
+		if err := t.value(depth + 1); err != nil {
+			return err
+		}
+	}
+	t.out.WriteByte('}')
+	return nil
+}
+
+// key transcodes a map key. JSON keys must be strings, so integer keys are
+// quoted; other key types are rejected.
+func (t *transcoder) key(depth int) error {
+	start := t.out.Len()
+	if err := t.value(depth); err != nil {
+		return err
+	}
+
+	k := t.out.Bytes()[start:]
+	if len(k) > 0 && k[0] == '"' {
+		return nil
+	}
+	if _, err := strconv.ParseInt(string(k), 10, 64); err != nil {
+		if _, err := strconv.ParseUint(string(k), 10, 64); err != nil {
+			return fmt.Errorf("msgpack: unsupported map key %s", k)
+		}
+	}
+	quoted := `"` + string(k) + `"`
+	t.out.Truncate(start)
+	t.out.WriteString(quoted)
+	return nil
+}
+
+// str transcodes a string payload of n bytes.
+func (t *transcoder) str(n int) error {
+	b, err := t.next(n)
+	if err != nil {
+		return err
+	}
+	return t.writeString(string(b))
+}
+
+// bin transcodes a binary payload of n bytes as a base64 string.
+func (t *transcoder) bin(n int) error {
+	b, err := t.next(n)
+	if err != nil {
+		return err
+	}
+	t.out.WriteByte('"')
+	t.out.WriteString(base64.StdEncoding.EncodeToString(b))
+	t.out.WriteByte('"')
+	return nil
+}
+
+// ext transcodes an extension value with a payload of n bytes.
+func (t *transcoder) ext(n int) error {
+	typ, err := t.next(1)
+	if err != nil {
+		return err
+	}
+	payload, err := t.next(n)
+	if err != nil {
+		return err
+	}
+	if int8(typ[0]) != timestampExt {
+		return fmt.Errorf("msgpack: unsupported extension type %d", int8(typ[0]))
+	}
+
+	var ts time.Time
+	switch n {
+	case 4:
+		ts = time.Unix(int64(binary.BigEndian.Uint32(payload)), 0)
+	case 8:
+		v := binary.BigEndian.Uint64(payload)
+		ts = time.Unix(int64(v&0x3ffffffff), int64(v>>34))
+	case 12:
+		nsec := binary.BigEndian.Uint32(payload[:4])
+		sec := int64(binary.BigEndian.Uint64(payload[4:]))
+		ts = time.Unix(sec, int64(nsec))
+	default:
+		return fmt.Errorf("msgpack: invalid timestamp length %d", n)
+	}
+	return t.writeString(ts.UTC().Format(time.RFC3339Nano))
+}
+
+// float writes a floating-point number.
+func (t *transcoder) float(f float64, bits int) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("msgpack: unsupported float value %v", f)
+	}
+	t.out.WriteString(strconv.FormatFloat(f, 'g', -1, bits))
+	return nil
+}
+
+// writeString writes s as a JSON string.
+func (t *transcoder) writeString(s string) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	t.out.Write(b)
+	return nil
+}
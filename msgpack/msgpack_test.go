@@ -0,0 +1,129 @@
+package msgpack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// fileDoc is {"id":300,"original_name":"a.jpg","width":-1,"created_at":<ts 1700000000>,"misc":[true,null],"ratio":1.5}.
+var fileDoc = []byte{
+	0x86,
+	0xa2, 'i', 'd', 0xcd, 0x01, 0x2c,
+	0xad, 'o', 'r', 'i', 'g', 'i', 'n', 'a', 'l', '_', 'n', 'a', 'm', 'e', 0xa5, 'a', '.', 'j', 'p', 'g',
+	0xa5, 'w', 'i', 'd', 't', 'h', 0xff,
+	0xaa, 'c', 'r', 'e', 'a', 't', 'e', 'd', '_', 'a', 't', 0xd6, 0xff, 0x65, 0x53, 0xf1, 0x00,
+	0xa4, 'm', 'i', 's', 'c', 0x92, 0xc3, 0xc0,
+	0xa5, 'r', 'a', 't', 'i', 'o', 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0,
+}
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+
+	got, err := ToJSON(fileDoc)
+	if err != nil {
+		t.Fatalf("ToJSON returned error: %v", err)
+	}
+	want := `{"id":300,"original_name":"a.jpg","width":-1,"created_at":"2023-11-14T22:13:20Z","misc":[true,null],"ratio":1.5}`
+	if string(got) != want {
+		t.Fatalf("unexpected JSON:\n got %s\nwant %s", got, want)
+	}
+
+	if _, err := ToJSON(fileDoc[:len(fileDoc)-1]); !errors.Is(err, ErrTruncated) {
+		t.Fatalf("expected ErrTruncated, got %v", err)
+	}
+	if _, err := ToJSON([]byte{0x81, 0x01, 0xc4, 0x01, 0xff}); err != nil {
+		t.Fatalf("integer key with binary value: %v", err)
+	}
+	if _, err := ToJSON([]byte{0xc1}); err == nil {
+		t.Fatal("expected error for reserved type byte")
+	}
+}
+
+func TestClientDecodesMsgpackResponses(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Accept"), ContentType) {
+			t.Errorf("unexpected Accept header: %q", r.Header.Get("Accept"))
+		}
+		if r.URL.Path == "/api/files/404" {
+			w.Header().Set("Content-Type", ContentType)
+			w.WriteHeader(http.StatusNotFound)
+			// {"code":"file_not_found"}
+			_, _ = w.Write(append([]byte{0x81, 0xa4, 'c', 'o', 'd', 'e', 0xae}, "file_not_found"...))
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = w.Write(fileDoc)
+	}))
+	defer server.Close()
+
+	client := fimage.NewClient("test-token",
+		fimage.WithBaseURL(server.URL),
+		fimage.WithHTTPClient(server.Client()),
+		fimage.WithCodec(Codec{}),
+	)
+
+	file, err := client.Files.Get(context.Background(), 300)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if file.ID != 300 || file.OriginalName != "a.jpg" || file.CreatedAt != "2023-11-14T22:13:20Z" {
+		t.Fatalf("unexpected file: %+v", file)
+	}
+
+	_, err = client.Files.Get(context.Background(), 404)
+	if !fimage.IsCode(err, fimage.CodeFileNotFound) {
+		t.Fatalf("expected file_not_found error, got %v", err)
+	}
+}
+
+func TestClientStreamsMetadataWithMsgpackCodec(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if got := r.Header.Get("Accept"); got != "text/csv" {
+				t.Errorf("unexpected export Accept header: %q", got)
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("filename,description,tags\na.jpg,Beach,summer\n"))
+		case http.MethodPut:
+			if got := r.Header.Get("Accept"); got != "application/json" {
+				t.Errorf("unexpected import Accept header: %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"updated":1,"skipped":0}`))
+		}
+	}))
+	defer server.Close()
+
+	client := fimage.NewClient("test-token",
+		fimage.WithBaseURL(server.URL),
+		fimage.WithHTTPClient(server.Client()),
+		fimage.WithCodec(Codec{}),
+	)
+
+	var out strings.Builder
+	if _, err := client.Albums.ExportMetadata(context.Background(), 1, &out, fimage.MetadataFormatCSV); err != nil {
+		t.Fatalf("ExportMetadata returned error: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "filename,") {
+		t.Fatalf("unexpected export: %q", out.String())
+	}
+
+	result, err := client.Albums.ImportMetadata(context.Background(), 1, strings.NewReader(out.String()), fimage.MetadataFormatCSV)
+	if err != nil {
+		t.Fatalf("ImportMetadata returned error: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
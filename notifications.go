@@ -0,0 +1,154 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/lpg-it/f-image-go/gen"
+)
+
+// NotificationsService handles in-app account notifications.
+type NotificationsService struct {
+	client *Client
+}
+
+// Notification is an in-app notification, as shown under the dashboard's
+// bell icon.
+type Notification = gen.Notification
+
+// NotificationListOptions contains options for listing notifications.
+type NotificationListOptions struct {
+	// Page is the page number (1-indexed).
+	Page int
+
+	// Limit is the number of items per page.
+	Limit int
+
+	// UnreadOnly lists only unread notifications.
+	UnreadOnly bool
+
+	// Type lists only notifications of this type.
+	Type string
+}
+
+// Validate checks the options for invalid fields.
+func (opts *NotificationListOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	return v.err()
+}
+
+// NotificationsListResponse is the response from listing notifications.
+type NotificationsListResponse = gen.NotificationsListResponse
+
+// UnreadCounts is the number of unread notifications, in total and per type.
+type UnreadCounts = gen.UnreadCounts
+
+// List returns a paginated list of notifications, newest first.
+//
+// Example:
+//
+//	resp, err := client.Notifications.List(ctx, &fimage.NotificationListOptions{
+//	    UnreadOnly: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, n := range resp.Notifications {
+//	    fmt.Printf("[%s] %s\n", n.Type, n.Title)
+//	}
+func (s *NotificationsService) List(ctx context.Context, opts *NotificationListOptions, callOpts ...CallOption) (*NotificationsListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceNotifications, callOpts)
+
+	query := url.Values{}
+
+	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.UnreadOnly {
+			query.Set("unread", "true")
+		}
+		if opts.Type != "" {
+			query.Set("type", opts.Type)
+		}
+	}
+
+	setDefaultLimit(ctx, query)
+
+	var resp NotificationsListResponse
+	if err := s.client.requestWithQuery(ctx, "/api/notifications", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// MarkRead marks notifications as read. Unknown or already-read IDs are
+// ignored.
+//
+// Example:
+//
+//	_, err := client.Notifications.MarkRead(ctx, []int64{1, 2, 3})
+func (s *NotificationsService) MarkRead(ctx context.Context, ids []int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceNotifications, callOpts)
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one notification ID is required")
+	}
+
+	req := gen.MarkNotificationsReadRequest{IDs: ids}
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodPost, "/api/notifications/read", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// MarkAllRead marks every notification as read.
+//
+// Example:
+//
+//	_, err := client.Notifications.MarkAllRead(ctx)
+func (s *NotificationsService) MarkAllRead(ctx context.Context, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceNotifications, callOpts)
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodPost, "/api/notifications/read-all", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// UnreadCount returns the number of unread notifications, in total and per
+// type. It is cheaper than List for polling a badge.
+//
+// Example:
+//
+//	counts, err := client.Notifications.UnreadCount(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d unread (%d quota warnings)\n", counts.Total, counts.ByType["quota_warning"])
+func (s *NotificationsService) UnreadCount(ctx context.Context, callOpts ...CallOption) (*UnreadCounts, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceNotifications, callOpts)
+
+	var counts UnreadCounts
+	if err := s.client.request(ctx, http.MethodGet, "/api/notifications/unread-count", nil, &counts); err != nil {
+		return nil, err
+	}
+
+	return &counts, nil
+}
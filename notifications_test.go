@@ -0,0 +1,134 @@
+package fimage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNotificationsList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/notifications" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.RawQuery; got != "limit=10&page=2&type=quota_warning&unread=true" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"notifications": [
+				{"id": 9, "type": "quota_warning", "title": "Storage almost full", "message": "You have used 90% of your quota.", "link": "/settings/billing", "read": false, "created_at": "2024-07-01T09:30:00Z"}
+			],
+			"total": 11,
+			"unread": 4,
+			"page": 2,
+			"limit": 10
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Notifications.List(context.Background(), &NotificationListOptions{
+		Page:       2,
+		Limit:      10,
+		UnreadOnly: true,
+		Type:       "quota_warning",
+	})
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if resp.Total != 11 || resp.Unread != 4 || resp.Page != 2 || len(resp.Notifications) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	n := resp.Notifications[0]
+	if n.ID != 9 || n.Type != "quota_warning" || n.Link != "/settings/billing" || n.Read || n.CreatedAt.IsZero() {
+		t.Fatalf("unexpected notification: %+v", n)
+	}
+}
+
+func TestNotificationsMarkReadAndUnreadCount(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method string
+		path   string
+		body   string
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, call{method: r.Method, path: r.URL.Path, body: string(body)})
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"total":3,"by_type":{"quota_warning":1,"share_viewed":2}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"Notifications marked as read"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	resp, err := client.Notifications.MarkRead(ctx, []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("MarkRead returned error: %v", err)
+	}
+	if resp.Message != "Notifications marked as read" {
+		t.Fatalf("unexpected message: %q", resp.Message)
+	}
+	if _, err := client.Notifications.MarkAllRead(ctx); err != nil {
+		t.Fatalf("MarkAllRead returned error: %v", err)
+	}
+	counts, err := client.Notifications.UnreadCount(ctx)
+	if err != nil {
+		t.Fatalf("UnreadCount returned error: %v", err)
+	}
+	if counts.Total != 3 || counts.ByType["share_viewed"] != 2 {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+
+	want := []call{
+		{http.MethodPost, "/api/notifications/read", `{"ids":[1,2,3]}`},
+		{http.MethodPost, "/api/notifications/read-all", ``},
+		{http.MethodGet, "/api/notifications/unread-count", ``},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(calls))
+	}
+	for i, w := range want {
+		if calls[i] != w {
+			t.Errorf("request %d: got %+v, want %+v", i, calls[i], w)
+		}
+	}
+}
+
+func TestNotificationsValidateArguments(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	if _, err := client.Notifications.MarkRead(ctx, nil); err == nil {
+		t.Error("expected error for empty IDs")
+	}
+	_, err := client.Notifications.List(ctx, &NotificationListOptions{Page: -1})
+	if got := invalidFields(t, err); got != "Page" {
+		t.Errorf("invalid fields = %q", got)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("expected no requests, got %d", n)
+	}
+}
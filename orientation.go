@@ -0,0 +1,50 @@
+package fimage
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// AspectRatio returns the file's width divided by its height, or 0 when the
+// dimensions are unknown.
+func (f *File) AspectRatio() float64 {
+	if f.Width <= 0 || f.Height <= 0 {
+		return 0
+	}
+	return float64(f.Width) / float64(f.Height)
+}
+
+// Orientation returns the shape of the file, or "" when the dimensions are
+// unknown.
+func (f *File) Orientation() Orientation {
+	switch {
+	case f.Width <= 0 || f.Height <= 0:
+		return ""
+	case f.Width > f.Height:
+		return OrientationLandscape
+	case f.Width < f.Height:
+		return OrientationPortrait
+	}
+	return OrientationSquare
+}
+
+// shape records errors for invalid orientation and aspect ratio filters.
+func (v *validator) shape(orientation Orientation, minRatio, maxRatio float64) {
+	v.check(orientation == "" || orientation.Valid(), "Orientation", "has unsupported value %q", orientation)
+	v.check(minRatio >= 0, "MinAspectRatio", "must not be negative")
+	v.check(maxRatio >= 0, "MaxAspectRatio", "must not be negative")
+	v.check(maxRatio == 0 || minRatio <= maxRatio, "MaxAspectRatio", "must not be less than MinAspectRatio")
+}
+
+// setShapeQuery adds orientation and aspect ratio filters to query.
+func setShapeQuery(query url.Values, orientation Orientation, minRatio, maxRatio float64) {
+	if orientation != "" {
+		query.Set("orientation", string(orientation))
+	}
+	if minRatio > 0 {
+		query.Set("min_aspect_ratio", strconv.FormatFloat(minRatio, 'f', -1, 64))
+	}
+	if maxRatio > 0 {
+		query.Set("max_aspect_ratio", strconv.FormatFloat(maxRatio, 'f', -1, 64))
+	}
+}
@@ -0,0 +1,224 @@
+package fimage
+
+import "context"
+
+// page is one page of results fetched by an Iterator.
+type page[T any] struct {
+	items    []T
+	warnings []DecodeWarning
+	total    int64
+	limit    int
+}
+
+// Iterator walks every item of a paginated listing, fetching successive
+// pages on demand. Use it like bufio.Scanner:
+//
+//	it := client.Files.ListAll(ctx, nil)
+//	for it.Next() {
+//	    file := it.Value()
+//	    fmt.Println(file.OriginalName)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page int) (*page[T], error)
+
+	page     int
+	items    []T
+	index    int
+	seen     int64
+	total    int64
+	warnings []DecodeWarning
+	cur      T
+	last     bool
+	err      error
+}
+
+// newIterator returns an iterator starting at startPage (1 if zero).
+func newIterator[T any](ctx context.Context, startPage int, fetch func(ctx context.Context, page int) (*page[T], error)) *Iterator[T] {
+	if startPage <= 0 {
+		startPage = 1
+	}
+	return &Iterator[T]{ctx: ctx, fetch: fetch, page: startPage, total: -1}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false when the listing is exhausted or an error occurs; check Err
+// afterwards.
+func (it *Iterator[T]) Next() bool {
+	for it.index >= len(it.items) {
+		if it.last || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		p, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page++
+		it.items, it.index = p.items, 0
+		it.total = p.total
+		for _, w := range p.warnings {
+			w.Index += int(it.seen)
+			it.warnings = append(it.warnings, w)
+		}
+
+		// Records skipped by tolerant decoding still count towards the page,
+		// so a page that lost records is not mistaken for the last one.
+		records := len(p.items) + len(p.warnings)
+		it.seen += int64(records)
+
+		// Stop on an empty or short page, or once the reported total is
+		// reached, so no extra empty page is requested.
+		it.last = records == 0 ||
+			(p.limit > 0 && records < p.limit) ||
+			(p.total > 0 && it.seen >= p.total)
+	}
+
+	it.cur = it.items[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Warnings returns the records skipped by tolerant decoding (see
+// WithTolerantDecoding) on the pages fetched so far. Each Index is the
+// position of the record counted from the first item of the iteration.
+func (it *Iterator[T]) Warnings() []DecodeWarning {
+	return it.warnings
+}
+
+// Total returns the total number of items reported by the API, or -1
+// before the first page has been fetched.
+func (it *Iterator[T]) Total() int64 {
+	return it.total
+}
+
+// Collect drains the iterator and returns the remaining items.
+func (it *Iterator[T]) Collect() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// ListAll returns an iterator over every file matching opts, fetching pages
+// of opts.Limit files (default: the API default) starting at opts.Page.
+//
+// Example:
+//
+//	it := client.Files.ListAll(ctx, &fimage.ListOptions{Limit: 100})
+//	for it.Next() {
+//	    fmt.Println(it.Value().OriginalName)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (s *FilesService) ListAll(ctx context.Context, opts *ListOptions, callOpts ...CallOption) *Iterator[File] {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	var o ListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(ctx, o.Page, func(ctx context.Context, n int) (*page[File], error) {
+		o.Page = n
+		resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, err
+		}
+		return &page[File]{items: resp.Files, warnings: resp.Warnings, total: resp.Total, limit: resp.Limit}, nil
+	})
+}
+
+// ListAll returns an iterator over every share link.
+//
+// Example:
+//
+//	shares, err := client.Share.ListAll(ctx, nil).Collect()
+func (s *ShareService) ListAll(ctx context.Context, opts *ShareListOptions, callOpts ...CallOption) *Iterator[ShareLink] {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
+	var o ShareListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(ctx, o.Page, func(ctx context.Context, n int) (*page[ShareLink], error) {
+		o.Page = n
+		resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, err
+		}
+		return &page[ShareLink]{items: resp.Shares, warnings: resp.Warnings, total: resp.Total, limit: resp.Limit}, nil
+	})
+}
+
+// GetAllFiles returns an iterator over every file with a tag.
+//
+// Example:
+//
+//	it := client.Tags.GetAllFiles(ctx, 123, nil)
+//	for it.Next() {
+//	    fmt.Println(it.Value().OriginalName)
+//	}
+func (s *TagsService) GetAllFiles(ctx context.Context, tagID int64, opts *TagFilesOptions, callOpts ...CallOption) *Iterator[File] {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
+	var o TagFilesOptions
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(ctx, o.Page, func(ctx context.Context, n int) (*page[File], error) {
+		o.Page = n
+		resp, err := s.GetFiles(ctx, tagID, &o)
+		if err != nil {
+			return nil, err
+		}
+		return &page[File]{items: resp.Files, warnings: resp.Warnings, total: resp.Total, limit: resp.Limit}, nil
+	})
+}
+
+// ListAll returns an iterator over every file in the trash.
+//
+// Example:
+//
+//	it := client.Trash.ListAll(ctx, nil)
+//	for it.Next() {
+//	    file := it.Value()
+//	    fmt.Printf("%s (deleted %s)\n", file.OriginalName, file.GetDeletedAt())
+//	}
+func (s *TrashService) ListAll(ctx context.Context, opts *TrashListOptions, callOpts ...CallOption) *Iterator[File] {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
+	var o TrashListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return newIterator(ctx, o.Page, func(ctx context.Context, n int) (*page[File], error) {
+		o.Page = n
+		resp, err := s.List(ctx, &o)
+		if err != nil {
+			return nil, err
+		}
+		return &page[File]{items: resp.Files, warnings: resp.Warnings, total: resp.Total, limit: resp.Limit}, nil
+	})
+}
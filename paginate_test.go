@@ -0,0 +1,103 @@
+package fimage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIteratorCountsSkippedRecordsTowardsPage(t *testing.T) {
+	t.Parallel()
+
+	skipped := DecodeWarning{Index: 1, Err: errors.New("bad record")}
+	tests := []struct {
+		name    string
+		pages   []page[int]
+		fetches int
+		items   int
+	}{
+		{
+			// Two decoded items and a skipped one fill a page of three, so
+			// the iterator fetches the next page.
+			name: "full page with skipped record",
+			pages: []page[int]{
+				{items: []int{1, 3}, warnings: []DecodeWarning{skipped}, limit: 3},
+				{items: []int{4}, limit: 3},
+			},
+			fetches: 2,
+			items:   3,
+		},
+		{
+			// The skipped record counts towards the reported total, so no
+			// extra empty page is requested.
+			name: "total reached with skipped record",
+			pages: []page[int]{
+				{items: []int{1, 3}, warnings: []DecodeWarning{skipped}, total: 5, limit: 3},
+				{items: []int{4, 5}, total: 5, limit: 3},
+			},
+			fetches: 2,
+			items:   4,
+		},
+		{
+			name: "page of skipped records only",
+			pages: []page[int]{
+				{warnings: []DecodeWarning{{Index: 0}, {Index: 1}}, limit: 2},
+				{items: []int{3}, limit: 2},
+			},
+			fetches: 2,
+			items:   1,
+		},
+	}
+	for _, tt := range tests {
+		fetches := 0
+		it := newIterator(context.Background(), 0, func(ctx context.Context, n int) (*page[int], error) {
+			fetches++
+			if n > len(tt.pages) {
+				t.Errorf("%s: unexpected fetch of page %d", tt.name, n)
+				return &page[int]{}, nil
+			}
+			p := tt.pages[n-1]
+			return &p, nil
+		})
+		items, err := it.Collect()
+		if err != nil {
+			t.Fatalf("%s: Collect returned error: %v", tt.name, err)
+		}
+		if fetches != tt.fetches || len(items) != tt.items {
+			t.Errorf("%s: got %d items in %d fetches, want %d items in %d fetches", tt.name, len(items), fetches, tt.items, tt.fetches)
+		}
+	}
+}
+
+func TestIteratorWarningIndexesSpanPages(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"shares":[{"id":1},{"id":2}],"total":4,"page":1,"limit":2}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"shares":[{"id":"bad"},{"id":4}],"total":4,"page":2,"limit":2}`))
+		default:
+			t.Errorf("unexpected page: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithTolerantDecoding())
+
+	it := client.Share.ListAll(context.Background(), &ShareListOptions{Limit: 2})
+	shares, err := it.Collect()
+	if err != nil {
+		t.Fatalf("ListAll returned error: %v", err)
+	}
+	if len(shares) != 3 || shares[2].ID != 4 {
+		t.Fatalf("unexpected shares: %+v", shares)
+	}
+	if warnings := it.Warnings(); len(warnings) != 1 || warnings[0].Index != 2 {
+		t.Fatalf("unexpected warnings: %+v", warnings)
+	}
+}
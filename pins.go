@@ -0,0 +1,175 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PinsService handles pinned albums and files.
+type PinsService struct {
+	client *Client
+}
+
+// PinKind is the kind of item a pin points at.
+type PinKind string
+
+const (
+	// PinAlbum pins an album.
+	PinAlbum PinKind = "album"
+
+	// PinFile pins a file.
+	PinFile PinKind = "file"
+)
+
+// Valid reports whether k is a known pin kind.
+func (k PinKind) Valid() bool {
+	switch k {
+	case PinAlbum, PinFile:
+		return true
+	}
+	return false
+}
+
+// Pin is a user-pinned album or file.
+type Pin struct {
+	// ID is the pin ID, used by Unpin and Reorder.
+	ID int64 `json:"id"`
+
+	// Kind is the kind of pinned item.
+	Kind PinKind `json:"kind"`
+
+	// TargetID is the ID of the pinned album or file.
+	TargetID int64 `json:"target_id"`
+
+	// Position is the pin's place in the list, starting at 0.
+	Position int `json:"position"`
+
+	// Album is the pinned album when Kind is PinAlbum.
+	Album *Album `json:"album,omitempty"`
+
+	// File is the pinned file when Kind is PinFile.
+	File *File `json:"file,omitempty"`
+
+	// CreatedAt is when the item was pinned.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Pin pins an album or file. New pins are added to the end of the list;
+// pinning an item that is already pinned returns the existing pin.
+//
+// Example:
+//
+//	pin, err := client.Pins.Pin(ctx, fimage.PinAlbum, 42)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Pinned at position %d\n", pin.Position)
+func (s *PinsService) Pin(ctx context.Context, kind PinKind, id int64, callOpts ...CallOption) (*Pin, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePins, callOpts)
+
+	if !kind.Valid() {
+		return nil, fmt.Errorf("unsupported pin kind: %q", kind)
+	}
+
+	req := struct {
+		Kind     PinKind `json:"kind"`
+		TargetID int64   `json:"target_id"`
+	}{
+		Kind:     kind,
+		TargetID: id,
+	}
+
+	var pin Pin
+	if err := s.client.request(ctx, http.MethodPost, "/api/pins", req, &pin); err != nil {
+		return nil, err
+	}
+
+	return &pin, nil
+}
+
+// Unpin removes a pin. The pinned album or file is not affected.
+//
+// Example:
+//
+//	_, err := client.Pins.Unpin(ctx, pin.ID)
+func (s *PinsService) Unpin(ctx context.Context, pinID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePins, callOpts)
+
+	path := fmt.Sprintf("/api/pins/%d", pinID)
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodDelete, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// List returns all pins in display order, with the pinned albums and files
+// embedded.
+//
+// Example:
+//
+//	pins, err := client.Pins.List(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, pin := range pins {
+//	    switch pin.Kind {
+//	    case fimage.PinAlbum:
+//	        fmt.Println("Album:", pin.Album.Name)
+//	    case fimage.PinFile:
+//	        fmt.Println("File:", pin.File.OriginalName)
+//	    }
+//	}
+func (s *PinsService) List(ctx context.Context, callOpts ...CallOption) ([]Pin, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePins, callOpts)
+
+	var pins []Pin
+	if err := s.client.request(ctx, http.MethodGet, "/api/pins", nil, &pins); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+// Reorder sets the display order of pins. pinIDs must list every pin
+// exactly once, in the new order. It returns the reordered pins.
+//
+// Example:
+//
+//	// Move the last pin to the top
+//	ids := []int64{pins[len(pins)-1].ID}
+//	for _, p := range pins[:len(pins)-1] {
+//	    ids = append(ids, p.ID)
+//	}
+//	pins, err = client.Pins.Reorder(ctx, ids)
+func (s *PinsService) Reorder(ctx context.Context, pinIDs []int64, callOpts ...CallOption) ([]Pin, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePins, callOpts)
+
+	if len(pinIDs) == 0 {
+		return nil, fmt.Errorf("at least one pin ID is required")
+	}
+	seen := make(map[int64]bool, len(pinIDs))
+	for _, id := range pinIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate pin ID %d", id)
+		}
+		seen[id] = true
+	}
+
+	req := struct {
+		PinIDs []int64 `json:"pin_ids"`
+	}{
+		PinIDs: pinIDs,
+	}
+
+	var pins []Pin
+	if err := s.client.request(ctx, http.MethodPut, "/api/pins/order", req, &pins); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
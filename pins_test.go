@@ -0,0 +1,113 @@
+package fimage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPinsRequests(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		method string
+		path   string
+		body   string
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, call{method: r.Method, path: r.URL.Path, body: string(body)})
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"id":3,"kind":"album","target_id":42,"position":1,"album":{"id":42,"name":"Trips"},"created_at":"2024-04-01T08:00:00Z"}`))
+		case r.Method == http.MethodDelete:
+			_, _ = w.Write([]byte(`{"message":"Pin removed"}`))
+		default:
+			_, _ = w.Write([]byte(`[
+				{"id":4,"kind":"file","target_id":7,"position":0,"file":{"id":7,"original_name":"cover.jpg"}},
+				{"id":3,"kind":"album","target_id":42,"position":1,"album":{"id":42,"name":"Trips"}}
+			]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	pin, err := client.Pins.Pin(ctx, PinAlbum, 42)
+	if err != nil {
+		t.Fatalf("Pin returned error: %v", err)
+	}
+	if pin.ID != 3 || pin.Kind != PinAlbum || pin.Position != 1 || pin.Album == nil || pin.Album.Name != "Trips" || pin.CreatedAt.IsZero() {
+		t.Fatalf("unexpected pin: %+v", pin)
+	}
+
+	pins, err := client.Pins.List(ctx)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(pins) != 2 || pins[0].Kind != PinFile || pins[0].File == nil || pins[0].File.OriginalName != "cover.jpg" {
+		t.Fatalf("unexpected pins: %+v", pins)
+	}
+
+	if pins, err = client.Pins.Reorder(ctx, []int64{4, 3}); err != nil {
+		t.Fatalf("Reorder returned error: %v", err)
+	}
+	if len(pins) != 2 || pins[1].ID != 3 {
+		t.Fatalf("unexpected reordered pins: %+v", pins)
+	}
+
+	resp, err := client.Pins.Unpin(ctx, 3)
+	if err != nil {
+		t.Fatalf("Unpin returned error: %v", err)
+	}
+	if resp.Message != "Pin removed" {
+		t.Fatalf("unexpected message: %q", resp.Message)
+	}
+
+	want := []call{
+		{http.MethodPost, "/api/pins", `{"kind":"album","target_id":42}`},
+		{http.MethodGet, "/api/pins", ``},
+		{http.MethodPut, "/api/pins/order", `{"pin_ids":[4,3]}`},
+		{http.MethodDelete, "/api/pins/3", ``},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %d requests, got %d", len(want), len(calls))
+	}
+	for i, w := range want {
+		if calls[i] != w {
+			t.Errorf("request %d: got %+v, want %+v", i, calls[i], w)
+		}
+	}
+}
+
+func TestPinsValidateArguments(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	if _, err := client.Pins.Pin(ctx, "tag", 1); err == nil {
+		t.Error("expected error for unsupported pin kind")
+	}
+	if _, err := client.Pins.Reorder(ctx, nil); err == nil {
+		t.Error("expected error for empty pin IDs")
+	}
+	if _, err := client.Pins.Reorder(ctx, []int64{1, 2, 1}); err == nil {
+		t.Error("expected error for duplicate pin IDs")
+	}
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("expected no requests, got %d", n)
+	}
+}
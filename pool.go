@@ -0,0 +1,87 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// DefaultConcurrency is the default number of concurrent requests used by
+// batch operations.
+const DefaultConcurrency = 4
+
+// PanicError reports a panic recovered while a batch operation processed one
+// of its items. The batch continues with the remaining items.
+type PanicError struct {
+	// Index is the position of the item that panicked.
+	Index int
+
+	// Value is the value passed to panic.
+	Value interface{}
+
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic processing item %d: %v", e.Index, e.Value)
+}
+
+// forEach calls fn for every index in [0, n) using at most concurrency
+// workers and returns one error per item. A panic in fn is recovered and
+// reported as a *PanicError for that item only. Items not started before ctx
+// is done report ctx.Err().
+func forEach(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	if n == 0 {
+		return errs
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = safeCall(ctx, i, fn)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			for ; i < n; i++ {
+				errs[i] = err
+			}
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
+
+// safeCall calls fn for item i, converting a panic into a *PanicError.
+func safeCall(ctx context.Context, i int, fn func(ctx context.Context, i int) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Index: i, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx, i)
+}
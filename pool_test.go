@@ -0,0 +1,35 @@
+package fimage
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestForEachRecoversPanicsPerItem(t *testing.T) {
+	t.Parallel()
+
+	errBad := errors.New("bad item")
+	errs := forEach(context.Background(), 5, 2, func(ctx context.Context, i int) error {
+		switch i {
+		case 1:
+			panic("boom")
+		case 3:
+			return errBad
+		}
+		return nil
+	})
+
+	var panicErr *PanicError
+	if !errors.As(errs[1], &panicErr) || panicErr.Index != 1 || panicErr.Value != "boom" {
+		t.Fatalf("expected PanicError for item 1, got %v", errs[1])
+	}
+	if !errors.Is(errs[3], errBad) {
+		t.Fatalf("expected errBad for item 3, got %v", errs[3])
+	}
+	for _, i := range []int{0, 2, 4} {
+		if errs[i] != nil {
+			t.Fatalf("item %d: unexpected error %v", i, errs[i])
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxPresetNameLength is the longest preset name accepted by the API.
+const maxPresetNameLength = 64
+
+// PresetsService handles named transform presets.
+type PresetsService struct {
+	client *Client
+}
+
+// TransformPreset is a named, server-stored transform that can be referenced
+// from transformation URLs with Preset.
+type TransformPreset struct {
+	// Name is the unique preset name.
+	Name string `json:"name"`
+
+	// Transform is the transformation the preset applies.
+	Transform Transform `json:"transform"`
+
+	// CreatedAt is the preset creation timestamp.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the last update timestamp.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// validPresetName reports whether name may be used as a preset name.
+// Names may contain letters, digits, underscores, and dashes.
+func validPresetName(name string) bool {
+	if name == "" || len(name) > maxPresetNameLength {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// List returns all transform presets.
+//
+// Example:
+//
+//	presets, err := client.Presets.List(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, p := range presets {
+//	    fmt.Printf("%s: %dx%d\n", p.Name, p.Transform.Width, p.Transform.Height)
+//	}
+func (s *PresetsService) List(ctx context.Context, callOpts ...CallOption) ([]TransformPreset, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePresets, callOpts)
+
+	var presets []TransformPreset
+	if err := s.client.featureRequest(ctx, FeatureTransforms, http.MethodGet, "/api/transform-presets", nil, &presets); err != nil {
+		return nil, err
+	}
+
+	return presets, nil
+}
+
+// Create stores a named transform preset. Creating a preset with an existing
+// name replaces it.
+//
+// Example:
+//
+//	preset, err := client.Presets.Create(ctx, "hero", fimage.Transform{
+//	    Width:  1600,
+//	    Height: 900,
+//	    Fit:    fimage.FitCover,
+//	    Format: "webp",
+//	})
+func (s *PresetsService) Create(ctx context.Context, name string, transform Transform, callOpts ...CallOption) (*TransformPreset, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePresets, callOpts)
+
+	if !validPresetName(name) {
+		return nil, fmt.Errorf("invalid preset name %q", name)
+	}
+	if err := transform.Validate(); err != nil {
+		return nil, err
+	}
+
+	req := struct {
+		Name      string    `json:"name"`
+		Transform Transform `json:"transform"`
+	}{
+		Name:      name,
+		Transform: transform,
+	}
+
+	var preset TransformPreset
+	if err := s.client.featureRequest(ctx, FeatureTransforms, http.MethodPost, "/api/transform-presets", req, &preset); err != nil {
+		return nil, err
+	}
+
+	return &preset, nil
+}
+
+// Delete deletes a transform preset. URLs that reference it stop resolving.
+//
+// Example:
+//
+//	_, err := client.Presets.Delete(ctx, "hero")
+func (s *PresetsService) Delete(ctx context.Context, name string, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServicePresets, callOpts)
+
+	if !validPresetName(name) {
+		return nil, fmt.Errorf("invalid preset name %q", name)
+	}
+
+	path := "/api/transform-presets/" + url.PathEscape(name)
+
+	var resp MessageResponse
+	if err := s.client.featureRequest(ctx, FeatureTransforms, http.MethodDelete, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,138 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate limit response headers.
+const (
+	// RateLimitLimitHeader is the number of requests allowed per window.
+	RateLimitLimitHeader = "X-RateLimit-Limit"
+
+	// RateLimitRemainingHeader is the number of requests left in the
+	// current window.
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+	// RateLimitResetHeader is when the current window resets, in Unix
+	// seconds.
+	RateLimitResetHeader = "X-RateLimit-Reset"
+)
+
+// maxRateLimitRetries is how many times a request rejected with 429 is
+// retried when WithRateLimitWait is enabled.
+const maxRateLimitRetries = 3
+
+// RateLimit is the server-side rate limit state reported with a response.
+type RateLimit struct {
+	// Limit is the number of requests allowed per window, or 0 if the
+	// server did not report it.
+	Limit int
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+
+	// Reset is when the current window resets.
+	Reset time.Time
+}
+
+// parseRateLimit reads the rate limit headers of a response. It returns nil
+// when the server did not send them.
+func parseRateLimit(header http.Header) *RateLimit {
+	remaining, err := strconv.Atoi(strings.TrimSpace(header.Get(RateLimitRemainingHeader)))
+	if err != nil {
+		return nil
+	}
+
+	rl := &RateLimit{Remaining: remaining}
+	if limit, err := strconv.Atoi(strings.TrimSpace(header.Get(RateLimitLimitHeader))); err == nil {
+		rl.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(strings.TrimSpace(header.Get(RateLimitResetHeader)), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0)
+	}
+	return rl
+}
+
+// WithRateLimitWait makes the client wait for the rate limit window to reset
+// instead of failing. When the last response reported no remaining requests,
+// new requests wait until the reset time; JSON requests rejected with 429
+// are retried after the reset, up to three times. Uploads and downloads are
+// not retried, since their bodies cannot be replayed.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token", fimage.WithRateLimitWait(true))
+func WithRateLimitWait(wait bool) ClientOption {
+	return func(c *Client) {
+		c.rateLimitWait = wait
+	}
+}
+
+// RateLimit returns the rate limit state reported with the most recent
+// response, or nil if no response has carried rate limit headers yet.
+//
+// Example:
+//
+//	if rl := client.RateLimit(); rl != nil && rl.Remaining < 10 {
+//	    log.Printf("only %d requests left until %s", rl.Remaining, rl.Reset)
+//	}
+func (c *Client) RateLimit() *RateLimit {
+	rl := c.rateLimit.Load()
+	if rl == nil {
+		return nil
+	}
+	cp := *rl
+	return &cp
+}
+
+// recordRateLimit stores the rate limit state reported with resp.
+func (c *Client) recordRateLimit(resp *http.Response) {
+	if rl := parseRateLimit(resp.Header); rl != nil {
+		c.rateLimit.Store(rl)
+	}
+}
+
+// waitRateLimit blocks until the rate limit window resets if
+// WithRateLimitWait is enabled and the last response reported that no
+// requests remain.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	if !c.rateLimitWait {
+		return nil
+	}
+	rl := c.rateLimit.Load()
+	if rl == nil || rl.Remaining > 0 || rl.Reset.IsZero() {
+		return nil
+	}
+	if wait := time.Until(rl.Reset); wait > 0 {
+		return sleepCtx(ctx, wait)
+	}
+	return nil
+}
+
+// rateLimitBackoff reports how long to wait before retrying a request that
+// failed with err, and whether to retry at all.
+func (c *Client) rateLimitBackoff(err error, attempt int) (time.Duration, bool) {
+	if !c.rateLimitWait || attempt >= maxRateLimitRetries {
+		return 0, false
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	// Without a Retry-After or reset time, back off for a second.
+	if apiErr.RetryAfter <= 0 && (apiErr.RateLimit == nil || apiErr.RateLimit.Reset.IsZero()) {
+		return time.Second, true
+	}
+	wait := apiErr.RetryAfter
+	if apiErr.RateLimit != nil {
+		if d := time.Until(apiErr.RateLimit.Reset); d > wait {
+			wait = d
+		}
+	}
+	return wait, true
+}
@@ -0,0 +1,54 @@
+package fimage
+
+import "context"
+
+// RequestIDHeader is the header used to correlate requests across services.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key for request IDs.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying a request ID. The client sends
+// it as the X-Request-ID header on every request made with the context, so
+// traces line up across your services and F-Image support tickets. Without
+// it, the client generates a new ID per call, which its retries reuse.
+//
+// Example:
+//
+//	ctx = fimage.WithRequestID(ctx, r.Header.Get("X-Request-ID"))
+//	_, err := client.Files.List(ctx, nil)
+//	var apiErr *fimage.APIError
+//	if errors.As(err, &apiErr) {
+//	    log.Printf("request %s failed: %v", apiErr.RequestID, err)
+//	}
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// withCallRequestID stores the request ID of a call in ctx, so every
+// attempt, including retries, sends the same X-Request-ID.
+func withCallRequestID(ctx context.Context) context.Context {
+	return WithRequestID(ctx, requestIDFor(ctx))
+}
+
+// requestIDFor returns the request ID from the call options or ctx,
+// generating one if absent.
+func requestIDFor(ctx context.Context) string {
+	if id := requestOptionsFrom(ctx).requestID; id != "" {
+		return id
+	}
+	if id, ok := RequestIDFromContext(ctx); ok {
+		return id
+	}
+	id, err := newClientID()
+	if err != nil {
+		return ""
+	}
+	return id
+}
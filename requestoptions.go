@@ -0,0 +1,167 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// IdempotencyKeyHeader is the header that lets the server recognize a
+// retried request and return the original result instead of repeating it.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// CallOption configures a single API call. Pass options as the trailing
+// arguments of a service method, or attach them to a context with
+// WithRequestOptions to apply them to every call made with it.
+//
+// Example:
+//
+//	file, err := client.Files.Get(ctx, 123,
+//	    fimage.WithCallTimeout(5*time.Second),
+//	    fimage.WithHeader("X-Tenant-ID", "acme"),
+//	)
+type CallOption func(*requestOptions)
+
+// requestOptions holds the per-call settings collected from the context.
+type requestOptions struct {
+	rawResponse *[]byte
+	response    *Response
+	header      http.Header
+	timeout     time.Duration
+	retries     int
+	pageSize    int
+	requestID   string
+}
+
+// requestOptionsKey is the context key for request options.
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx carrying opts. They apply to every
+// API call made with the context, after any options already attached to it.
+//
+// Example:
+//
+//	var raw []byte
+//	ctx := fimage.WithRequestOptions(ctx, fimage.WithRawResponse(&raw))
+//	file, err := client.Files.Get(ctx, 123)
+//	auditLog.Write(raw)
+func WithRequestOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if prev, ok := ctx.Value(requestOptionsKey{}).([]CallOption); ok {
+		opts = append(append([]CallOption(nil), prev...), opts...)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, opts)
+}
+
+// withCallOptions attaches the trailing options of a service method to ctx.
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	return WithRequestOptions(ctx, opts...)
+}
+
+// WithRawResponse stores the exact response body in *dst while the response
+// is still decoded into the method's typed result. Error response bodies are
+// captured too. When a method makes several API calls, *dst holds the body of
+// the last one.
+func WithRawResponse(dst *[]byte) CallOption {
+	return func(o *requestOptions) {
+		o.rawResponse = dst
+	}
+}
+
+// WithHeader sets a header on every request of the call, including
+// multipart uploads, e.g. for gateways that require a tenant or cost-center
+// header. It overrides headers from WithDefaultHeaders with the same key.
+//
+// Example:
+//
+//	resp, err := client.Files.Upload(ctx, f, opts, fimage.WithHeader("X-Cost-Center", "marketing"))
+func WithHeader(key, value string) CallOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sends key as the Idempotency-Key header, so that
+// retrying a create or upload after a network failure does not perform it
+// twice. Use a new key for every logical operation.
+//
+// Example:
+//
+//	album, err := client.Albums.Create(ctx, opts, fimage.WithIdempotencyKey(orderID))
+func WithIdempotencyKey(key string) CallOption {
+	return WithHeader(IdempotencyKeyHeader, key)
+}
+
+// WithCallTimeout bounds the whole call independently of the client's
+// timeout: every request a method makes, such as the album settings lookup
+// and attribute update around an upload or the pages of an iterator,
+// including waiting for the rate limiter, every attempt and the backoff
+// between retries, and reading a streamed response body. A value of 0
+// leaves the call unbounded beyond the context and the client timeout.
+//
+// Example:
+//
+//	file, err := client.Files.Get(ctx, 123, fimage.WithCallTimeout(2*time.Second))
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithTraceID sends id as the X-Request-ID header of the call, like
+// WithRequestID does for a context.
+//
+// Example:
+//
+//	_, err := client.Files.Delete(ctx, 123, fimage.WithTraceID(span.TraceID()))
+func WithTraceID(id string) CallOption {
+	return func(o *requestOptions) {
+		o.requestID = id
+	}
+}
+
+// callDeadlineKey is the context key for the deadline of a call with a
+// timeout.
+type callDeadlineKey struct{}
+
+// withCallDeadline fixes the deadline of a call from its timeout, so that
+// every request the call makes shares one deadline instead of each getting
+// the full timeout. A deadline already fixed by an enclosing call is kept.
+func withCallDeadline(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(callDeadlineKey{}).(time.Time); ok {
+		return ctx
+	}
+	timeout := requestOptionsFrom(ctx).timeout
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, callDeadlineKey{}, time.Now().Add(timeout))
+}
+
+// requestOptionsFrom returns the options attached to ctx.
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	var o requestOptions
+	if opts, ok := ctx.Value(requestOptionsKey{}).([]CallOption); ok {
+		for _, opt := range opts {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// captureResponse applies the response-related options in ctx to resp and
+// its body. body is nil for streamed responses.
+func captureResponse(ctx context.Context, resp *http.Response, body []byte) {
+	o := requestOptionsFrom(ctx)
+	if o.rawResponse != nil && body != nil {
+		*o.rawResponse = body
+	}
+	if o.response != nil {
+		*o.response = newResponse(resp)
+	}
+}
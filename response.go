@@ -0,0 +1,61 @@
+package fimage
+
+import (
+	"net/http"
+	"time"
+)
+
+// Response holds the HTTP response metadata of an API call, so layers that
+// proxy F-Image content can pass correct caching semantics downstream.
+// Populate it with WithResponse.
+type Response struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+
+	// CacheControl is the Cache-Control header, e.g. "public, max-age=3600".
+	CacheControl string
+
+	// ETag is the entity tag of the response, including quotes and any W/
+	// prefix, for use in If-None-Match.
+	ETag string
+
+	// LastModified is the Last-Modified header, or the zero time if the
+	// server did not send a valid one.
+	LastModified time.Time
+
+	// RequestID is the X-Request-ID of the response.
+	RequestID string
+}
+
+// WithResponse stores the response metadata of the call in *dst. Error
+// responses are captured too. When a method makes several API calls, *dst
+// describes the last one.
+//
+// Example:
+//
+//	var resp fimage.Response
+//	file, err := client.Files.Get(ctx, 123, fimage.WithResponse(&resp))
+//	if err != nil {
+//	    return err
+//	}
+//	w.Header().Set("Cache-Control", resp.CacheControl)
+//	w.Header().Set("ETag", resp.ETag)
+func WithResponse(dst *Response) CallOption {
+	return func(o *requestOptions) {
+		o.response = dst
+	}
+}
+
+// newResponse extracts the exposed metadata of resp.
+func newResponse(resp *http.Response) Response {
+	r := Response{
+		StatusCode:   resp.StatusCode,
+		CacheControl: resp.Header.Get("Cache-Control"),
+		ETag:         resp.Header.Get("ETag"),
+		RequestID:    resp.Header.Get(RequestIDHeader),
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		r.LastModified = lastModified
+	}
+	return r
+}
@@ -0,0 +1,227 @@
+package fimage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// EncryptionService handles maintenance of client-encrypted files.
+type EncryptionService struct {
+	client *Client
+}
+
+// RotationScope selects the files a key rotation covers and how it runs.
+type RotationScope struct {
+	// AlbumID limits the rotation to an album. Nil rotates every file
+	// encrypted with the old key.
+	AlbumID *int64
+
+	// Concurrency is the number of files rotated in parallel
+	// (default: DefaultConcurrency).
+	Concurrency int
+
+	// OnProgress, if set, is called after each file is processed.
+	// Calls are serialized.
+	OnProgress func(RotationProgress)
+}
+
+// RotationProgress reports the progress of a key rotation.
+type RotationProgress struct {
+	// FileID is the file that was just processed.
+	FileID int64
+
+	// Done is the number of files processed so far, including failures.
+	Done int
+
+	// Total is the number of files the rotation covers.
+	Total int
+
+	// Err is the error for FileID, or nil if it was rotated.
+	Err error
+}
+
+// RotationFailure describes a file that could not be rotated.
+type RotationFailure struct {
+	// FileID is the ID of the file.
+	FileID int64
+
+	// Err is why the file could not be rotated.
+	Err error
+}
+
+// RotationResult summarizes a key rotation.
+type RotationResult struct {
+	// Total is the number of files that were encrypted with the old key.
+	Total int
+
+	// Rotated is the number of files now encrypted with the new key.
+	Rotated int
+
+	// Failed lists the files still encrypted with the old key.
+	Failed []RotationFailure
+}
+
+// Rotate re-encrypts files from oldKey to newKey: each file is downloaded,
+// decrypted, encrypted with the new key, and replaced in place, keeping its
+// ID. Files are found by the key ID recorded in their attributes, so rotated
+// files drop out of later runs; if a rotation is interrupted or some files
+// fail, call Rotate again with the same keys to resume.
+//
+// Example:
+//
+//	result, err := client.Encryption.Rotate(ctx, oldKey, newKey, &fimage.RotationScope{
+//	    Concurrency: 8,
+//	    OnProgress: func(p fimage.RotationProgress) {
+//	        fmt.Printf("\r%d/%d", p.Done, p.Total)
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("\nrotated %d, failed %d\n", result.Rotated, len(result.Failed))
+func (s *EncryptionService) Rotate(ctx context.Context, oldKey, newKey []byte, scope *RotationScope, callOpts ...CallOption) (*RotationResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceEncryption, callOpts)
+
+	if _, err := newGCM(oldKey); err != nil {
+		return nil, fmt.Errorf("invalid old key: %w", err)
+	}
+	if _, err := newGCM(newKey); err != nil {
+		return nil, fmt.Errorf("invalid new key: %w", err)
+	}
+	if bytes.Equal(oldKey, newKey) {
+		return nil, fmt.Errorf("old and new keys are identical")
+	}
+	if scope == nil {
+		scope = &RotationScope{}
+	}
+
+	files, err := s.encryptedWith(ctx, KeyID(oldKey), scope.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RotationResult{Total: len(files)}
+
+	var mu sync.Mutex
+	done := 0
+	errs := forEach(ctx, len(files), scope.Concurrency, func(ctx context.Context, i int) error {
+		err := s.rotateFile(ctx, &files[i], oldKey, newKey)
+
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if scope.OnProgress != nil {
+			scope.OnProgress(RotationProgress{FileID: files[i].ID, Done: done, Total: len(files), Err: err})
+		}
+		return err
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			result.Failed = append(result.Failed, RotationFailure{FileID: files[i].ID, Err: err})
+			continue
+		}
+		result.Rotated++
+	}
+
+	return result, nil
+}
+
+// encryptedWith returns every file whose key ID attribute equals keyID.
+func (s *EncryptionService) encryptedWith(ctx context.Context, keyID string, albumID *int64) ([]File, error) {
+	var files []File
+	for page := 1; ; page++ {
+		resp, err := s.client.Files.SearchByAttribute(ctx, AttrEncryptionKeyID, keyID, &AttributeSearchOptions{
+			Page:    page,
+			Limit:   maxPageLimit,
+			Sort:    SortCreatedAsc,
+			AlbumID: albumID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list encrypted files: %w", err)
+		}
+		files = append(files, resp.Files...)
+		if len(resp.Files) < maxPageLimit {
+			return files, nil
+		}
+	}
+}
+
+// rotateFile re-encrypts a single file. A file whose content was already
+// replaced by an interrupted rotation only has its attributes updated.
+func (s *EncryptionService) rotateFile(ctx context.Context, file *File, oldKey, newKey []byte) error {
+	data, err := s.client.download(ctx, file.URL)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := decrypt(oldKey, data)
+	if err != nil {
+		if _, newErr := decrypt(newKey, data); newErr != nil {
+			return err
+		}
+	} else {
+		ciphertext, err := encrypt(newKey, plaintext)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt file: %w", err)
+		}
+
+		filename := file.OriginalName
+		if filename == "" {
+			filename = "image.jpg"
+		}
+
+		path := fmt.Sprintf("/api/files/%d/replace", file.ID)
+		if _, err := s.client.uploadMultipart(ctx, path, bytes.NewReader(ciphertext), filename, nil, int64(len(ciphertext))); err != nil {
+			return fmt.Errorf("failed to replace file content: %w", err)
+		}
+	}
+
+	_, err = s.client.Files.SetAttributes(ctx, file.ID, map[string]string{
+		AttrEncryptionAlgorithm: EncryptionAlgorithm,
+		AttrEncryptionKeyID:     KeyID(newKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update encryption attributes: %w", err)
+	}
+	return nil
+}
+
+// download fetches the bytes behind a public file URL.
+func (c *Client) download(ctx context.Context, rawURL string) ([]byte, error) {
+	if rawURL == "" {
+		return nil, errors.New("file has no URL")
+	}
+
+	ctx, end, err := c.life.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer end()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download: %w", err)
+	}
+	return data, nil
+}
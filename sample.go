@@ -0,0 +1,85 @@
+package fimage
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SampleOptions contains options for sampling random files.
+type SampleOptions struct {
+	// Count is the number of files to return (default: 1, max 100).
+	Count int
+
+	// AlbumID limits the sample to an album.
+	AlbumID *int64
+
+	// TagIDs limits the sample to files with at least one of the tags.
+	TagIDs []int64
+}
+
+// Validate checks the options for invalid fields.
+func (opts *SampleOptions) Validate() error {
+	var v validator
+	v.check(opts.Count >= 0 && opts.Count <= maxPageLimit, "Count", "must be between 0 and %d", maxPageLimit)
+	return v.err()
+}
+
+// Sample returns a random selection of files, chosen by the server without
+// listing the whole library. Each call returns a new selection, and the
+// result has fewer than Count files when fewer match.
+//
+// Example:
+//
+//	// Rotating hero image
+//	files, err := client.Files.Sample(ctx, &fimage.SampleOptions{
+//	    AlbumID: &heroAlbumID,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if len(files) > 0 {
+//	    fmt.Println(files[0].BestURL(fimage.VariantMedium))
+//	}
+func (s *FilesService) Sample(ctx context.Context, opts *SampleOptions, callOpts ...CallOption) ([]File, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		opts = &SampleOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	count := opts.Count
+	if count == 0 {
+		count = 1
+	}
+	query.Set("count", strconv.Itoa(count))
+	if opts.AlbumID != nil {
+		query.Set("album_id", strconv.FormatInt(*opts.AlbumID, 10))
+	}
+	if len(opts.TagIDs) > 0 {
+		query.Set("tag_ids", joinIDs(opts.TagIDs))
+	}
+
+	var resp struct {
+		Files []File `json:"files"`
+	}
+	if err := s.client.requestWithQuery(ctx, "/api/files/sample", query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Files, nil
+}
+
+// joinIDs formats IDs as a comma-separated list for query parameters.
+func joinIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
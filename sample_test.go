@@ -0,0 +1,47 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSampleDefaultsCountAndJoinsTagIDs(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/files/sample" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":7}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	files, err := client.Files.Sample(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Sample returned error: %v", err)
+	}
+	if len(files) != 1 || files[0].ID != 7 {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+
+	albumID := int64(3)
+	if _, err := client.Files.Sample(context.Background(), &SampleOptions{Count: 5, AlbumID: &albumID, TagIDs: []int64{1, 22, 333}}); err != nil {
+		t.Fatalf("Sample returned error: %v", err)
+	}
+
+	want := []string{"count=1", "album_id=3&count=5&tag_ids=1%2C22%2C333"}
+	if len(queries) != 2 || queries[0] != want[0] || queries[1] != want[1] {
+		t.Fatalf("unexpected queries: %q, want %q", queries, want)
+	}
+
+	if _, err := client.Files.Sample(context.Background(), &SampleOptions{Count: maxPageLimit + 1}); err == nil {
+		t.Fatal("expected an error for a count over the limit")
+	}
+}
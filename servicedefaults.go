@@ -0,0 +1,176 @@
+package fimage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Service identifies a service of the client for WithServiceDefaults.
+type Service string
+
+// Services of the client.
+const (
+	ServiceFiles         Service = "files"
+	ServiceLogos         Service = "logos"
+	ServiceAlbums        Service = "albums"
+	ServiceShare         Service = "share"
+	ServiceTags          Service = "tags"
+	ServiceTrash         Service = "trash"
+	ServiceJobs          Service = "jobs"
+	ServiceAccount       Service = "account"
+	ServiceGallery       Service = "gallery"
+	ServiceTransforms    Service = "transforms"
+	ServicePresets       Service = "presets"
+	ServiceEncryption    Service = "encryption"
+	ServiceAdmin         Service = "admin"
+	ServicePins          Service = "pins"
+	ServiceNotifications Service = "notifications"
+)
+
+// maxRetryBackoff caps the wait between retries of a failed request.
+const maxRetryBackoff = 5 * time.Second
+
+// Defaults are the default settings of every call to a service. Zero fields
+// keep the client-wide behavior, and options passed to a call override them.
+type Defaults struct {
+	// Timeout bounds each call, including every request it makes and
+	// their retries, as WithCallTimeout does.
+	Timeout time.Duration
+
+	// Retries is how many times a request is retried after a network error
+	// or a 429 or 5xx response, as WithRetries does.
+	Retries int
+
+	// PageSize is the number of items per page of list methods when the
+	// options leave Limit unset, as WithPageSize does.
+	PageSize int
+}
+
+// option returns the call option applying d.
+func (d Defaults) option() CallOption {
+	return func(o *requestOptions) {
+		if d.Timeout > 0 {
+			o.timeout = d.Timeout
+		}
+		if d.Retries > 0 {
+			o.retries = d.Retries
+		}
+		if d.PageSize > 0 {
+			o.pageSize = d.PageSize
+		}
+	}
+}
+
+// WithServiceDefaults sets the defaults of every call to a service, e.g. to
+// give uploads long timeouts and listings short ones. Repeated use for the
+// same service replaces its defaults.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token",
+//	    fimage.WithServiceDefaults(fimage.ServiceFiles, fimage.Defaults{
+//	        Timeout:  5 * time.Minute,
+//	        Retries:  2,
+//	        PageSize: 100,
+//	    }),
+//	    fimage.WithServiceDefaults(fimage.ServiceAlbums, fimage.Defaults{
+//	        Timeout: 5 * time.Second,
+//	    }),
+//	)
+func WithServiceDefaults(service Service, defaults Defaults) ClientOption {
+	return func(c *Client) {
+		if c.serviceDefaults == nil {
+			c.serviceDefaults = map[Service]Defaults{}
+		}
+		c.serviceDefaults[service] = defaults
+	}
+}
+
+// WithRetries retries each request of the call up to n times after a
+// network error or a 429 or 5xx response, waiting for the server's
+// Retry-After or with exponential backoff. Only GET, HEAD, PUT, and DELETE
+// requests, and requests with an idempotency key, are retried, since
+// repeating other requests could apply them twice. Uploads are never
+// retried, and retries share the call's timeout.
+//
+// Example:
+//
+//	file, err := client.Files.Get(ctx, 123, fimage.WithRetries(3))
+func WithRetries(n int) CallOption {
+	return func(o *requestOptions) {
+		o.retries = n
+	}
+}
+
+// WithPageSize sets the number of items per page of list methods when
+// their options leave Limit unset. It is capped at 100.
+//
+// Example:
+//
+//	resp, err := client.Files.List(ctx, nil, fimage.WithPageSize(50))
+func WithPageSize(n int) CallOption {
+	return func(o *requestOptions) {
+		o.pageSize = n
+	}
+}
+
+// withCallOptions attaches the defaults of service and the trailing options
+// of a service method to ctx, and fixes the deadline of the call. Options
+// already attached to ctx, and then the call's options, take precedence over
+// the service defaults.
+func (c *Client) withCallOptions(ctx context.Context, service Service, opts []CallOption) context.Context {
+	defaults, ok := c.serviceDefaults[service]
+	if !ok {
+		return withCallDeadline(withCallOptions(ctx, opts))
+	}
+	prev, _ := ctx.Value(requestOptionsKey{}).([]CallOption)
+	all := append([]CallOption{defaults.option()}, prev...)
+	return withCallDeadline(context.WithValue(ctx, requestOptionsKey{}, append(all, opts...)))
+}
+
+// setDefaultLimit sets the page size of a list query from the call options
+// when the query has none.
+func setDefaultLimit(ctx context.Context, query url.Values) {
+	if query.Has("limit") {
+		return
+	}
+	if n := requestOptionsFrom(ctx).pageSize; n > 0 {
+		query.Set("limit", strconv.Itoa(min(n, maxPageLimit)))
+	}
+}
+
+// retryBackoff reports how long to wait before retrying a request that
+// failed with err, and whether to retry at all under the call options.
+func retryBackoff(ctx context.Context, method string, err error, attempt int) (time.Duration, bool) {
+	o := requestOptionsFrom(ctx)
+	if attempt >= o.retries || ctx.Err() != nil {
+		return 0, false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		if o.header.Get(IdempotencyKeyHeader) == "" {
+			return 0, false
+		}
+	}
+
+	var apiErr *APIError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.StatusCode != http.StatusTooManyRequests && apiErr.StatusCode < 500 {
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			return min(apiErr.RetryAfter, maxRetryBackoff), true
+		}
+	case !errors.As(err, &urlErr):
+		return 0, false
+	}
+
+	return min(250*time.Millisecond<<attempt, maxRetryBackoff), true
+}
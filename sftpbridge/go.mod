@@ -0,0 +1,16 @@
+module github.com/lpg-it/f-image-go/sftpbridge
+
+go 1.21
+
+require (
+	github.com/lpg-it/f-image-go v1.0.3
+	github.com/pkg/sftp v1.13.6
+	golang.org/x/crypto v0.21.0
+)
+
+require (
+	github.com/kr/fs v0.1.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+)
+
+replace github.com/lpg-it/f-image-go => ../
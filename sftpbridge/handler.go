@@ -0,0 +1,140 @@
+package sftpbridge
+
+import (
+	"io"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+)
+
+// handler implements the sftp request handlers for one session.
+type handler struct {
+	server *Server
+	user   string
+
+	mu   sync.Mutex
+	dirs map[string]bool
+}
+
+// Fileread rejects reads; the bridge is write-only.
+func (h *handler) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	return nil, sftp.ErrSSHFxPermissionDenied
+}
+
+// Filewrite buffers an incoming file to disk and uploads it when the client
+// closes it.
+func (h *handler) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	filePath := cleanPath(r.Filepath)
+	if !h.server.accepts(filePath) {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	tmp, err := os.CreateTemp("", "sftpbridge-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingUpload{
+		file:    tmp,
+		path:    filePath,
+		handler: h,
+	}, nil
+}
+
+// Filecmd allows directory creation and rejects every other command.
+func (h *handler) Filecmd(r *sftp.Request) error {
+	switch r.Method {
+	case "Mkdir":
+		h.mu.Lock()
+		h.dirs[cleanPath(r.Filepath)] = true
+		h.mu.Unlock()
+		return nil
+	case "Setstat":
+		return nil
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+// Filelist reports known directories; listings are always empty.
+func (h *handler) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	filePath := cleanPath(r.Filepath)
+
+	switch r.Method {
+	case "List":
+		return listerAt(nil), nil
+	case "Stat", "Lstat":
+		if !h.isDir(filePath) {
+			return nil, sftp.ErrSSHFxNoSuchFile
+		}
+		return listerAt{dirInfo(path.Base(filePath))}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+// isDir reports whether filePath is the root, a created directory, or a
+// rule directory (or one of its parents).
+func (h *handler) isDir(filePath string) bool {
+	h.mu.Lock()
+	known := h.dirs[filePath]
+	h.mu.Unlock()
+	if known {
+		return true
+	}
+	for _, rule := range h.server.cfg.Rules {
+		if withinDir(cleanPath(rule.Dir), filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingUpload buffers a file until the client closes it.
+type pendingUpload struct {
+	file    *os.File
+	path    string
+	handler *handler
+}
+
+// WriteAt implements io.WriterAt.
+func (u *pendingUpload) WriteAt(p []byte, off int64) (int, error) {
+	return u.file.WriteAt(p, off)
+}
+
+// Close uploads the buffered file and removes the temporary copy.
+func (u *pendingUpload) Close() error {
+	f := removeOnClose{u.file}
+	defer f.Close()
+
+	if _, err := u.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return u.handler.server.upload(u.handler.user, u.path, u.file)
+}
+
+// listerAt serves a fixed slice of file infos.
+type listerAt []os.FileInfo
+
+// ListAt implements sftp.ListerAt.
+func (l listerAt) ListAt(dst []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(dst, l[offset:])
+	if n < len(dst) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// dirInfo is a synthetic directory entry.
+type dirInfo string
+
+func (d dirInfo) Name() string       { return string(d) }
+func (d dirInfo) Size() int64        { return 0 }
+func (d dirInfo) Mode() os.FileMode  { return os.ModeDir | 0o755 }
+func (d dirInfo) ModTime() time.Time { return time.Time{} }
+func (d dirInfo) IsDir() bool        { return true }
+func (d dirInfo) Sys() interface{}   { return nil }
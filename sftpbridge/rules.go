@@ -0,0 +1,52 @@
+package sftpbridge
+
+import (
+	"path"
+	"strings"
+)
+
+// Rule maps an upload directory to an album.
+type Rule struct {
+	// Dir is the remote directory the rule applies to, e.g. "/cameras/front".
+	// Files in subdirectories of Dir also match.
+	Dir string
+
+	// AlbumID is the album uploaded files are filed into.
+	AlbumID int64
+
+	// Description is an optional description applied to uploaded files.
+	Description string
+}
+
+// matchRule returns the rule with the longest Dir containing filePath.
+func matchRule(rules []Rule, filePath string) (Rule, bool) {
+	dir := path.Dir(cleanPath(filePath))
+
+	var best Rule
+	bestLen := -1
+	for _, rule := range rules {
+		ruleDir := cleanPath(rule.Dir)
+		if !withinDir(dir, ruleDir) {
+			continue
+		}
+		if len(ruleDir) > bestLen {
+			best = rule
+			bestLen = len(ruleDir)
+		}
+	}
+
+	return best, bestLen >= 0
+}
+
+// withinDir reports whether dir is parent or one of its subdirectories.
+func withinDir(dir, parent string) bool {
+	if parent == "/" || dir == parent {
+		return true
+	}
+	return strings.HasPrefix(dir, parent+"/")
+}
+
+// cleanPath returns an absolute, cleaned slash path.
+func cleanPath(p string) string {
+	return path.Clean("/" + p)
+}
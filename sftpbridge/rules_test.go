@@ -0,0 +1,35 @@
+package sftpbridge
+
+import "testing"
+
+func TestMatchRulePrefersLongestDirectory(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Dir: "/", AlbumID: 1},
+		{Dir: "/cameras", AlbumID: 2},
+		{Dir: "/cameras/front", AlbumID: 3},
+	}
+
+	cases := map[string]int64{
+		"/upload.jpg":                   1,
+		"/cameras/a.jpg":                2,
+		"/cameras/frontyard/a.jpg":      2,
+		"/cameras/front/a.jpg":          3,
+		"/cameras/front/2024/06/01.jpg": 3,
+		"cameras/front/../back/a.jpg":   2,
+	}
+	for filePath, want := range cases {
+		rule, ok := matchRule(rules, filePath)
+		if !ok {
+			t.Fatalf("%s: expected a match", filePath)
+		}
+		if rule.AlbumID != want {
+			t.Fatalf("%s: got album %d, want %d", filePath, rule.AlbumID, want)
+		}
+	}
+
+	if _, ok := matchRule(rules[1:], "/other/a.jpg"); ok {
+		t.Fatal("expected no match outside rule directories")
+	}
+}
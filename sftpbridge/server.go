@@ -0,0 +1,304 @@
+// Package sftpbridge provides an embeddable SFTP server that uploads
+// incoming files to F-Image.
+//
+// It lets legacy cameras, scanners, and file-drop workflows that only speak
+// SFTP feed an F-Image library. Directories are mapped to albums with rules:
+//
+//	srv, err := sftpbridge.New(sftpbridge.Config{
+//	    Client:  fimage.NewClient(token),
+//	    HostKey: hostKey,
+//	    PasswordCallback: func(user, password string) bool {
+//	        return user == "camera" && password == os.Getenv("CAMERA_PASSWORD")
+//	    },
+//	    Rules: []sftpbridge.Rule{
+//	        {Dir: "/front-door", AlbumID: 12},
+//	        {Dir: "/backyard", AlbumID: 13},
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Fatal(srv.ListenAndServe(":2022"))
+//
+// The server is write-only: clients can create directories and upload files,
+// but cannot read, list, rename, or delete anything.
+package sftpbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// ErrServerClosed is returned by Serve after Close is called.
+var ErrServerClosed = errors.New("sftpbridge: server closed")
+
+// Config configures a Server.
+type Config struct {
+	// Client is the F-Image client used for uploads (required).
+	Client *fimage.Client
+
+	// HostKey is the server's SSH host key (required).
+	HostKey ssh.Signer
+
+	// PasswordCallback authenticates password logins.
+	PasswordCallback func(user, password string) bool
+
+	// PublicKeyCallback authenticates public key logins.
+	PublicKeyCallback func(user string, key ssh.PublicKey) bool
+
+	// Rules map upload directories to albums. The rule with the longest
+	// matching directory wins.
+	Rules []Rule
+
+	// DefaultAlbumID is used for files that match no rule.
+	// Leave nil to upload them without an album.
+	DefaultAlbumID *int64
+
+	// RejectUnmatched refuses uploads that match no rule.
+	RejectUnmatched bool
+
+	// UploadTimeout bounds each upload to F-Image. Defaults to 5 minutes.
+	UploadTimeout time.Duration
+
+	// OnUpload, if set, is called after each upload attempt.
+	OnUpload func(user, filePath string, resp *fimage.UploadResponse, err error)
+
+	// Logger receives connection errors. Defaults to the standard logger.
+	Logger *log.Logger
+}
+
+// Server is an SFTP server that uploads received files to F-Image.
+type Server struct {
+	cfg       Config
+	sshConfig *ssh.ServerConfig
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+}
+
+// New creates a Server from cfg.
+func New(cfg Config) (*Server, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if cfg.HostKey == nil {
+		return nil, fmt.Errorf("host key is required")
+	}
+	if cfg.PasswordCallback == nil && cfg.PublicKeyCallback == nil {
+		return nil, fmt.Errorf("password or public key callback is required")
+	}
+	if cfg.UploadTimeout <= 0 {
+		cfg.UploadTimeout = 5 * time.Minute
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.Default()
+	}
+
+	sshConfig := &ssh.ServerConfig{}
+	if cfg.PasswordCallback != nil {
+		sshConfig.PasswordCallback = func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if cfg.PasswordCallback(meta.User(), string(password)) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("invalid credentials for %q", meta.User())
+		}
+	}
+	if cfg.PublicKeyCallback != nil {
+		sshConfig.PublicKeyCallback = func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if cfg.PublicKeyCallback(meta.User(), key) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown public key for %q", meta.User())
+		}
+	}
+	sshConfig.AddHostKey(cfg.HostKey)
+
+	return &Server{
+		cfg:       cfg,
+		sshConfig: sshConfig,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// ListenAndServe listens on the TCP address addr and serves SFTP sessions.
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l until Close is called.
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		l.Close()
+		return ErrServerClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.listeners, l)
+		s.mu.Unlock()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// Close stops all listeners and closes active connections.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	var firstErr error
+	for l := range s.listeners {
+		if err := l.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	return firstErr
+}
+
+// handleConn performs the SSH handshake and serves SFTP subsystem requests.
+func (s *Server) handleConn(conn net.Conn) {
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, s.sshConfig)
+	if err != nil {
+		s.cfg.Logger.Printf("sftpbridge: handshake with %s failed: %v", conn.RemoteAddr(), err)
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			s.cfg.Logger.Printf("sftpbridge: accept channel: %v", err)
+			continue
+		}
+		go s.handleSession(sshConn.User(), channel, requests)
+	}
+}
+
+// handleSession waits for an sftp subsystem request and serves it.
+func (s *Server) handleSession(user string, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	for req := range requests {
+		ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+		if req.WantReply {
+			_ = req.Reply(ok, nil)
+		}
+		if !ok {
+			continue
+		}
+
+		h := &handler{server: s, user: user, dirs: map[string]bool{"/": true}}
+		server := sftp.NewRequestServer(channel, sftp.Handlers{
+			FileGet:  h,
+			FilePut:  h,
+			FileCmd:  h,
+			FileList: h,
+		})
+		if err := server.Serve(); err != nil && err != io.EOF {
+			s.cfg.Logger.Printf("sftpbridge: session for %q ended: %v", user, err)
+		}
+		server.Close()
+		return
+	}
+}
+
+// upload sends a received file to F-Image according to the mapping rules.
+func (s *Server) upload(user, filePath string, r io.Reader) error {
+	opts := &fimage.UploadOptions{Filename: path.Base(filePath)}
+	if rule, ok := matchRule(s.cfg.Rules, filePath); ok {
+		albumID := rule.AlbumID
+		opts.AlbumID = &albumID
+		opts.Description = rule.Description
+	} else if s.cfg.DefaultAlbumID != nil {
+		albumID := *s.cfg.DefaultAlbumID
+		opts.AlbumID = &albumID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.UploadTimeout)
+	defer cancel()
+
+	resp, err := s.cfg.Client.Files.Upload(ctx, r, opts)
+	if s.cfg.OnUpload != nil {
+		s.cfg.OnUpload(user, filePath, resp, err)
+	}
+	if err != nil {
+		s.cfg.Logger.Printf("sftpbridge: upload %s for %q failed: %v", filePath, user, err)
+	}
+	return err
+}
+
+// accepts reports whether the server accepts uploads to filePath.
+func (s *Server) accepts(filePath string) bool {
+	if !s.cfg.RejectUnmatched {
+		return true
+	}
+	_, ok := matchRule(s.cfg.Rules, filePath)
+	return ok
+}
+
+// removeOnClose deletes a temporary file after it has been closed.
+type removeOnClose struct {
+	*os.File
+}
+
+// Close closes and removes the file.
+func (f removeOnClose) Close() error {
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+	return err
+}
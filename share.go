@@ -2,6 +2,7 @@ package fimage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -34,6 +35,30 @@ type CreateShareOptions struct {
 	// MaxViews is the maximum number of views allowed.
 	// Leave as 0 for unlimited views.
 	MaxViews int
+
+	// AllowedEmails restricts access to the listed recipients.
+	// The server emails each recipient a personal access code.
+	AllowedEmails []string
+
+	// EmbedDomains restricts which websites may embed or hotlink the share
+	// (e.g., "example.com"). Leave empty to allow embedding anywhere.
+	EmbedDomains []string
+}
+
+// Validate checks the options for invalid fields.
+func (opts *CreateShareOptions) Validate() error {
+	var v validator
+	v.check(opts.FileID != nil || opts.AlbumID != nil, "FileID", "or AlbumID is required")
+	v.check(opts.FileID == nil || opts.AlbumID == nil, "FileID", "and AlbumID are mutually exclusive")
+	v.check(opts.ExpiresIn >= 0, "ExpiresIn", "must not be negative")
+	v.check(opts.MaxViews >= 0, "MaxViews", "must not be negative")
+	for i, email := range opts.AllowedEmails {
+		v.check(isEmail(email), fmt.Sprintf("AllowedEmails[%d]", i), "is not an email address: %q", email)
+	}
+	for i, domain := range opts.EmbedDomains {
+		v.check(isDomain(domain), fmt.Sprintf("EmbedDomains[%d]", i), "is not a domain name: %q", domain)
+	}
+	return v.err()
 }
 
 // UpdateShareOptions contains options for updating a share link.
@@ -46,6 +71,22 @@ type UpdateShareOptions struct {
 
 	// IsActive sets whether the share is active.
 	IsActive *bool
+
+	// EmbedDomains replaces the list of websites allowed to embed the share.
+	// Point to an empty slice to allow embedding anywhere.
+	EmbedDomains *[]string
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UpdateShareOptions) Validate() error {
+	var v validator
+	v.check(opts.MaxViews == nil || *opts.MaxViews >= 0, "MaxViews", "must not be negative")
+	if opts.EmbedDomains != nil {
+		for i, domain := range *opts.EmbedDomains {
+			v.check(isDomain(domain), fmt.Sprintf("EmbedDomains[%d]", i), "is not a domain name: %q", domain)
+		}
+	}
+	return v.err()
 }
 
 // ShareListOptions contains options for listing share links.
@@ -57,6 +98,13 @@ type ShareListOptions struct {
 	Limit int
 }
 
+// Validate checks the options for invalid fields.
+func (opts *ShareListOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	return v.err()
+}
+
 // List returns all share links for the authenticated user.
 //
 // Example:
@@ -68,10 +116,15 @@ type ShareListOptions struct {
 //	for _, share := range resp.Shares {
 //	    fmt.Printf("Share: %s (views: %d)\n", share.ShareURL, share.ViewCount)
 //	}
-func (s *ShareService) List(ctx context.Context, opts *ShareListOptions) (*SharesListResponse, error) {
+func (s *ShareService) List(ctx context.Context, opts *ShareListOptions, callOpts ...CallOption) (*SharesListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
 	query := url.Values{}
 
 	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
 		}
@@ -80,6 +133,8 @@ func (s *ShareService) List(ctx context.Context, opts *ShareListOptions) (*Share
 		}
 	}
 
+	setDefaultLimit(ctx, query)
+
 	var resp SharesListResponse
 	if err := s.client.requestWithQuery(ctx, "/api/shares", query, &resp); err != nil {
 		return nil, err
@@ -110,23 +165,32 @@ func (s *ShareService) List(ctx context.Context, opts *ShareListOptions) (*Share
 //	    AlbumID:  &albumID,
 //	    MaxViews: 100,
 //	})
-func (s *ShareService) Create(ctx context.Context, opts *CreateShareOptions) (*ShareLink, error) {
-	if opts == nil || (opts.FileID == nil && opts.AlbumID == nil) {
+func (s *ShareService) Create(ctx context.Context, opts *CreateShareOptions, callOpts ...CallOption) (*ShareLink, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
+	if opts == nil {
 		return nil, fmt.Errorf("either FileID or AlbumID is required")
 	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 
 	req := struct {
-		FileID    *int64 `json:"file_id,omitempty"`
-		AlbumID   *int64 `json:"album_id,omitempty"`
-		Password  string `json:"password,omitempty"`
-		ExpiresIn int    `json:"expires_in,omitempty"`
-		MaxViews  int    `json:"max_views,omitempty"`
+		FileID        *int64   `json:"file_id,omitempty"`
+		AlbumID       *int64   `json:"album_id,omitempty"`
+		Password      string   `json:"password,omitempty"`
+		ExpiresIn     int      `json:"expires_in,omitempty"`
+		MaxViews      int      `json:"max_views,omitempty"`
+		AllowedEmails []string `json:"allowed_emails,omitempty"`
+		EmbedDomains  []string `json:"embed_domains,omitempty"`
 	}{
-		FileID:    opts.FileID,
-		AlbumID:   opts.AlbumID,
-		Password:  opts.Password,
-		ExpiresIn: opts.ExpiresIn,
-		MaxViews:  opts.MaxViews,
+		FileID:        opts.FileID,
+		AlbumID:       opts.AlbumID,
+		Password:      opts.Password,
+		ExpiresIn:     opts.ExpiresIn,
+		MaxViews:      opts.MaxViews,
+		AllowedEmails: opts.AllowedEmails,
+		EmbedDomains:  opts.EmbedDomains,
 	}
 
 	var share ShareLink
@@ -145,21 +209,28 @@ func (s *ShareService) Create(ctx context.Context, opts *CreateShareOptions) (*S
 //	share, err := client.Share.Update(ctx, 123, &fimage.UpdateShareOptions{
 //	    IsActive: &isActive,
 //	})
-func (s *ShareService) Update(ctx context.Context, shareID int64, opts *UpdateShareOptions) (*ShareLink, error) {
+func (s *ShareService) Update(ctx context.Context, shareID int64, opts *UpdateShareOptions, callOpts ...CallOption) (*ShareLink, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
 	if opts == nil {
 		return nil, fmt.Errorf("update options are required")
 	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 
 	path := fmt.Sprintf("/api/shares/%d", shareID)
 
 	req := struct {
-		Password *string `json:"password,omitempty"`
-		MaxViews *int64  `json:"max_views,omitempty"`
-		IsActive *bool   `json:"is_active,omitempty"`
+		Password     *string   `json:"password,omitempty"`
+		MaxViews     *int64    `json:"max_views,omitempty"`
+		IsActive     *bool     `json:"is_active,omitempty"`
+		EmbedDomains *[]string `json:"embed_domains,omitempty"`
 	}{
-		Password: opts.Password,
-		MaxViews: opts.MaxViews,
-		IsActive: opts.IsActive,
+		Password:     opts.Password,
+		MaxViews:     opts.MaxViews,
+		IsActive:     opts.IsActive,
+		EmbedDomains: opts.EmbedDomains,
 	}
 
 	var share ShareLink
@@ -178,7 +249,9 @@ func (s *ShareService) Update(ctx context.Context, shareID int64, opts *UpdateSh
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (s *ShareService) Delete(ctx context.Context, shareID int64) (*MessageResponse, error) {
+func (s *ShareService) Delete(ctx context.Context, shareID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
 	path := fmt.Sprintf("/api/shares/%d", shareID)
 
 	var resp MessageResponse
@@ -189,6 +262,33 @@ func (s *ShareService) Delete(ctx context.Context, shareID int64) (*MessageRespo
 	return &resp, nil
 }
 
+// ListRecipients returns the email recipients of a restricted share link
+// along with their delivery and access status.
+//
+// Example:
+//
+//	recipients, err := client.Share.ListRecipients(ctx, 123)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, r := range recipients {
+//	    fmt.Printf("%s: %s\n", r.Email, r.Status)
+//	}
+func (s *ShareService) ListRecipients(ctx context.Context, shareID int64, callOpts ...CallOption) ([]ShareRecipient, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
+	path := fmt.Sprintf("/api/shares/%d/recipients", shareID)
+
+	var resp struct {
+		Recipients []ShareRecipient `json:"recipients"`
+	}
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Recipients, nil
+}
+
 // Access retrieves the content of a share link.
 // This is a public endpoint that doesn't require authentication.
 //
@@ -201,7 +301,9 @@ func (s *ShareService) Delete(ctx context.Context, shareID int64) (*MessageRespo
 //	if content.RequiresPassword {
 //	    // Use VerifyPassword to access
 //	}
-func (s *ShareService) Access(ctx context.Context, token string) (*SharedContent, error) {
+func (s *ShareService) Access(ctx context.Context, token string, callOpts ...CallOption) (*SharedContent, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
 	path := fmt.Sprintf("/api/s/%s", token)
 
 	var content SharedContent
@@ -215,14 +317,27 @@ func (s *ShareService) Access(ctx context.Context, token string) (*SharedContent
 // VerifyPassword verifies the password for a password-protected share.
 // This is a public endpoint that doesn't require authentication.
 //
+// A wrong password returns an *APIError matching ErrInvalidSharePassword,
+// with AttemptsRemaining and RetryAfter set when the server reports them.
+//
 // Example:
 //
 //	content, err := client.Share.VerifyPassword(ctx, "abc123token", "secret123")
+//	if errors.Is(err, fimage.ErrInvalidSharePassword) {
+//	    var apiErr *fimage.APIError
+//	    errors.As(err, &apiErr)
+//	    if apiErr.RetryAfter > 0 {
+//	        fmt.Printf("Locked, try again in %s\n", apiErr.RetryAfter)
+//	    }
+//	    return
+//	}
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Access granted: %s\n", content.Type)
-func (s *ShareService) VerifyPassword(ctx context.Context, token, password string) (*SharedContent, error) {
+func (s *ShareService) VerifyPassword(ctx context.Context, token, password string, callOpts ...CallOption) (*SharedContent, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
 	path := fmt.Sprintf("/api/s/%s/verify", token)
 
 	req := struct {
@@ -233,6 +348,10 @@ func (s *ShareService) VerifyPassword(ctx context.Context, token, password strin
 
 	var content SharedContent
 	if err := s.client.request(ctx, http.MethodPost, path, req, &content); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden && apiErr.Code == "" {
+			apiErr.Code = CodeSharePasswordInvalid
+		}
 		return nil, err
 	}
 
@@ -269,6 +388,18 @@ func (opts *CreateShareOptions) WithMaxViews(maxViews int) *CreateShareOptions {
 	return opts
 }
 
+// WithAllowedEmails restricts share options to the given email recipients.
+func (opts *CreateShareOptions) WithAllowedEmails(emails ...string) *CreateShareOptions {
+	opts.AllowedEmails = append(opts.AllowedEmails, emails...)
+	return opts
+}
+
+// WithEmbedDomains restricts which websites may embed the share.
+func (opts *CreateShareOptions) WithEmbedDomains(domains ...string) *CreateShareOptions {
+	opts.EmbedDomains = append(opts.EmbedDomains, domains...)
+	return opts
+}
+
 // ExpiresAt returns the expiration time based on ExpiresIn hours from now.
 func (opts *CreateShareOptions) ExpiresAt() *time.Time {
 	if opts.ExpiresIn <= 0 {
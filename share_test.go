@@ -0,0 +1,173 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyPasswordReturnsLockoutDetails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/s/abc/verify" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"Invalid password","attempts_remaining":2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	_, err := client.Share.VerifyPassword(context.Background(), "abc", "wrong")
+	if !errors.Is(err, ErrInvalidSharePassword) {
+		t.Fatalf("expected ErrInvalidSharePassword, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.AttemptsRemaining == nil || *apiErr.AttemptsRemaining != 2 {
+		t.Fatalf("unexpected attempts remaining: %v", apiErr.AttemptsRemaining)
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Fatalf("unexpected retry after: %s", apiErr.RetryAfter)
+	}
+}
+
+func TestCreateShareSendsAllowedEmails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/shares" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			AlbumID       int64    `json:"album_id"`
+			AllowedEmails []string `json:"allowed_emails"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.AlbumID != 4 || strings.Join(req.AllowedEmails, ",") != "ann@example.com,bob@example.org" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"token":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	albumID := int64(4)
+	opts := (&CreateShareOptions{AlbumID: &albumID}).WithAllowedEmails("ann@example.com", "bob@example.org")
+	if _, err := client.Share.Create(context.Background(), opts); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+}
+
+func TestCreateShareRejectsMalformedEmails(t *testing.T) {
+	t.Parallel()
+
+	fileID := int64(1)
+	for _, email := range []string{"", "bob", "bob@", "@example.com", "bob@localhost", "Bob <bob@example.com>", "bob@example.com, ann@example.com"} {
+		opts := &CreateShareOptions{FileID: &fileID, AllowedEmails: []string{email}}
+		if got := invalidFields(t, opts.Validate()); got != "AllowedEmails[0]" {
+			t.Errorf("%q: invalid fields = %q, want AllowedEmails[0]", email, got)
+		}
+	}
+
+	opts := &CreateShareOptions{FileID: &fileID, AllowedEmails: []string{"ann.lee+press@mail.example.com"}}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("unexpected error for a valid email: %v", err)
+	}
+}
+
+func TestListRecipientsDecodesStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/shares/7/recipients" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"recipients":[
+			{"email":"ann@example.com","status":"accessed","sent_at":"2024-05-01T10:00:00Z","last_accessed_at":"2024-05-02T08:30:00Z"},
+			{"email":"bob@example.org","status":"bounced"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	recipients, err := client.Share.ListRecipients(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("ListRecipients returned error: %v", err)
+	}
+	if len(recipients) != 2 {
+		t.Fatalf("unexpected recipients: %+v", recipients)
+	}
+	if recipients[0].Status != ShareRecipientAccessed || recipients[0].LastAccessedAt == nil ||
+		!recipients[0].LastAccessedAt.Equal(time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected first recipient: %+v", recipients[0])
+	}
+	if recipients[1].Status != ShareRecipientBounced || recipients[1].SentAt != nil {
+		t.Fatalf("unexpected second recipient: %+v", recipients[1])
+	}
+}
+
+func TestShareEmbedDomainsAreSentAndValidated(t *testing.T) {
+	t.Parallel()
+
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		bodies = append(bodies, req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":1,"token":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	fileID := int64(1)
+	opts := (&CreateShareOptions{FileID: &fileID}).WithEmbedDomains("example.com", "blog.example.com")
+	if _, err := client.Share.Create(context.Background(), opts); err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	none := []string{}
+	if _, err := client.Share.Update(context.Background(), 1, &UpdateShareOptions{EmbedDomains: &none}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+
+	if got, _ := json.Marshal(bodies[0]["embed_domains"]); string(got) != `["example.com","blog.example.com"]` {
+		t.Errorf("unexpected create embed_domains: %s", got)
+	}
+	if got, ok := bodies[1]["embed_domains"]; !ok || len(got.([]interface{})) != 0 {
+		t.Errorf("expected an empty embed_domains list to clear the restriction, got %v", bodies[1])
+	}
+
+	for _, domain := range []string{"", " ", "https://example.com", "example.com/path", "example.com:8080", "-example.com", "example..com"} {
+		create := &CreateShareOptions{FileID: &fileID, EmbedDomains: []string{domain}}
+		if got := invalidFields(t, create.Validate()); got != "EmbedDomains[0]" {
+			t.Errorf("create %q: invalid fields = %q, want EmbedDomains[0]", domain, got)
+		}
+		update := &UpdateShareOptions{EmbedDomains: &[]string{domain}}
+		if got := invalidFields(t, update.Validate()); got != "EmbedDomains[0]" {
+			t.Errorf("update %q: invalid fields = %q, want EmbedDomains[0]", domain, got)
+		}
+	}
+}
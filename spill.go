@@ -0,0 +1,176 @@
+package fimage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// spillPageSize is the number of items an Iterator over a SpillFile decodes
+// at a time.
+const spillPageSize = 1000
+
+// SpillFile holds the items of a listing in a temporary file, for scans that
+// need the full list (dedup scans, audits) without keeping millions of items
+// in memory. Create one with Iterator.Spill and remove it with Close.
+//
+// A SpillFile is safe for concurrent use; each Iterator it returns is not.
+type SpillFile[T any] struct {
+	path string
+	n    int64
+
+	mu      sync.Mutex
+	readers map[*os.File]struct{}
+	closed  bool
+}
+
+// Spill drains the iterator into a temporary file in dir (the default
+// temporary directory if empty), holding only one page in memory at a time.
+// The returned SpillFile can be iterated any number of times. If the
+// iteration fails, the partial file is removed and the error is returned.
+//
+// Example:
+//
+//	spill, err := client.Files.ListAll(ctx, &fimage.ListOptions{Limit: 100}).Spill("")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer spill.Close()
+//
+//	fmt.Printf("%d files\n", spill.Len())
+//	it := spill.Iterator(ctx)
+//	for it.Next() {
+//	    audit(it.Value())
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (it *Iterator[T]) Spill(dir string) (_ *SpillFile[T], err error) {
+	f, err := os.CreateTemp(dir, "fimage-spill-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spill file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	var n int64
+	for it.Next() {
+		if err := enc.Encode(it.Value()); err != nil {
+			return nil, fmt.Errorf("failed to write spill file: %w", err)
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write spill file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write spill file: %w", err)
+	}
+
+	return &SpillFile[T]{path: f.Name(), n: n, readers: map[*os.File]struct{}{}}, nil
+}
+
+// Len returns the number of items in the file.
+func (s *SpillFile[T]) Len() int64 {
+	return s.n
+}
+
+// Path returns the location of the file on disk.
+func (s *SpillFile[T]) Path() string {
+	return s.path
+}
+
+// Iterator returns an iterator over the items in the file, in listing order.
+// It decodes the file a page at a time and closes it once exhausted.
+func (s *SpillFile[T]) Iterator(ctx context.Context) *Iterator[T] {
+	var (
+		f    *os.File
+		dec  *json.Decoder
+		read int64
+	)
+	return newIterator(ctx, 1, func(ctx context.Context, _ int) (*page[T], error) {
+		if f == nil {
+			var err error
+			if f, err = s.open(); err != nil {
+				return nil, err
+			}
+			dec = json.NewDecoder(bufio.NewReader(f))
+		}
+
+		items := make([]T, 0, spillPageSize)
+		for len(items) < spillPageSize {
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				s.release(f)
+				return nil, fmt.Errorf("failed to read spill file: %w", err)
+			}
+			items = append(items, item)
+		}
+		read += int64(len(items))
+		if len(items) < spillPageSize || read >= s.n {
+			s.release(f)
+		}
+		return &page[T]{items: items, total: s.n, limit: spillPageSize}, nil
+	})
+}
+
+// open opens the file for a new iterator.
+func (s *SpillFile[T]) open() (*os.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New("spill file is closed")
+	}
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spill file: %w", err)
+	}
+	s.readers[f] = struct{}{}
+	return f, nil
+}
+
+// release closes a file opened by an iterator.
+func (s *SpillFile[T]) release(f *os.File) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.readers[f]; ok {
+		delete(s.readers, f)
+		f.Close()
+	}
+}
+
+// Close removes the file. Iterators that have not finished fail on their
+// next page.
+func (s *SpillFile[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	for f := range s.readers {
+		f.Close()
+	}
+	s.readers = nil
+	if err := os.Remove(s.path); err != nil {
+		return fmt.Errorf("failed to remove spill file: %w", err)
+	}
+	return nil
+}
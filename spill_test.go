@@ -0,0 +1,55 @@
+package fimage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestSpillIteratesListingFromDisk(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"files":[{"id":1},{"id":2}],"total":3,"page":1,"limit":2}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"files":[{"id":3}],"total":3,"page":2,"limit":2}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	spill, err := client.Files.ListAll(context.Background(), &ListOptions{Limit: 2}).Spill(t.TempDir())
+	if err != nil {
+		t.Fatalf("Spill returned error: %v", err)
+	}
+	if spill.Len() != 3 {
+		t.Fatalf("unexpected length: %d", spill.Len())
+	}
+
+	for pass := 0; pass < 2; pass++ {
+		files, err := spill.Iterator(context.Background()).Collect()
+		if err != nil {
+			t.Fatalf("Collect returned error: %v", err)
+		}
+		if got := fmt.Sprint(len(files), files[0].ID, files[2].ID); got != "3 1 3" {
+			t.Fatalf("unexpected files on pass %d: %s", pass, got)
+		}
+	}
+
+	if err := spill.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if _, err := os.Stat(spill.Path()); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("spill file was not removed: %v", err)
+	}
+}
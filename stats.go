@@ -0,0 +1,247 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// StatsGranularity selects the bucket size of traffic statistics.
+type StatsGranularity string
+
+const (
+	// StatsHour buckets statistics by hour.
+	StatsHour StatsGranularity = "hour"
+
+	// StatsDay buckets statistics by calendar day.
+	StatsDay StatsGranularity = "day"
+
+	// StatsWeek buckets statistics by ISO week.
+	StatsWeek StatsGranularity = "week"
+
+	// StatsMonth buckets statistics by calendar month.
+	StatsMonth StatsGranularity = "month"
+)
+
+// Valid reports whether g is a known granularity.
+func (g StatsGranularity) Valid() bool {
+	switch g {
+	case StatsHour, StatsDay, StatsWeek, StatsMonth:
+		return true
+	}
+	return false
+}
+
+// StatsOptions selects the time range and bucket size of traffic
+// statistics.
+type StatsOptions struct {
+	// From is the start of the range (default: 30 days before To).
+	From time.Time
+
+	// To is the end of the range (default: now).
+	To time.Time
+
+	// Granularity is the bucket size (default: StatsDay).
+	Granularity StatsGranularity
+}
+
+// Validate checks the options for invalid fields.
+func (opts *StatsOptions) Validate() error {
+	var v validator
+	v.check(opts.From.IsZero() || opts.To.IsZero() || opts.From.Before(opts.To), "From", "must be before To")
+	v.check(opts.Granularity == "" || opts.Granularity.Valid(), "Granularity", "has unsupported value %q", opts.Granularity)
+	return v.err()
+}
+
+// query returns the options as query parameters.
+func (opts *StatsOptions) query() url.Values {
+	query := url.Values{}
+	if !opts.From.IsZero() {
+		query.Set("from", opts.From.UTC().Format(time.RFC3339))
+	}
+	if !opts.To.IsZero() {
+		query.Set("to", opts.To.UTC().Format(time.RFC3339))
+	}
+	if opts.Granularity != "" {
+		query.Set("granularity", string(opts.Granularity))
+	}
+	return query
+}
+
+// TrafficCounts are traffic totals for a period.
+type TrafficCounts struct {
+	// Views is the number of times the image was served for display.
+	Views int64 `json:"views"`
+
+	// Downloads is the number of original-file downloads.
+	Downloads int64 `json:"downloads"`
+
+	// Bandwidth is the number of bytes served.
+	Bandwidth int64 `json:"bandwidth"`
+}
+
+// StatsPoint is the traffic of one bucket.
+type StatsPoint struct {
+	TrafficCounts
+
+	// Start is the beginning of the bucket.
+	Start time.Time `json:"start"`
+}
+
+// FileStats is the traffic of a file over a time range.
+type FileStats struct {
+	// FileID is the ID of the file.
+	FileID int64 `json:"file_id"`
+
+	// From is the start of the range.
+	From time.Time `json:"from"`
+
+	// To is the end of the range.
+	To time.Time `json:"to"`
+
+	// Granularity is the bucket size used by the server.
+	Granularity StatsGranularity `json:"granularity"`
+
+	// Totals are the totals over the whole range.
+	Totals TrafficCounts `json:"totals"`
+
+	// Points are the buckets, oldest first. Buckets without traffic are
+	// included with zero counts.
+	Points []StatsPoint `json:"points"`
+}
+
+// ViewStats returns view, download, and bandwidth statistics of a file.
+//
+// Example:
+//
+//	stats, err := client.Files.ViewStats(ctx, 123, &fimage.StatsOptions{
+//	    From:        time.Now().AddDate(0, 0, -7),
+//	    Granularity: fimage.StatsDay,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d views, %d bytes served\n", stats.Totals.Views, stats.Totals.Bandwidth)
+//	for _, p := range stats.Points {
+//	    fmt.Printf("%s: %d views\n", p.Start.Format("Jan 2"), p.Views)
+//	}
+func (s *FilesService) ViewStats(ctx context.Context, fileID int64, opts *StatsOptions, callOpts ...CallOption) (*FileStats, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		opts = &StatsOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/files/%d/stats", fileID)
+
+	var stats FileStats
+	if err := s.client.requestWithQuery(ctx, path, opts.query(), &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// ShareStatsPoint is the engagement of a share link in one bucket.
+type ShareStatsPoint struct {
+	// Start is the beginning of the bucket.
+	Start time.Time `json:"start"`
+
+	// Views is the number of times the share link was opened.
+	Views int64 `json:"views"`
+
+	// UniqueVisitors is the number of distinct visitors.
+	UniqueVisitors int64 `json:"unique_visitors"`
+}
+
+// ReferrerCount is the number of share link views from a referrer.
+type ReferrerCount struct {
+	// Referrer is the referring host (e.g. "twitter.com"), or "" for direct
+	// visits.
+	Referrer string `json:"referrer"`
+
+	// Views is the number of views from the referrer.
+	Views int64 `json:"views"`
+}
+
+// CountryCount is the number of share link views from a country.
+type CountryCount struct {
+	// Country is the ISO 3166-1 alpha-2 country code, or "" when unknown.
+	Country string `json:"country"`
+
+	// Views is the number of views from the country.
+	Views int64 `json:"views"`
+}
+
+// ShareStats is the engagement of a share link over a time range.
+type ShareStats struct {
+	// ShareID is the ID of the share link.
+	ShareID int64 `json:"share_id"`
+
+	// From is the start of the range.
+	From time.Time `json:"from"`
+
+	// To is the end of the range.
+	To time.Time `json:"to"`
+
+	// Granularity is the bucket size used by the server.
+	Granularity StatsGranularity `json:"granularity"`
+
+	// Views is the number of views over the whole range.
+	Views int64 `json:"views"`
+
+	// UniqueVisitors is the number of distinct visitors over the whole range.
+	UniqueVisitors int64 `json:"unique_visitors"`
+
+	// Points are the buckets, oldest first.
+	Points []ShareStatsPoint `json:"points"`
+
+	// Referrers are the views by referrer, most views first.
+	Referrers []ReferrerCount `json:"referrers"`
+
+	// Countries are the views by country, most views first.
+	Countries []CountryCount `json:"countries"`
+
+	// ViewedAt are the timestamps of the most recent views in the range,
+	// newest first.
+	ViewedAt []time.Time `json:"viewed_at"`
+}
+
+// GetStats returns views, unique visitors, referrers, and the country
+// breakdown of a share link.
+//
+// Example:
+//
+//	stats, err := client.Share.GetStats(ctx, 123, &fimage.StatsOptions{
+//	    From: time.Now().AddDate(0, -1, 0),
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d views from %d visitors\n", stats.Views, stats.UniqueVisitors)
+//	for _, r := range stats.Referrers {
+//	    fmt.Printf("%s: %d\n", r.Referrer, r.Views)
+//	}
+func (s *ShareService) GetStats(ctx context.Context, shareID int64, opts *StatsOptions, callOpts ...CallOption) (*ShareStats, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceShare, callOpts)
+
+	if opts == nil {
+		opts = &StatsOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/api/shares/%d/stats", shareID)
+
+	var stats ShareStats
+	if err := s.client.requestWithQuery(ctx, path, opts.query(), &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,164 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileViewStats(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/files/21/stats" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.RawQuery; got != "from=2024-03-01T00%3A00%3A00Z&granularity=week&to=2024-03-15T00%3A00%3A00Z" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"file_id": 21,
+			"from": "2024-03-01T00:00:00Z",
+			"to": "2024-03-15T00:00:00Z",
+			"granularity": "week",
+			"totals": {"views": 130, "downloads": 4, "bandwidth": 5200000},
+			"points": [
+				{"start": "2024-02-26T00:00:00Z", "views": 100, "downloads": 3, "bandwidth": 4000000},
+				{"start": "2024-03-04T00:00:00Z", "views": 30, "downloads": 1, "bandwidth": 1200000},
+				{"start": "2024-03-11T00:00:00Z", "views": 0, "downloads": 0, "bandwidth": 0}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	// A non-UTC range is sent in UTC.
+	est := time.FixedZone("EST", -5*60*60)
+	stats, err := client.Files.ViewStats(context.Background(), 21, &StatsOptions{
+		From:        time.Date(2024, 2, 29, 19, 0, 0, 0, est),
+		To:          time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Granularity: StatsWeek,
+	})
+	if err != nil {
+		t.Fatalf("ViewStats returned error: %v", err)
+	}
+	if stats.FileID != 21 || stats.Granularity != StatsWeek || stats.Totals.Views != 130 || stats.Totals.Bandwidth != 5200000 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(stats.Points) != 3 || stats.Points[1].Views != 30 || stats.Points[1].Downloads != 1 || stats.Points[2].Views != 0 {
+		t.Fatalf("unexpected points: %+v", stats.Points)
+	}
+	if !stats.Points[0].Start.Equal(time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected first bucket start: %v", stats.Points[0].Start)
+	}
+}
+
+func TestStatsOptionsValidate(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query for default options, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"file_id":21,"granularity":"day"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		opts   StatsOptions
+		fields string
+	}{
+		{"defaults", StatsOptions{}, ""},
+		{"open start", StatsOptions{To: now}, ""},
+		{"reversed range", StatsOptions{From: now, To: now.Add(-time.Hour)}, "From"},
+		{"empty range", StatsOptions{From: now, To: now}, "From"},
+		{"unknown granularity", StatsOptions{Granularity: "minute"}, "Granularity"},
+	}
+	for _, tt := range tests {
+		if got := invalidFields(t, tt.opts.Validate()); got != tt.fields {
+			t.Errorf("%s: invalid fields = %q, want %q", tt.name, got, tt.fields)
+		}
+	}
+
+	if _, err := client.Files.ViewStats(ctx, 21, &StatsOptions{Granularity: "minute"}); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if _, err := client.Files.ViewStats(ctx, 21, nil); err != nil {
+		t.Fatalf("ViewStats returned error: %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected only the valid call to make a request, got %d requests", n)
+	}
+}
+
+func TestShareGetStats(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/shares/14/stats" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.RawQuery; got != "from=2024-05-01T00%3A00%3A00Z&granularity=day" {
+			t.Errorf("unexpected query: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"share_id": 14,
+			"from": "2024-05-01T00:00:00Z",
+			"to": "2024-05-03T00:00:00Z",
+			"granularity": "day",
+			"views": 12,
+			"unique_visitors": 7,
+			"points": [
+				{"start": "2024-05-01T00:00:00Z", "views": 9, "unique_visitors": 5},
+				{"start": "2024-05-02T00:00:00Z", "views": 3, "unique_visitors": 2}
+			],
+			"referrers": [{"referrer": "twitter.com", "views": 8}, {"referrer": "", "views": 4}],
+			"countries": [{"country": "DE", "views": 10}, {"country": "", "views": 2}],
+			"viewed_at": ["2024-05-02T18:04:00Z", "2024-05-02T09:12:00Z"]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	stats, err := client.Share.GetStats(context.Background(), 14, &StatsOptions{
+		From:        time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Granularity: StatsDay,
+	})
+	if err != nil {
+		t.Fatalf("GetStats returned error: %v", err)
+	}
+	if stats.ShareID != 14 || stats.Views != 12 || stats.UniqueVisitors != 7 || stats.Granularity != StatsDay {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(stats.Points) != 2 || stats.Points[0].UniqueVisitors != 5 {
+		t.Fatalf("unexpected points: %+v", stats.Points)
+	}
+	if len(stats.Referrers) != 2 || stats.Referrers[0].Referrer != "twitter.com" || stats.Referrers[1].Referrer != "" {
+		t.Fatalf("unexpected referrers: %+v", stats.Referrers)
+	}
+	if len(stats.Countries) != 2 || stats.Countries[0].Country != "DE" || stats.Countries[0].Views != 10 {
+		t.Fatalf("unexpected countries: %+v", stats.Countries)
+	}
+	if len(stats.ViewedAt) != 2 || !stats.ViewedAt[0].After(stats.ViewedAt[1]) {
+		t.Fatalf("unexpected view times: %v", stats.ViewedAt)
+	}
+
+	if _, err := client.Share.GetStats(context.Background(), 14, &StatsOptions{Granularity: "year"}); invalidFields(t, err) != "Granularity" {
+		t.Fatalf("expected Granularity validation error, got %v", err)
+	}
+}
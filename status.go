@@ -0,0 +1,101 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HealthState is the health of the API or one of its components.
+type HealthState string
+
+const (
+	// HealthOperational means the component works normally.
+	HealthOperational HealthState = "operational"
+
+	// HealthDegraded means the component works with reduced performance or
+	// partial failures.
+	HealthDegraded HealthState = "degraded"
+
+	// HealthOutage means the component is unavailable.
+	HealthOutage HealthState = "outage"
+)
+
+// ComponentHealth is the health of a single API component.
+type ComponentHealth struct {
+	// Name is the component name (e.g., "uploads", "cdn", "search").
+	Name string `json:"name"`
+
+	// Status is the component health.
+	Status HealthState `json:"status"`
+
+	// Message describes an ongoing incident (if any).
+	Message string `json:"message,omitempty"`
+}
+
+// ServiceStatus describes the state of the F-Image API.
+type ServiceStatus struct {
+	// Status is the overall API health.
+	Status HealthState `json:"status"`
+
+	// Version is the API server version.
+	Version string `json:"version"`
+
+	// Region is the region that served the request.
+	Region string `json:"region"`
+
+	// Components lists the health of individual components.
+	Components []ComponentHealth `json:"components"`
+
+	// CheckedAt is when the status was computed.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Operational reports whether the API and every component are operational.
+func (s *ServiceStatus) Operational() bool {
+	if s.Status != HealthOperational {
+		return false
+	}
+	for _, c := range s.Components {
+		if c.Status != HealthOperational {
+			return false
+		}
+	}
+	return true
+}
+
+// Ping checks that the API is reachable and the API token is accepted.
+// It is cheap enough to use in readiness probes.
+//
+// Example:
+//
+//	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//	    if err := client.Ping(r.Context()); err != nil {
+//	        http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//	        return
+//	    }
+//	    w.WriteHeader(http.StatusOK)
+//	})
+func (c *Client) Ping(ctx context.Context) error {
+	return c.request(ctx, http.MethodGet, "/api/health", nil, nil)
+}
+
+// Status returns the API version, serving region, and component health.
+//
+// Example:
+//
+//	status, err := client.Status(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, c := range status.Components {
+//	    fmt.Printf("%s: %s\n", c.Name, c.Status)
+//	}
+func (c *Client) Status(ctx context.Context) (*ServiceStatus, error) {
+	var status ServiceStatus
+	if err := c.request(ctx, http.MethodGet, "/api/status", nil, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
@@ -0,0 +1,387 @@
+// Package sync mirrors a local directory into an F-Image album.
+//
+// SyncDir walks the directory, uploads new and changed files, skips
+// unchanged ones, and optionally deletes album files that no longer exist
+// locally. Files are matched by their path relative to the directory, with
+// the path separators replaced by "__" since the server keeps only base
+// names (e.g. "trips/rome.jpg" is uploaded as "trips__rome.jpg"). Unchanged
+// files are detected by comparing SHA-256 digests with the server's hash
+// index, so re-running a sync only transfers what changed.
+//
+// Example:
+//
+//	result, err := sync.New(client).SyncDir(ctx, "/photos/2024", sync.SyncOptions{
+//	    AlbumID:      42,
+//	    DeleteRemote: true,
+//	    Concurrency:  8,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d uploaded, %d updated, %d unchanged, %d deleted\n",
+//	    len(result.Uploaded), len(result.Updated), len(result.Unchanged), len(result.Deleted))
+//	if err := result.Err(); err != nil {
+//	    log.Printf("some files failed: %v", err)
+//	}
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	stdsync "sync"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// listPageSize is the page size used to list the album.
+const listPageSize = 100
+
+// nameSeparator replaces the path separators of a relative path in the
+// album file name.
+const nameSeparator = "__"
+
+// SyncOptions configures SyncDir.
+type SyncOptions struct {
+	// AlbumID is the album the directory is mirrored into (required).
+	AlbumID int64
+
+	// DeleteRemote moves album files with no local counterpart to the trash.
+	DeleteRemote bool
+
+	// Concurrency is the number of files processed at once
+	// (default: fimage.DefaultConcurrency).
+	Concurrency int
+}
+
+// FileError describes a file that could not be synced.
+type FileError struct {
+	// Path is the path of the file relative to the synced directory.
+	Path string
+
+	// Err is why the file failed.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// Result is the outcome of a sync. Paths are relative to the synced
+// directory, slash-separated, and sorted.
+type Result struct {
+	// Uploaded lists files that were new to the album.
+	Uploaded []string
+
+	// Updated lists files whose album copy had different contents and was
+	// replaced.
+	Updated []string
+
+	// Unchanged lists files whose album copy has the same contents.
+	Unchanged []string
+
+	// Deduplicated lists files whose contents were already in the library,
+	// so the server linked the existing copy instead of storing the upload.
+	// A previous album version with other contents is still replaced.
+	Deduplicated []string
+
+	// Deleted lists the names of album files moved to the trash because
+	// they no longer exist locally.
+	Deleted []string
+
+	// Failed lists the files that could not be synced.
+	Failed []FileError
+}
+
+// Err returns an error joining the failed files, or nil if every file was
+// synced.
+func (r *Result) Err() error {
+	errs := make([]error, len(r.Failed))
+	for i := range r.Failed {
+		errs[i] = &r.Failed[i]
+	}
+	return errors.Join(errs...)
+}
+
+// Syncer syncs local directories with albums.
+type Syncer struct {
+	client *fimage.Client
+}
+
+// New returns a Syncer that uses client.
+func New(client *fimage.Client) *Syncer {
+	return &Syncer{client: client}
+}
+
+// outcome is how a single local file was synced.
+type outcome int
+
+const (
+	uploaded outcome = iota
+	updated
+	unchanged
+	deduplicated
+)
+
+// SyncDir mirrors dir into the album. Hidden files and directories (names
+// starting with ".") and non-regular files are ignored. It only returns an
+// error when the sync could not run at all, e.g. when dir cannot be read or
+// the album cannot be listed; per-file failures are reported in the result.
+//
+// A changed file is replaced by uploading the new contents and then
+// deleting the old album file, so the album never lacks the file. Local
+// paths that map to the same album file name (e.g. "a/b.jpg" and
+// "a__b.jpg") fail, except for the first in walk order.
+func (s *Syncer) SyncDir(ctx context.Context, dir string, opts SyncOptions) (*Result, error) {
+	if opts.AlbumID <= 0 {
+		return nil, errors.New("sync: AlbumID is required")
+	}
+
+	local, err := walk(dir)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := s.listAlbum(ctx, opts.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = fimage.DefaultConcurrency
+	}
+
+	var (
+		mu     stdsync.Mutex
+		wg     stdsync.WaitGroup
+		result Result
+	)
+
+	names := make(map[string]string, len(local))
+	queue := local[:0:0]
+	for _, rel := range local {
+		name := remoteName(rel)
+		if other, ok := names[name]; ok {
+			result.Failed = append(result.Failed, FileError{
+				Path: rel,
+				Err:  fmt.Errorf("album file name %q is already used by %s", name, other),
+			})
+			continue
+		}
+		names[name] = rel
+		queue = append(queue, rel)
+	}
+
+	paths := make(chan string)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rel := range paths {
+				out, err := s.syncFile(ctx, dir, rel, remote[remoteName(rel)], opts.AlbumID)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					result.Failed = append(result.Failed, FileError{Path: rel, Err: err})
+				case out == uploaded:
+					result.Uploaded = append(result.Uploaded, rel)
+				case out == updated:
+					result.Updated = append(result.Updated, rel)
+				case out == deduplicated:
+					result.Deduplicated = append(result.Deduplicated, rel)
+				default:
+					result.Unchanged = append(result.Unchanged, rel)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, rel := range queue {
+		paths <- rel
+	}
+	close(paths)
+	wg.Wait()
+
+	if opts.DeleteRemote && ctx.Err() == nil {
+		s.deleteRemote(ctx, names, remote, &result)
+	}
+
+	sort.Strings(result.Uploaded)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Unchanged)
+	sort.Strings(result.Deduplicated)
+	sort.Strings(result.Deleted)
+	sort.Slice(result.Failed, func(i, j int) bool { return result.Failed[i].Path < result.Failed[j].Path })
+
+	return &result, nil
+}
+
+// walk returns the relative, slash-separated paths of the files in dir.
+func walk(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+	return paths, nil
+}
+
+// remoteName returns the album file name of the relative path rel.
+func remoteName(rel string) string {
+	return strings.ReplaceAll(rel, "/", nameSeparator)
+}
+
+// listAlbum returns the files of the album by name.
+func (s *Syncer) listAlbum(ctx context.Context, albumID int64) (map[string]*fimage.File, error) {
+	files := map[string]*fimage.File{}
+	it := s.client.Files.ListAll(ctx, &fimage.ListOptions{AlbumID: &albumID, Limit: listPageSize})
+	for it.Next() {
+		file := it.Value()
+		files[file.OriginalName] = &file
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list album %d: %w", albumID, err)
+	}
+	return files, nil
+}
+
+// syncFile uploads the file at rel unless its album copy has the same bytes.
+// existing is the album file with the same name, if any.
+func (s *Syncer) syncFile(ctx context.Context, dir, rel string, existing *fimage.File, albumID int64) (outcome, error) {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if existing != nil && existing.Size == info.Size() {
+		same, err := s.sameContents(ctx, f, existing)
+		if err != nil {
+			return 0, err
+		}
+		if same {
+			return unchanged, nil
+		}
+	}
+
+	resp, err := s.client.Files.Upload(ctx, f, &fimage.UploadOptions{
+		Filename: remoteName(rel),
+		AlbumID:  &albumID,
+		Size:     info.Size(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to upload: %w", err)
+	}
+	flash := resp.Data != nil && resp.Data.IsFlash
+
+	if existing != nil {
+		if resp.Data != nil && resp.Data.ID == existing.ID {
+			// The server matched the upload to the album copy itself.
+			return unchanged, nil
+		}
+		if _, err := s.client.Files.Delete(ctx, existing.ID); err != nil {
+			return 0, fmt.Errorf("uploaded, but failed to delete the previous version (file %d): %w", existing.ID, err)
+		}
+	}
+
+	switch {
+	case flash:
+		return deduplicated, nil
+	case existing != nil:
+		return updated, nil
+	}
+	return uploaded, nil
+}
+
+// sameContents reports whether f holds the bytes of existing, using the
+// server's hash index, and rewinds f.
+func (s *Syncer) sameContents(ctx context.Context, f *os.File, existing *fimage.File) (bool, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false, fmt.Errorf("failed to hash file: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, fmt.Errorf("failed to rewind file: %w", err)
+	}
+
+	check, err := s.client.Files.CheckHash(ctx, hex.EncodeToString(h.Sum(nil)))
+	if err != nil {
+		return false, fmt.Errorf("failed to check hash: %w", err)
+	}
+	return check.Exists && check.File != nil && check.File.ID == existing.ID, nil
+}
+
+// deleteRemote trashes the album files with no local counterpart.
+// present holds the album file names of the local files.
+func (s *Syncer) deleteRemote(ctx context.Context, present map[string]string, remote map[string]*fimage.File, result *Result) {
+	var (
+		names []string
+		ids   []int64
+	)
+	for name, file := range remote {
+		if _, ok := present[name]; !ok {
+			names = append(names, name)
+			ids = append(ids, file.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	batch, err := s.client.Files.BatchDelete(ctx, ids)
+	if err != nil {
+		for _, name := range names {
+			result.Failed = append(result.Failed, FileError{Path: name, Err: fmt.Errorf("failed to delete: %w", err)})
+		}
+		return
+	}
+	failed := make(map[int]error, len(batch.Failed))
+	for _, item := range batch.Failed {
+		failed[item.Index] = item.Err
+	}
+	for i, name := range names {
+		if err, ok := failed[i]; ok {
+			result.Failed = append(result.Failed, FileError{Path: name, Err: fmt.Errorf("failed to delete: %w", err)})
+			continue
+		}
+		result.Deleted = append(result.Deleted, name)
+	}
+}
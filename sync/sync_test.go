@@ -0,0 +1,182 @@
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	stdsync "sync"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+func TestSyncDirUploadsChangesAndMirrorsDeletions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"a.jpg":       "A",
+		"b.jpg":       "B",
+		"sub/c.jpg":   "C",
+		".hidden.jpg": "H",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sumA := sha256.Sum256([]byte("A"))
+
+	var (
+		mu       stdsync.Mutex
+		uploaded []string
+		deleted  []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/files":
+			if r.URL.Query().Get("album_id") != "42" {
+				t.Errorf("unexpected listing: %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"files":[
+				{"id":1,"original_name":"a.jpg","size":1},
+				{"id":2,"original_name":"b.jpg","size":1},
+				{"id":3,"original_name":"old.jpg","size":1}
+			],"total":3,"page":1,"limit":100}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/files/hash/"+hex.EncodeToString(sumA[:]):
+			_, _ = w.Write([]byte(`{"exists":true,"file":{"id":1}}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"exists":false}`))
+		case r.URL.Path == "/api/files/upload":
+			_, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("failed to read upload: %v", err)
+				return
+			}
+			if got := r.FormValue("album_id"); got != "42" {
+				t.Errorf("unexpected album_id: %q", got)
+			}
+			mu.Lock()
+			uploaded = append(uploaded, header.Filename)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":10}}`))
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"message":"deleted"}`))
+		case r.URL.Path == "/api/files/batch-delete":
+			_, _ = w.Write([]byte(`{"results":[{"id":3,"success":true}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := fimage.NewClient("test-token", fimage.WithBaseURL(server.URL), fimage.WithHTTPClient(server.Client()))
+
+	result, err := New(client).SyncDir(context.Background(), dir, SyncOptions{AlbumID: 42, DeleteRemote: true})
+	if err != nil {
+		t.Fatalf("SyncDir returned error: %v", err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatalf("unexpected failures: %v", err)
+	}
+	want := Result{
+		Uploaded:  []string{"sub/c.jpg"},
+		Updated:   []string{"b.jpg"},
+		Unchanged: []string{"a.jpg"},
+		Deleted:   []string{"old.jpg"},
+	}
+	if !reflect.DeepEqual(*result, want) {
+		t.Fatalf("unexpected result: %+v", *result)
+	}
+	sort.Strings(uploaded)
+	if fmt.Sprint(deleted) != "[/api/files/2]" || fmt.Sprint(uploaded) != "[b.jpg sub__c.jpg]" {
+		t.Fatalf("unexpected requests: uploaded %v, deleted %v", uploaded, deleted)
+	}
+}
+
+func TestSyncDirReportsDeduplicatedUploads(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"same.jpg":   "S2",
+		"linked.jpg": "L",
+		"a/b.jpg":    "X",
+		"a__b.jpg":   "Y",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var deletes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/files":
+			_, _ = w.Write([]byte(`{"files":[{"id":5,"original_name":"same.jpg","size":1}],"total":1,"page":1,"limit":100}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/files/upload":
+			_, header, err := r.FormFile("file")
+			if err != nil {
+				t.Errorf("failed to read upload: %v", err)
+				return
+			}
+			switch header.Filename {
+			case "same.jpg":
+				_, _ = w.Write([]byte(`{"success":true,"data":{"id":5,"is_flash":true}}`))
+			case "linked.jpg":
+				_, _ = w.Write([]byte(`{"success":true,"data":{"id":20,"is_flash":true}}`))
+			default:
+				_, _ = w.Write([]byte(`{"success":true,"data":{"id":30}}`))
+			}
+		case r.Method == http.MethodDelete:
+			deletes++
+			_, _ = w.Write([]byte(`{"message":"deleted"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := fimage.NewClient("test-token", fimage.WithBaseURL(server.URL), fimage.WithHTTPClient(server.Client()))
+
+	result, err := New(client).SyncDir(context.Background(), dir, SyncOptions{AlbumID: 42, Concurrency: 1})
+	if err != nil {
+		t.Fatalf("SyncDir returned error: %v", err)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Path != "a__b.jpg" {
+		t.Fatalf("expected the colliding name to fail, got %+v", result.Failed)
+	}
+	result.Failed = nil
+	want := Result{
+		Uploaded:     []string{"a/b.jpg"},
+		Unchanged:    []string{"same.jpg"},
+		Deduplicated: []string{"linked.jpg"},
+	}
+	if !reflect.DeepEqual(*result, want) {
+		t.Fatalf("unexpected result: %+v", *result)
+	}
+	if deletes != 0 {
+		t.Fatalf("expected no deletions, got %d", deletes)
+	}
+}
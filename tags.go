@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // TagsService handles tag operations.
@@ -38,6 +39,36 @@ type TagFilesOptions struct {
 
 	// Limit is the number of items per page.
 	Limit int
+
+	// IncludeAggregates asks the server to compute TotalSize for all
+	// matching files, not just the current page.
+	IncludeAggregates bool
+
+	// Sort is the result ordering. Defaults to SortCreatedDesc.
+	Sort SortOrder
+}
+
+// Validate checks the options for invalid fields.
+func (opts *CreateTagOptions) Validate() error {
+	var v validator
+	v.check(strings.TrimSpace(opts.Name) != "", "Name", "is required")
+	v.check(opts.Color == "" || isHexColor(opts.Color), "Color", "must be a hex color like #FF5733")
+	return v.err()
+}
+
+// Validate checks the options for invalid fields.
+func (opts *UpdateTagOptions) Validate() error {
+	var v validator
+	v.check(opts.Color == "" || isHexColor(opts.Color), "Color", "must be a hex color like #FF5733")
+	return v.err()
+}
+
+// Validate checks the options for invalid fields.
+func (opts *TagFilesOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	v.check(opts.Sort == "" || opts.Sort.Valid(), "Sort", "has unsupported value %q", opts.Sort)
+	return v.err()
 }
 
 // List returns all tags for the authenticated user.
@@ -51,7 +82,9 @@ type TagFilesOptions struct {
 //	for _, tag := range tags {
 //	    fmt.Printf("%s (%d files)\n", tag.Name, tag.FileCount)
 //	}
-func (s *TagsService) List(ctx context.Context) ([]Tag, error) {
+func (s *TagsService) List(ctx context.Context, callOpts ...CallOption) ([]Tag, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
 	var tags []Tag
 	if err := s.client.request(ctx, http.MethodGet, "/api/tags", nil, &tags); err != nil {
 		return nil, err
@@ -60,6 +93,28 @@ func (s *TagsService) List(ctx context.Context) ([]Tag, error) {
 	return tags, nil
 }
 
+// ListWithAggregates returns all tags together with the combined size of
+// the tagged files, computed by the server. With aggregates requested, the
+// server wraps the tags in an object next to total_size.
+//
+// Example:
+//
+//	resp, err := client.Tags.ListWithAggregates(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d tags, %d bytes\n", len(resp.Tags), resp.TotalSize)
+func (s *TagsService) ListWithAggregates(ctx context.Context, callOpts ...CallOption) (*TagsListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
+	var resp TagsListResponse
+	if err := s.client.request(ctx, http.MethodGet, "/api/tags?include_aggregates=true", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // Create creates a new tag.
 //
 // Example:
@@ -72,9 +127,14 @@ func (s *TagsService) List(ctx context.Context) ([]Tag, error) {
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Created tag: %s (ID: %d)\n", tag.Name, tag.ID)
-func (s *TagsService) Create(ctx context.Context, opts *CreateTagOptions) (*Tag, error) {
-	if opts == nil || opts.Name == "" {
-		return nil, fmt.Errorf("tag name is required")
+func (s *TagsService) Create(ctx context.Context, opts *CreateTagOptions, callOpts ...CallOption) (*Tag, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
+	if opts == nil {
+		opts = &CreateTagOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
 
 	req := struct {
@@ -105,10 +165,15 @@ func (s *TagsService) Create(ctx context.Context, opts *CreateTagOptions) (*Tag,
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Updated tag: %s\n", tag.Name)
-func (s *TagsService) Update(ctx context.Context, tagID int64, opts *UpdateTagOptions) (*Tag, error) {
+func (s *TagsService) Update(ctx context.Context, tagID int64, opts *UpdateTagOptions, callOpts ...CallOption) (*Tag, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
 	if opts == nil {
 		return nil, fmt.Errorf("update options are required")
 	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
 
 	path := fmt.Sprintf("/api/tags/%d", tagID)
 
@@ -136,7 +201,9 @@ func (s *TagsService) Update(ctx context.Context, tagID int64, opts *UpdateTagOp
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (s *TagsService) Delete(ctx context.Context, tagID int64) (*MessageResponse, error) {
+func (s *TagsService) Delete(ctx context.Context, tagID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
 	path := fmt.Sprintf("/api/tags/%d", tagID)
 
 	var resp MessageResponse
@@ -155,7 +222,9 @@ func (s *TagsService) Delete(ctx context.Context, tagID int64) (*MessageResponse
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (s *TagsService) TagFile(ctx context.Context, fileID, tagID int64) (*MessageResponse, error) {
+func (s *TagsService) TagFile(ctx context.Context, fileID, tagID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
 	req := struct {
 		FileID int64 `json:"file_id"`
 		TagID  int64 `json:"tag_id"`
@@ -180,7 +249,9 @@ func (s *TagsService) TagFile(ctx context.Context, fileID, tagID int64) (*Messag
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (s *TagsService) UntagFile(ctx context.Context, fileID, tagID int64) (*MessageResponse, error) {
+func (s *TagsService) UntagFile(ctx context.Context, fileID, tagID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
 	req := struct {
 		FileID int64 `json:"file_id"`
 		TagID  int64 `json:"tag_id"`
@@ -197,6 +268,56 @@ func (s *TagsService) UntagFile(ctx context.Context, fileID, tagID int64) (*Mess
 	return &resp, nil
 }
 
+// TagFiles adds a tag to many files in one request. Files that already
+// have the tag are reported as succeeded.
+//
+// Example:
+//
+//	result, err := client.Tags.TagFiles(ctx, 123, importedIDs)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Tagged: %d, Failed: %d\n", len(result.Succeeded), len(result.Failed))
+func (s *TagsService) TagFiles(ctx context.Context, tagID int64, fileIDs []int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
+	return s.batchTag(ctx, http.MethodPost, tagID, fileIDs)
+}
+
+// UntagFiles removes a tag from many files in one request. Files that do
+// not have the tag are reported as succeeded.
+//
+// Example:
+//
+//	result, err := client.Tags.UntagFiles(ctx, 123, []int64{1, 2, 3})
+func (s *TagsService) UntagFiles(ctx context.Context, tagID int64, fileIDs []int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
+	return s.batchTag(ctx, http.MethodDelete, tagID, fileIDs)
+}
+
+// batchTag adds (POST) or removes (DELETE) a tag on many files.
+func (s *TagsService) batchTag(ctx context.Context, method string, tagID int64, fileIDs []int64) (*BatchResult[int64], error) {
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file ID is required")
+	}
+
+	path := fmt.Sprintf("/api/tags/%d/files", tagID)
+
+	req := struct {
+		FileIDs []int64 `json:"file_ids"`
+	}{
+		FileIDs: fileIDs,
+	}
+
+	var resp batchResponse
+	if err := s.client.request(ctx, method, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.idResult(fileIDs), nil
+}
+
 // GetFiles returns all files with a specific tag.
 //
 // Example:
@@ -208,19 +329,32 @@ func (s *TagsService) UntagFile(ctx context.Context, fileID, tagID int64) (*Mess
 //	for _, file := range resp.Files {
 //	    fmt.Println(file.OriginalName)
 //	}
-func (s *TagsService) GetFiles(ctx context.Context, tagID int64, opts *TagFilesOptions) (*FilesListResponse, error) {
+func (s *TagsService) GetFiles(ctx context.Context, tagID int64, opts *TagFilesOptions, callOpts ...CallOption) (*FilesListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTags, callOpts)
+
 	path := fmt.Sprintf("/api/tags/%d/files", tagID)
 
 	query := url.Values{}
 	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
 		}
 		if opts.Limit > 0 {
 			query.Set("limit", strconv.Itoa(opts.Limit))
 		}
+		if opts.IncludeAggregates {
+			query.Set("include_aggregates", "true")
+		}
+		if opts.Sort != "" {
+			query.Set("sort", string(opts.Sort))
+		}
 	}
 
+	setDefaultLimit(ctx, query)
+
 	if len(query) > 0 {
 		path = path + "?" + query.Encode()
 	}
@@ -0,0 +1,93 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTagAndUntagFilesReportPerItemResults(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.URL.Path != "/api/tags/5/files" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var body struct {
+			FileIDs []int64 `json:"file_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.FileIDs) != 3 {
+			t.Errorf("unexpected body: %+v (%v)", body, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":1,"success":true},
+			{"id":2,"success":false,"status":403,"code":"forbidden","error":"Not your file"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	for _, call := range []func(context.Context, int64, []int64, ...CallOption) (*BatchResult[int64], error){
+		client.Tags.TagFiles,
+		client.Tags.UntagFiles,
+	} {
+		result, err := call(context.Background(), 5, []int64{1, 2, 3})
+		if err != nil {
+			t.Fatalf("returned error: %v", err)
+		}
+		if len(result.Succeeded) != 1 || result.Succeeded[0] != 1 {
+			t.Fatalf("unexpected successes: %v", result.Succeeded)
+		}
+		if len(result.Failed) != 2 {
+			t.Fatalf("expected 2 failures, got %+v", result.Failed)
+		}
+		if f := result.Failed[0]; f.ID != 2 || f.Index != 1 || !IsForbidden(f.Err) || !IsCode(f.Err, "forbidden") {
+			t.Fatalf("unexpected failure for file 2: %+v", f)
+		}
+		if f := result.Failed[1]; f.ID != 3 || f.Index != 2 || f.Err == nil {
+			t.Fatalf("unreported file 3 should fail: %+v", f)
+		}
+	}
+	if len(methods) != 2 || methods[0] != http.MethodPost || methods[1] != http.MethodDelete {
+		t.Fatalf("unexpected methods: %v", methods)
+	}
+
+	if _, err := client.Tags.TagFiles(context.Background(), 5, nil); err == nil {
+		t.Fatal("expected an error for no file IDs")
+	}
+}
+
+func TestTagsListWithAggregatesDecodesTotalSize(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/tags" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("include_aggregates"); got != "true" {
+			t.Errorf("unexpected include_aggregates: %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tags":[{"id":1,"name":"Nature","file_count":3}],"total_size":4096}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Tags.ListWithAggregates(context.Background())
+	if err != nil {
+		t.Fatalf("ListWithAggregates returned error: %v", err)
+	}
+	if len(resp.Tags) != 1 || resp.Tags[0].FileCount != 3 {
+		t.Fatalf("unexpected tags: %+v", resp.Tags)
+	}
+	if resp.TotalSize != 4096 {
+		t.Fatalf("unexpected total size: %d", resp.TotalSize)
+	}
+}
@@ -0,0 +1,417 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// TransformsService handles bulk image transformation operations.
+type TransformsService struct {
+	client *Client
+}
+
+// FitMode controls how an image is resized into the target box.
+type FitMode string
+
+const (
+	// FitCover fills the box, cropping any overflow.
+	FitCover FitMode = "cover"
+
+	// FitContain fits the whole image inside the box, letterboxing if needed.
+	FitContain FitMode = "contain"
+
+	// FitFill stretches the image to the exact box size.
+	FitFill FitMode = "fill"
+
+	// FitInside shrinks the image to fit inside the box, never enlarging it.
+	FitInside FitMode = "inside"
+)
+
+// Valid reports whether m is a known fit mode.
+func (m FitMode) Valid() bool {
+	switch m {
+	case FitCover, FitContain, FitFill, FitInside:
+		return true
+	}
+	return false
+}
+
+// Gravity controls which part of the image is kept when cropping.
+type Gravity string
+
+const (
+	// GravityCenter keeps the center of the image (the default).
+	GravityCenter Gravity = "center"
+
+	// GravitySmart detects faces and salient subjects and keeps them in frame.
+	GravitySmart Gravity = "smart"
+
+	// GravityFocal keeps the file's focal point in frame.
+	// See FilesService.SetFocalPoint.
+	GravityFocal Gravity = "focal"
+)
+
+// Valid reports whether g is a known gravity.
+func (g Gravity) Valid() bool {
+	switch g {
+	case GravityCenter, GravitySmart, GravityFocal:
+		return true
+	}
+	return false
+}
+
+// WatermarkPosition is where a watermark is placed on the image.
+type WatermarkPosition string
+
+const (
+	// WatermarkCenter places the watermark in the center.
+	WatermarkCenter WatermarkPosition = "center"
+
+	// WatermarkTopLeft places the watermark in the top-left corner.
+	WatermarkTopLeft WatermarkPosition = "top_left"
+
+	// WatermarkTopRight places the watermark in the top-right corner.
+	WatermarkTopRight WatermarkPosition = "top_right"
+
+	// WatermarkBottomLeft places the watermark in the bottom-left corner.
+	WatermarkBottomLeft WatermarkPosition = "bottom_left"
+
+	// WatermarkBottomRight places the watermark in the bottom-right corner.
+	WatermarkBottomRight WatermarkPosition = "bottom_right"
+)
+
+// Watermark describes a text or image overlay.
+type Watermark struct {
+	// Text is the watermark text. Either Text or FileID is required.
+	Text string `json:"text,omitempty"`
+
+	// FileID is the ID of an uploaded image to use as the watermark.
+	FileID int64 `json:"file_id,omitempty"`
+
+	// Position is where the watermark is placed (default: bottom right).
+	Position WatermarkPosition `json:"position,omitempty"`
+
+	// Opacity is the watermark opacity between 0 and 1 (default: server side).
+	Opacity float64 `json:"opacity,omitempty"`
+}
+
+// CropRect is a region of the original image, in pixels.
+type CropRect struct {
+	// X is the left edge of the region.
+	X int `json:"x"`
+
+	// Y is the top edge of the region.
+	Y int `json:"y"`
+
+	// Width is the width of the region.
+	Width int `json:"width"`
+
+	// Height is the height of the region.
+	Height int `json:"height"`
+}
+
+// Transform describes an image transformation.
+// Zero-valued fields are left unchanged.
+type Transform struct {
+	// Width is the target width in pixels.
+	Width int `json:"width,omitempty"`
+
+	// Height is the target height in pixels.
+	Height int `json:"height,omitempty"`
+
+	// Fit controls how the image is resized when both Width and Height are set.
+	Fit FitMode `json:"fit,omitempty"`
+
+	// Gravity controls which part of the image is kept when Fit crops it.
+	Gravity Gravity `json:"gravity,omitempty"`
+
+	// Crop cuts a region out of the original before resizing.
+	Crop *CropRect `json:"crop,omitempty"`
+
+	// Format is the output format (e.g., "webp", "avif", "jpeg").
+	Format string `json:"format,omitempty"`
+
+	// Quality is the output quality between 1 and 100, or 0 to leave it
+	// unset.
+	Quality int `json:"quality,omitempty"`
+
+	// Rotate rotates the image clockwise by 90, 180, or 270 degrees.
+	Rotate int `json:"rotate,omitempty"`
+
+	// Blur is the blur radius between 1 and 100, or 0 for no blur.
+	Blur int `json:"blur,omitempty"`
+
+	// Watermark overlays text or an image.
+	Watermark *Watermark `json:"watermark,omitempty"`
+}
+
+// Validate checks the transform for invalid fields.
+func (t Transform) Validate() error {
+	var v validator
+	v.check(t.Width >= 0, "Width", "must not be negative")
+	v.check(t.Height >= 0, "Height", "must not be negative")
+	v.check(t.Fit == "" || t.Fit.Valid(), "Fit", "%q is not a valid fit mode", t.Fit)
+	v.check(t.Gravity == "" || t.Gravity.Valid(), "Gravity", "%q is not a valid gravity", t.Gravity)
+	v.check(t.Quality >= 0 && t.Quality <= 100, "Quality", "must be between 1 and 100")
+	v.check(t.Rotate%90 == 0 && t.Rotate >= 0 && t.Rotate < 360, "Rotate", "must be 0, 90, 180, or 270")
+	v.check(t.Blur >= 0 && t.Blur <= 100, "Blur", "must be between 1 and 100")
+	if t.Crop != nil {
+		v.check(t.Crop.X >= 0 && t.Crop.Y >= 0, "Crop", "must not start at a negative offset")
+		v.check(t.Crop.Width > 0 && t.Crop.Height > 0, "Crop", "must have a positive width and height")
+	}
+	if t.Watermark != nil {
+		v.check(t.Watermark.Text != "" || t.Watermark.FileID > 0, "Watermark", "requires Text or FileID")
+		v.check(t.Watermark.Opacity >= 0 && t.Watermark.Opacity <= 1, "Watermark.Opacity", "must be between 0 and 1")
+	}
+	return v.err()
+}
+
+// ApplyOptions configures a bulk transform job.
+type ApplyOptions struct {
+	// SaveAsNewAlbum stores the transformed copies in a new album instead of
+	// alongside the originals.
+	SaveAsNewAlbum bool
+
+	// AlbumName is the name of the new album when SaveAsNewAlbum is set
+	// (default: server generated from the source album name).
+	AlbumName string
+}
+
+// ApplyToAlbum starts a job that creates a transformed copy of every file in
+// an album. Originals are left untouched. Use client.Jobs.Wait to follow it.
+//
+// Example:
+//
+//	job, err := client.Transforms.ApplyToAlbum(ctx, 42, fimage.Transform{
+//	    Width:     2048,
+//	    Fit:       fimage.FitInside,
+//	    Watermark: &fimage.Watermark{Text: "© Studio North"},
+//	}, &fimage.ApplyOptions{SaveAsNewAlbum: true, AlbumName: "Delivery"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	job, err = client.Jobs.Wait(ctx, job.ID, 0, nil)
+func (s *TransformsService) ApplyToAlbum(ctx context.Context, albumID int64, transform Transform, opts *ApplyOptions, callOpts ...CallOption) (*Job, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTransforms, callOpts)
+
+	if err := transform.Validate(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ApplyOptions{}
+	}
+
+	path := fmt.Sprintf("/api/albums/%d/transform", albumID)
+
+	req := struct {
+		Transform      Transform `json:"transform"`
+		SaveAsNewAlbum bool      `json:"save_as_new_album,omitempty"`
+		AlbumName      string    `json:"album_name,omitempty"`
+	}{
+		Transform:      transform,
+		SaveAsNewAlbum: opts.SaveAsNewAlbum,
+		AlbumName:      strings.TrimSpace(opts.AlbumName),
+	}
+
+	var job Job
+	if err := s.client.featureRequest(ctx, FeatureTransforms, http.MethodPost, path, req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// TransformOption is applied to a transformation URL. Transform and Preset
+// both implement it.
+type TransformOption interface {
+	applyTransform(query url.Values)
+}
+
+// Preset references a named transform preset in a transformation URL.
+//
+// Example:
+//
+//	u := file.TransformURL(fimage.Preset("hero")).String()
+type Preset string
+
+func (p Preset) applyTransform(query url.Values) {
+	query.Set("preset", string(p))
+}
+
+func (t Transform) applyTransform(query url.Values) {
+	setInt := func(key string, v int) {
+		if v != 0 {
+			query.Set(key, strconv.Itoa(v))
+		}
+	}
+	setInt("w", t.Width)
+	setInt("h", t.Height)
+	setInt("q", t.Quality)
+	setInt("rot", t.Rotate)
+	setInt("blur", t.Blur)
+	if t.Fit != "" {
+		query.Set("fit", string(t.Fit))
+	}
+	if t.Gravity != "" {
+		query.Set("gravity", string(t.Gravity))
+	}
+	if t.Format != "" {
+		query.Set("fm", t.Format)
+	}
+	if c := t.Crop; c != nil {
+		query.Set("crop", fmt.Sprintf("%d,%d,%d,%d", c.X, c.Y, c.Width, c.Height))
+	}
+	if wm := t.Watermark; wm != nil {
+		if wm.Text != "" {
+			query.Set("wm_text", wm.Text)
+		}
+		if wm.FileID > 0 {
+			query.Set("wm_file", strconv.FormatInt(wm.FileID, 10))
+		}
+		if wm.Position != "" {
+			query.Set("wm_pos", string(wm.Position))
+		}
+		if wm.Opacity > 0 {
+			query.Set("wm_opacity", strconv.FormatFloat(wm.Opacity, 'f', -1, 64))
+		}
+	}
+}
+
+// TransformBuilder builds an on-the-fly transformation URL for a file.
+// Options can be passed to File.TransformURL or chained:
+//
+//	u := file.TransformURL().Resize(800, 600).Format("webp").Quality(80).String()
+//
+// Chained methods validate their arguments; the first invalid one is reported
+// by Err and URL, while String still returns the URL.
+type TransformBuilder struct {
+	base  string
+	query url.Values
+	err   error
+}
+
+// TransformURL returns a builder for a transformation URL of the file's
+// original image. Options are applied in order; later options override
+// earlier ones.
+//
+// Example:
+//
+//	// Reference a preset and override the output format
+//	u := file.TransformURL(fimage.Preset("hero"), fimage.Transform{Format: "avif"}).String()
+func (f *File) TransformURL(opts ...TransformOption) *TransformBuilder {
+	b := &TransformBuilder{base: f.URL, query: url.Values{}}
+	return b.Apply(opts...)
+}
+
+// Apply applies more options to the builder.
+func (b *TransformBuilder) Apply(opts ...TransformOption) *TransformBuilder {
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyTransform(b.query)
+		}
+	}
+	return b
+}
+
+// set validates and applies a single-field transform.
+func (b *TransformBuilder) set(t Transform) *TransformBuilder {
+	if err := t.Validate(); err != nil && b.err == nil {
+		b.err = err
+	}
+	return b.Apply(t)
+}
+
+// setLevel validates and applies a transform that only sets a 1-100 level
+// such as Quality. A Transform treats 0 as unset, so the builder rejects it
+// instead of silently leaving the level out of the URL.
+func (b *TransformBuilder) setLevel(field string, level int, t Transform) *TransformBuilder {
+	if level == 0 && b.err == nil {
+		var v validator
+		v.check(false, field, "must be between 1 and 100")
+		b.err = v.err()
+	}
+	return b.set(t)
+}
+
+// Resize sets the target size in pixels. A zero dimension is derived from
+// the aspect ratio.
+func (b *TransformBuilder) Resize(width, height int) *TransformBuilder {
+	return b.set(Transform{Width: width, Height: height})
+}
+
+// Fit sets how the image is resized into the target box.
+func (b *TransformBuilder) Fit(mode FitMode) *TransformBuilder {
+	return b.set(Transform{Fit: mode})
+}
+
+// Gravity sets which part of the image is kept when Fit crops it.
+func (b *TransformBuilder) Gravity(gravity Gravity) *TransformBuilder {
+	return b.set(Transform{Gravity: gravity})
+}
+
+// Crop cuts a region out of the original before resizing.
+func (b *TransformBuilder) Crop(x, y, width, height int) *TransformBuilder {
+	return b.set(Transform{Crop: &CropRect{X: x, Y: y, Width: width, Height: height}})
+}
+
+// Format sets the output format (e.g., "webp", "avif", "jpeg").
+func (b *TransformBuilder) Format(format string) *TransformBuilder {
+	return b.set(Transform{Format: format})
+}
+
+// Quality sets the output quality between 1 and 100. Other values,
+// including 0, are reported by Err and URL.
+func (b *TransformBuilder) Quality(quality int) *TransformBuilder {
+	return b.setLevel("Quality", quality, Transform{Quality: quality})
+}
+
+// Rotate rotates the image clockwise by 90, 180, or 270 degrees.
+func (b *TransformBuilder) Rotate(degrees int) *TransformBuilder {
+	return b.set(Transform{Rotate: degrees})
+}
+
+// Blur sets the blur radius between 1 and 100. Other values, including 0,
+// are reported by Err and URL.
+func (b *TransformBuilder) Blur(radius int) *TransformBuilder {
+	return b.setLevel("Blur", radius, Transform{Blur: radius})
+}
+
+// Watermark overlays text or an image.
+func (b *TransformBuilder) Watermark(wm Watermark) *TransformBuilder {
+	return b.set(Transform{Watermark: &wm})
+}
+
+// Err returns the first invalid argument passed to a chained method.
+func (b *TransformBuilder) Err() error {
+	return b.err
+}
+
+// URL returns the transformation URL, or an error if a chained method was
+// given an invalid argument.
+func (b *TransformBuilder) URL() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	return b.String(), nil
+}
+
+// String returns the transformation URL. Query parameters already present on
+// the file URL are preserved.
+func (b *TransformBuilder) String() string {
+	u, err := url.Parse(b.base)
+	if err != nil {
+		return b.base
+	}
+
+	query := u.Query()
+	for key, values := range b.query {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
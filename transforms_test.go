@@ -0,0 +1,157 @@
+package fimage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTransformURLAppliesOptionsInOrder(t *testing.T) {
+	t.Parallel()
+
+	file := &File{URL: "https://cdn.f-image.com/u/1/photo.jpg?v=2"}
+
+	got := file.TransformURL(Preset("hero"), Transform{Width: 800, Format: "webp"}, Transform{Format: "avif"}).String()
+
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("invalid URL %q: %v", got, err)
+	}
+	query := u.Query()
+	if u.Path != "/u/1/photo.jpg" {
+		t.Fatalf("unexpected path: %s", u.Path)
+	}
+	if query.Get("v") != "2" || query.Get("preset") != "hero" || query.Get("w") != "800" || query.Get("fm") != "avif" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+}
+
+func TestTransformBuilderChain(t *testing.T) {
+	t.Parallel()
+
+	file := &File{URL: "https://cdn.f-image.com/u/1/photo.jpg"}
+
+	b := file.TransformURL().
+		Resize(800, 600).
+		Fit(FitCover).
+		Crop(10, 20, 300, 200).
+		Format("webp").
+		Quality(80).
+		Rotate(90).
+		Blur(5).
+		Watermark(Watermark{Text: "© Studio", Position: WatermarkBottomRight})
+
+	got, err := b.URL()
+	if err != nil {
+		t.Fatalf("URL returned error: %v", err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("invalid URL %q: %v", got, err)
+	}
+	want := url.Values{
+		"w": {"800"}, "h": {"600"}, "fit": {"cover"}, "crop": {"10,20,300,200"}, "fm": {"webp"},
+		"q": {"80"}, "rot": {"90"}, "blur": {"5"}, "wm_text": {"© Studio"}, "wm_pos": {"bottom_right"},
+	}
+	if u.RawQuery != want.Encode() {
+		t.Fatalf("unexpected query:\n got %s\nwant %s", u.RawQuery, want.Encode())
+	}
+
+	if _, err := file.TransformURL().Quality(150).Rotate(45).URL(); err == nil || !strings.Contains(err.Error(), "Quality") {
+		t.Fatalf("expected Quality validation error, got %v", err)
+	}
+	if _, err := file.TransformURL().Quality(0).URL(); err == nil || !strings.Contains(err.Error(), "Quality") {
+		t.Fatalf("expected Quality(0) to be rejected, got %v", err)
+	}
+	if _, err := file.TransformURL().Blur(0).URL(); err == nil || !strings.Contains(err.Error(), "Blur") {
+		t.Fatalf("expected Blur(0) to be rejected, got %v", err)
+	}
+	if _, err := file.TransformURL().Blur(-1).URL(); err == nil || !strings.Contains(err.Error(), "Blur") {
+		t.Fatalf("expected Blur(-1) to be rejected, got %v", err)
+	}
+}
+
+func TestTransformValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		transform Transform
+		want      string
+	}{
+		{"empty", Transform{}, ""},
+		{"resize", Transform{Width: 2048, Fit: FitInside, Quality: 80, Rotate: 270}, ""},
+		{"negative size", Transform{Width: -1, Height: -1}, "Width,Height"},
+		{"unknown fit", Transform{Fit: "squash"}, "Fit"},
+		{"out of range", Transform{Quality: 101, Blur: 101, Rotate: 45}, "Quality,Rotate,Blur"},
+		{"empty watermark", Transform{Watermark: &Watermark{Opacity: 2}}, "Watermark,Watermark.Opacity"},
+	}
+	for _, tt := range tests {
+		if got := invalidFields(t, tt.transform.Validate()); got != tt.want {
+			t.Errorf("%s: invalid fields = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyToAlbumStartsJob(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method != http.MethodPost || r.URL.Path != "/api/albums/42/transform" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		want := `{"transform":{"width":2048,"fit":"inside","watermark":{"text":"Studio North","position":"bottom_right"}},"save_as_new_album":true,"album_name":"Delivery"}`
+		if string(body) != want {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"job_7","type":"transform","status":"queued","total":120}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	job, err := client.Transforms.ApplyToAlbum(ctx, 42, Transform{
+		Width:     2048,
+		Fit:       FitInside,
+		Watermark: &Watermark{Text: "Studio North", Position: WatermarkBottomRight},
+	}, &ApplyOptions{SaveAsNewAlbum: true, AlbumName: " Delivery "})
+	if err != nil {
+		t.Fatalf("ApplyToAlbum returned error: %v", err)
+	}
+	if job.ID != "job_7" || job.Status != JobQueued || job.Total != 120 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	if _, err := client.Transforms.ApplyToAlbum(ctx, 42, Transform{Rotate: 45}, nil); err == nil {
+		t.Fatal("expected error for invalid transform")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Fatalf("expected an invalid transform to make no request, got %d requests", n)
+	}
+}
+
+func TestTransformGravity(t *testing.T) {
+	t.Parallel()
+
+	file := &File{URL: "https://cdn.example.com/a.jpg"}
+	got := file.TransformURL(Transform{Width: 400, Height: 400, Fit: FitCover, Gravity: GravityFocal}).String()
+	if !strings.Contains(got, "gravity=focal") {
+		t.Fatalf("unexpected URL: %s", got)
+	}
+	if _, err := file.TransformURL().Gravity("north").URL(); err == nil || !strings.Contains(err.Error(), "Gravity") {
+		t.Fatalf("expected Gravity validation error, got %v", err)
+	}
+	if got := invalidFields(t, Transform{Gravity: "north"}.Validate()); got != "Gravity" {
+		t.Fatalf("invalid fields = %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package fimage
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is the default size of the idle connection pool.
+	DefaultMaxIdleConns = 100
+
+	// DefaultMaxIdleConnsPerHost is the default number of idle connections
+	// kept per host. Go's default of 2 causes connection churn when many
+	// requests run in parallel against the API.
+	DefaultMaxIdleConnsPerHost = 32
+
+	// DefaultIdleConnTimeout is how long idle connections are kept by default.
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// TransportConfig tunes connection reuse of the client's HTTP transport.
+// Zero fields keep the SDK defaults.
+type TransportConfig struct {
+	// MaxIdleConns limits idle connections across all hosts
+	// (default: DefaultMaxIdleConns).
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost limits idle connections per host
+	// (default: DefaultMaxIdleConnsPerHost).
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits all connections per host, including active
+	// ones (default: unlimited).
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept
+	// (default: DefaultIdleConnTimeout).
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 forces HTTP/1.1, e.g. behind proxies that mishandle
+	// HTTP/2.
+	DisableHTTP2 bool
+}
+
+// newTransport returns the SDK's default transport: http.DefaultTransport
+// with HTTP/2 enabled and a larger idle pool.
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = DefaultMaxIdleConns
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	t.IdleConnTimeout = DefaultIdleConnTimeout
+	return t
+}
+
+// apply sets the non-zero fields of cfg on t.
+func (cfg TransportConfig) apply(t *http.Transport) {
+	if cfg.MaxIdleConns > 0 {
+		t.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	if cfg.DisableHTTP2 {
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
+
+// WithTransportConfig tunes connection reuse for high-QPS workloads. It
+// applies to the client's *http.Transport, including one supplied with
+// WithHTTPClient if that option comes first; the supplied transport is
+// cloned, not modified. Custom RoundTrippers are left untouched.
+//
+// Example:
+//
+//	client := fimage.NewClient("your-api-token",
+//	    fimage.WithTransportConfig(fimage.TransportConfig{
+//	        MaxIdleConnsPerHost: 64,
+//	        MaxConnsPerHost:     128,
+//	    }),
+//	)
+func WithTransportConfig(cfg TransportConfig) ClientOption {
+	return func(c *Client) {
+		var t *http.Transport
+		switch rt := c.HTTPClient.Transport.(type) {
+		case nil:
+			t = newTransport()
+		case *http.Transport:
+			t = rt.Clone()
+		default:
+			return
+		}
+		cfg.apply(t)
+
+		httpClient := *c.HTTPClient
+		httpClient.Transport = t
+		c.HTTPClient = &httpClient
+	}
+}
@@ -0,0 +1,78 @@
+package fimage
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithTransportConfigClonesSuppliedTransport(t *testing.T) {
+	t.Parallel()
+
+	if tr := NewClient("test-token").HTTPClient.Transport.(*http.Transport); !tr.ForceAttemptHTTP2 || tr.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Fatalf("unexpected default transport: http2=%v idle/host=%d", tr.ForceAttemptHTTP2, tr.MaxIdleConnsPerHost)
+	}
+
+	supplied := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: 2}}
+	client := NewClient("test-token",
+		WithHTTPClient(supplied),
+		WithTransportConfig(TransportConfig{MaxIdleConnsPerHost: 64, MaxConnsPerHost: 128, DisableHTTP2: true}),
+	)
+
+	tr := client.HTTPClient.Transport.(*http.Transport)
+	if tr.MaxIdleConnsPerHost != 64 || tr.MaxConnsPerHost != 128 || tr.ForceAttemptHTTP2 {
+		t.Fatalf("config not applied: %+v", tr)
+	}
+	if supplied.Transport.(*http.Transport).MaxIdleConnsPerHost != 2 {
+		t.Fatal("supplied transport was modified")
+	}
+}
+
+// benchmarkParallelList measures small-GET throughput for a transport config
+// and reports how many connections were opened, i.e. connection churn.
+func benchmarkParallelList(b *testing.B, opts ...ClientOption) {
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"files":[{"id":1,"original_name":"a.jpg"}],"total":1,"page":1,"limit":20}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewClient("test-token", append([]ClientOption{WithBaseURL(server.URL)}, opts...)...)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.SetParallelism(16)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.Files.List(ctx, nil); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.StopTimer()
+	b.ReportMetric(float64(conns.Load()), "conns")
+}
+
+func BenchmarkListParallelDefaultTransport(b *testing.B) {
+	benchmarkParallelList(b)
+}
+
+func BenchmarkListParallelStdlibTransport(b *testing.B) {
+	benchmarkParallelList(b, WithHTTPClient(&http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}))
+}
+
+func BenchmarkListParallelSmallPool(b *testing.B) {
+	benchmarkParallelList(b, WithTransportConfig(TransportConfig{MaxIdleConnsPerHost: 2}))
+}
@@ -20,6 +20,17 @@ type TrashListOptions struct {
 
 	// Limit is the number of items per page.
 	Limit int
+
+	// IncludeAggregates asks the server to compute TotalSize for all
+	// matching files, not just the current page.
+	IncludeAggregates bool
+}
+
+// Validate checks the options for invalid fields.
+func (opts *TrashListOptions) Validate() error {
+	var v validator
+	v.paging(opts.Page, opts.Limit)
+	return v.err()
 }
 
 // List returns all files in the trash.
@@ -33,18 +44,28 @@ type TrashListOptions struct {
 //	for _, file := range resp.Files {
 //	    fmt.Printf("%s (deleted: %s)\n", file.OriginalName, *file.DeletedAt)
 //	}
-func (s *TrashService) List(ctx context.Context, opts *TrashListOptions) (*TrashListResponse, error) {
+func (s *TrashService) List(ctx context.Context, opts *TrashListOptions, callOpts ...CallOption) (*TrashListResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
 	query := url.Values{}
 
 	if opts != nil {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
 		}
 		if opts.Limit > 0 {
 			query.Set("limit", strconv.Itoa(opts.Limit))
 		}
+		if opts.IncludeAggregates {
+			query.Set("include_aggregates", "true")
+		}
 	}
 
+	setDefaultLimit(ctx, query)
+
 	var resp TrashListResponse
 	if err := s.client.requestWithQuery(ctx, "/api/trash", query, &resp); err != nil {
 		return nil, err
@@ -62,7 +83,9 @@ func (s *TrashService) List(ctx context.Context, opts *TrashListOptions) (*Trash
 //	    log.Fatal(err)
 //	}
 //	fmt.Println(resp.Message)
-func (s *TrashService) Restore(ctx context.Context, fileID int64) (*RestoreResponse, error) {
+func (s *TrashService) Restore(ctx context.Context, fileID int64, callOpts ...CallOption) (*RestoreResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
 	path := fmt.Sprintf("/api/trash/%d/restore", fileID)
 
 	var resp RestoreResponse
@@ -77,24 +100,26 @@ func (s *TrashService) Restore(ctx context.Context, fileID int64) (*RestoreRespo
 //
 // Example:
 //
-//	resp, err := client.Trash.RestoreMany(ctx, []int64{1, 2, 3})
+//	result, err := client.Trash.RestoreMany(ctx, []int64{1, 2, 3})
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//	fmt.Printf("Restored: %d, Failed: %d\n", resp.Restored, resp.Failed)
-func (s *TrashService) RestoreMany(ctx context.Context, fileIDs []int64) (*RestoreResponse, error) {
+//	fmt.Printf("Restored: %d, Failed: %d\n", len(result.Succeeded), len(result.Failed))
+func (s *TrashService) RestoreMany(ctx context.Context, fileIDs []int64, callOpts ...CallOption) (*BatchResult[int64], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
 	req := struct {
 		FileIDs []int64 `json:"file_ids"`
 	}{
 		FileIDs: fileIDs,
 	}
 
-	var resp RestoreResponse
+	var resp batchResponse
 	if err := s.client.request(ctx, http.MethodPost, "/api/trash/restore", req, &resp); err != nil {
 		return nil, err
 	}
 
-	return &resp, nil
+	return resp.idResult(fileIDs), nil
 }
 
 // PermanentDelete permanently deletes a file from trash.
@@ -111,7 +136,9 @@ func (s *TrashService) RestoreMany(ctx context.Context, fileIDs []int64) (*Resto
 //	} else {
 //	    fmt.Printf("Failed: %s\n", result.Message)
 //	}
-func (s *TrashService) PermanentDelete(ctx context.Context, fileID int64) (*DeleteResult, error) {
+func (s *TrashService) PermanentDelete(ctx context.Context, fileID int64, callOpts ...CallOption) (*DeleteResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
 	path := fmt.Sprintf("/api/trash/%d", fileID)
 
 	var result DeleteResult
@@ -122,7 +149,8 @@ func (s *TrashService) PermanentDelete(ctx context.Context, fileID int64) (*Dele
 	return &result, nil
 }
 
-// Empty permanently deletes all files from trash.
+// Empty permanently deletes all files from trash in a single request. For
+// large trashes, use EmptyInBatches.
 // This action cannot be undone.
 //
 // Example:
@@ -135,7 +163,9 @@ func (s *TrashService) PermanentDelete(ctx context.Context, fileID int64) (*Dele
 //	if result.FailedCount > 0 {
 //	    fmt.Printf("Failed: %d files (may have active share links)\n", result.FailedCount)
 //	}
-func (s *TrashService) Empty(ctx context.Context) (*DeleteResult, error) {
+func (s *TrashService) Empty(ctx context.Context, callOpts ...CallOption) (*DeleteResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
 	var result DeleteResult
 	if err := s.client.request(ctx, http.MethodDelete, "/api/trash/empty", nil, &result); err != nil {
 		return nil, err
@@ -143,3 +173,124 @@ func (s *TrashService) Empty(ctx context.Context) (*DeleteResult, error) {
 
 	return &result, nil
 }
+
+// DefaultEmptyChunkSize is the number of files EmptyInBatches deletes per
+// request unless EmptyOptions.ChunkSize is set.
+const DefaultEmptyChunkSize = 1000
+
+// maxEmptyChunkSize is the largest batch accepted by the API.
+const maxEmptyChunkSize = 10000
+
+// EmptyOptions configures EmptyInBatches.
+type EmptyOptions struct {
+	// ChunkSize is the number of files deleted per request
+	// (default: DefaultEmptyChunkSize, max 10000).
+	ChunkSize int
+
+	// Cursor resumes an interrupted run. Pass the Cursor of the last
+	// EmptyProgress that was reported.
+	Cursor string
+
+	// OnProgress, if set, is called after each batch.
+	OnProgress func(EmptyProgress)
+}
+
+// Validate checks the options for invalid fields.
+func (opts *EmptyOptions) Validate() error {
+	var v validator
+	v.check(opts.ChunkSize >= 0 && opts.ChunkSize <= maxEmptyChunkSize, "ChunkSize", "must be between 0 and %d", maxEmptyChunkSize)
+	return v.err()
+}
+
+// EmptyProgress reports the progress of EmptyInBatches.
+type EmptyProgress struct {
+	// Cursor marks the position after the batch that was just deleted.
+	// Set it as EmptyOptions.Cursor to resume from here.
+	Cursor string
+
+	// Deleted is the number of files deleted so far in this run.
+	Deleted int
+
+	// Failed is the number of files that could not be deleted so far.
+	Failed int
+
+	// Remaining is the number of trashed files left after Cursor.
+	Remaining int64
+}
+
+// EmptyInBatches permanently deletes all files from trash in batches of
+// ChunkSize files, so large trashes do not exceed request timeouts. If it
+// fails part-way, the returned result covers the batches that completed and
+// the error reports the cursor to resume from.
+// This action cannot be undone.
+//
+// Example:
+//
+//	opts := &fimage.EmptyOptions{
+//	    ChunkSize: 5000,
+//	    Cursor:    loadCheckpoint(), // empty on the first run
+//	    OnProgress: func(p fimage.EmptyProgress) {
+//	        saveCheckpoint(p.Cursor)
+//	        fmt.Printf("\rdeleted %d, %d left", p.Deleted, p.Remaining)
+//	    },
+//	}
+//	result, err := client.Trash.EmptyInBatches(ctx, opts)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("\nDeleted: %d, Failed: %d\n", result.DeletedCount, result.FailedCount)
+func (s *TrashService) EmptyInBatches(ctx context.Context, opts *EmptyOptions, callOpts ...CallOption) (*DeleteResult, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceTrash, callOpts)
+
+	if opts == nil {
+		opts = &EmptyOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultEmptyChunkSize
+	}
+
+	total := &DeleteResult{Success: true}
+	cursor := opts.Cursor
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(chunkSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var batch struct {
+			DeleteResult
+			NextCursor string `json:"next_cursor"`
+			Remaining  int64  `json:"remaining"`
+		}
+		path := "/api/trash/empty?" + query.Encode()
+		if err := s.client.request(ctx, http.MethodDelete, path, nil, &batch); err != nil {
+			total.Success = false
+			return total, fmt.Errorf("failed to empty trash (resume from cursor %q): %w", cursor, err)
+		}
+
+		total.DeletedCount += batch.DeletedCount
+		total.FailedCount += batch.FailedCount
+		total.FailedDeletions = append(total.FailedDeletions, batch.FailedDeletions...)
+		total.Message = batch.Message
+		cursor = batch.NextCursor
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(EmptyProgress{
+				Cursor:    cursor,
+				Deleted:   total.DeletedCount,
+				Failed:    total.FailedCount,
+				Remaining: batch.Remaining,
+			})
+		}
+		if cursor == "" {
+			total.Success = total.FailedCount == 0
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package fimage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmptyInBatchesReportsProgressAndResumes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/trash/empty" || r.URL.Query().Get("limit") != "2" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"deleted_count":2,"next_cursor":"c1","remaining":3}`))
+		case "c1":
+			_, _ = w.Write([]byte(`{"deleted_count":1,"failed_count":1,"failed_deletions":[{"file_id":4}],"next_cursor":"c2","remaining":1}`))
+		case "c2":
+			_, _ = w.Write([]byte(`{"deleted_count":1,"next_cursor":"","remaining":0}`))
+		default:
+			t.Errorf("unexpected cursor: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	var progress []EmptyProgress
+	result, err := client.Trash.EmptyInBatches(context.Background(), &EmptyOptions{
+		ChunkSize:  2,
+		OnProgress: func(p EmptyProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("EmptyInBatches returned error: %v", err)
+	}
+	if result.DeletedCount != 4 || result.FailedCount != 1 || len(result.FailedDeletions) != 1 || result.Success {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(progress) != 3 || progress[1].Cursor != "c2" || progress[1].Deleted != 3 || progress[2].Remaining != 0 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+
+	result, err = client.Trash.EmptyInBatches(context.Background(), &EmptyOptions{ChunkSize: 2, Cursor: "c2"})
+	if err != nil {
+		t.Fatalf("resumed EmptyInBatches returned error: %v", err)
+	}
+	if result.DeletedCount != 1 || !result.Success {
+		t.Fatalf("unexpected resumed result: %+v", result)
+	}
+}
@@ -73,9 +73,16 @@ type File struct {
 	// ID is the unique identifier of the file.
 	ID int64 `json:"id"`
 
-	// AlbumID is the ID of the album this file belongs to (if any).
+	// AlbumID is the ID of the album this file belongs to (if any). With
+	// multi-album membership it is the album the file was uploaded or moved
+	// into; see AlbumIDs for every album.
 	AlbumID *int64 `json:"album_id,omitempty"`
 
+	// AlbumIDs are the IDs of all albums the file is linked into. Servers
+	// without multi-album membership leave it empty; use InAlbum to check
+	// membership on either kind of server.
+	AlbumIDs []int64 `json:"album_ids,omitempty"`
+
 	// AlbumName is the name of the album (if any).
 	AlbumName *string `json:"album_name,omitempty"`
 
@@ -85,6 +92,9 @@ type File struct {
 	// Description is the file description.
 	Description string `json:"description"`
 
+	// AltText is the accessibility text describing the image.
+	AltText string `json:"alt_text,omitempty"`
+
 	// URL is the direct URL to the original image.
 	URL string `json:"url"`
 
@@ -111,6 +121,143 @@ type File struct {
 
 	// DeletedAt is the soft deletion timestamp (for trash items).
 	DeletedAt *string `json:"deleted_at,omitempty"`
+
+	// Location is the GPS position the image was taken at (if known).
+	Location *GeoPoint `json:"location,omitempty"`
+
+	// Tags are the tags assigned to the file. They are included by
+	// FilesService.Get, and by List and Search with IncludeTags.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// FocalPoint is the subject position used for focal-point cropping (if set).
+	FocalPoint *FocalPoint `json:"focal_point,omitempty"`
+
+	// Attributes are application-defined key/value pairs (e.g., SKU, order ID).
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// GeoPoint is a GPS coordinate.
+type GeoPoint struct {
+	// Latitude in decimal degrees.
+	Latitude float64 `json:"latitude"`
+
+	// Longitude in decimal degrees.
+	Longitude float64 `json:"longitude"`
+
+	// Altitude in meters above sea level (if known).
+	Altitude *float64 `json:"altitude,omitempty"`
+}
+
+// BoundingBox is a rectangular geographic area.
+type BoundingBox struct {
+	// North is the northern latitude edge.
+	North float64 `json:"north"`
+
+	// South is the southern latitude edge.
+	South float64 `json:"south"`
+
+	// East is the eastern longitude edge.
+	East float64 `json:"east"`
+
+	// West is the western longitude edge.
+	West float64 `json:"west"`
+}
+
+// GeoCluster is a group of nearby files returned by a map query.
+type GeoCluster struct {
+	// Center is the centroid of the files in the cluster.
+	Center GeoPoint `json:"center"`
+
+	// Bounds is the area covered by the cluster.
+	Bounds BoundingBox `json:"bounds"`
+
+	// Count is the number of files in the cluster.
+	Count int64 `json:"count"`
+
+	// Cover is a representative file for the cluster.
+	Cover *File `json:"cover,omitempty"`
+}
+
+// GeoListResponse represents the response from a map query.
+type GeoListResponse struct {
+	// Clusters contains groups of nearby files at the requested zoom level.
+	Clusters []GeoCluster `json:"clusters"`
+
+	// Files contains individual files that were not clustered.
+	Files []File `json:"files"`
+
+	// Total is the total number of located files within the bounds.
+	Total int64 `json:"total"`
+}
+
+// IPTC contains IPTC/XMP metadata embedded in an image file.
+type IPTC struct {
+	// Title is a short headline for the image.
+	Title string `json:"title,omitempty"`
+
+	// Caption is the image description.
+	Caption string `json:"caption,omitempty"`
+
+	// Creator is the photographer or author.
+	Creator string `json:"creator,omitempty"`
+
+	// Copyright is the copyright notice.
+	Copyright string `json:"copyright,omitempty"`
+
+	// Credit is the credit line required when publishing the image.
+	Credit string `json:"credit,omitempty"`
+
+	// Source is the original owner of the image.
+	Source string `json:"source,omitempty"`
+
+	// UsageTerms describes how the image may be licensed.
+	UsageTerms string `json:"usage_terms,omitempty"`
+
+	// Keywords are searchable keywords.
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// FocalPoint is a position in an image, relative to its size.
+type FocalPoint struct {
+	// X is the horizontal position as a fraction of the image width, from
+	// 0 (left edge) to 1 (right edge).
+	X float64 `json:"x"`
+
+	// Y is the vertical position as a fraction of the image height, from
+	// 0 (top edge) to 1 (bottom edge).
+	Y float64 `json:"y"`
+}
+
+// ComparisonResult describes how similar two images are.
+type ComparisonResult struct {
+	// FileIDA is the first compared file.
+	FileIDA int64 `json:"file_id_a"`
+
+	// FileIDB is the second compared file.
+	FileIDB int64 `json:"file_id_b"`
+
+	// Similarity is the structural similarity index (SSIM) between 0 and 1,
+	// where 1 means identical.
+	Similarity float64 `json:"similarity"`
+
+	// DiffPercent is the percentage of pixels that differ (0-100).
+	DiffPercent float64 `json:"diff_percent"`
+
+	// DiffURL is a URL to an image highlighting the differences.
+	// It is nil when the images are identical or no diff was produced.
+	DiffURL *string `json:"diff_url,omitempty"`
+}
+
+// AltTextSuggestion is a machine-generated alt text proposal.
+type AltTextSuggestion struct {
+	// Text is the suggested alt text.
+	Text string `json:"text"`
+
+	// Language is the language of the suggestion (BCP 47 tag).
+	Language string `json:"language"`
+
+	// Confidence is the model confidence between 0 and 1.
+	Confidence float64 `json:"confidence"`
 }
 
 // FilesListResponse represents the response from listing files.
@@ -132,6 +279,40 @@ type FilesListResponse struct {
 
 	// Query is the search query (for search results).
 	Query string `json:"query,omitempty"`
+
+	// TotalSize is the combined size in bytes of all matching files.
+	// It is only populated when aggregates are requested.
+	TotalSize int64 `json:"total_size,omitempty"`
+
+	// Warnings lists records skipped by tolerant decoding (see WithTolerantDecoding).
+	Warnings []DecodeWarning `json:"-"`
+}
+
+// TimelineBucket is a group of files created within one period.
+type TimelineBucket struct {
+	// Period identifies the bucket, e.g. "2024-06" for month granularity.
+	Period string `json:"period"`
+
+	// Start is the beginning of the period.
+	Start time.Time `json:"start"`
+
+	// Count is the number of files in the period.
+	Count int64 `json:"count"`
+
+	// Samples are representative files for the period.
+	Samples []File `json:"samples,omitempty"`
+}
+
+// TimelineResponse represents the response from a timeline query.
+type TimelineResponse struct {
+	// Granularity is the bucket size used by the server.
+	Granularity TimelineGranularity `json:"granularity"`
+
+	// Buckets are the date groups, newest first.
+	Buckets []TimelineBucket `json:"buckets"`
+
+	// Total is the total number of files across all buckets.
+	Total int64 `json:"total"`
 }
 
 // Album represents an album.
@@ -148,6 +329,10 @@ type Album struct {
 	// FileCount is the number of files in the album.
 	FileCount int64 `json:"file_count"`
 
+	// ParentID is the ID of the parent album for sub-albums, such as those
+	// created by AlbumsService.AutoSplit.
+	ParentID *int64 `json:"parent_id,omitempty"`
+
 	// CreatedAt is the album creation timestamp.
 	CreatedAt string `json:"created_at"`
 }
@@ -156,6 +341,124 @@ type Album struct {
 type AlbumsListResponse struct {
 	// Albums is the list of albums.
 	Albums []Album `json:"albums"`
+
+	// TotalSize is the combined size in bytes of the files in all albums.
+	// It is only populated when aggregates are requested.
+	TotalSize int64 `json:"total_size,omitempty"`
+}
+
+// AlbumFeed is a feed of the most recent additions to an album.
+type AlbumFeed struct {
+	// AlbumID is the ID of the album.
+	AlbumID int64 `json:"album_id"`
+
+	// Title is the feed title (the album name).
+	Title string `json:"title"`
+
+	// Description is the feed description (the album description).
+	Description string `json:"description"`
+
+	// UpdatedAt is when a file was last added to the album.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Items are the most recent additions, newest first.
+	Items []FeedItem `json:"items"`
+}
+
+// FeedItem is a single file in an album feed.
+type FeedItem struct {
+	// FileID is the ID of the file.
+	FileID int64 `json:"file_id"`
+
+	// Title is the item title (the original filename).
+	Title string `json:"title"`
+
+	// Description is the file description.
+	Description string `json:"description"`
+
+	// URL is the direct URL to the original image.
+	URL string `json:"url"`
+
+	// ThumbnailURL is the URL to the thumbnail variant (if available).
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+
+	// PublishedAt is when the file was added to the album.
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// MetadataImportResult represents the result of an album metadata import.
+type MetadataImportResult struct {
+	// Updated is the number of files whose metadata was updated.
+	Updated int `json:"updated"`
+
+	// Skipped is the number of rows that were skipped.
+	Skipped int `json:"skipped"`
+
+	// Errors contains details about rows that could not be applied.
+	Errors []MetadataImportError `json:"errors,omitempty"`
+}
+
+// MetadataImportError describes a metadata row that could not be applied.
+type MetadataImportError struct {
+	// Row is the 1-indexed row (or array element) in the imported file.
+	Row int `json:"row"`
+
+	// Filename is the filename given in the row.
+	Filename string `json:"filename"`
+
+	// Reason is why the row was not applied.
+	Reason string `json:"reason"`
+}
+
+// ZipEntryStatus describes what happened to a ZIP archive entry.
+type ZipEntryStatus string
+
+const (
+	// ZipEntryImported means the entry was stored as a new file.
+	ZipEntryImported ZipEntryStatus = "imported"
+
+	// ZipEntryDuplicate means the entry matched an existing file, which was
+	// linked to the album instead.
+	ZipEntryDuplicate ZipEntryStatus = "duplicate"
+
+	// ZipEntrySkipped means the entry was not an image or was otherwise ignored.
+	ZipEntrySkipped ZipEntryStatus = "skipped"
+
+	// ZipEntryFailed means the entry could not be processed.
+	ZipEntryFailed ZipEntryStatus = "failed"
+)
+
+// ZipEntryResult is the outcome for a single ZIP archive entry.
+type ZipEntryResult struct {
+	// Name is the path of the entry inside the archive.
+	Name string `json:"name"`
+
+	// Status is the outcome for the entry.
+	Status ZipEntryStatus `json:"status"`
+
+	// FileID is the stored or matched file (if any).
+	FileID *int64 `json:"file_id,omitempty"`
+
+	// Reason explains skipped and failed entries.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ZipImportResponse represents the response from a ZIP import.
+type ZipImportResponse struct {
+	// Imported is the number of new files stored.
+	Imported int `json:"imported"`
+
+	// Duplicates is the number of entries matched to existing files.
+	Duplicates int `json:"duplicates"`
+
+	// Skipped is the number of ignored entries.
+	Skipped int `json:"skipped"`
+
+	// Failed is the number of entries that could not be processed.
+	Failed int `json:"failed"`
+
+	// Entries contains the per-entry results.
+	Entries []ZipEntryResult `json:"entries"`
 }
 
 // ShareLink represents a share link.
@@ -196,6 +499,12 @@ type ShareLink struct {
 	// IsActive indicates if the share link is active.
 	IsActive bool `json:"is_active"`
 
+	// AllowedEmails lists the recipients allowed to access the share (if restricted).
+	AllowedEmails []string `json:"allowed_emails,omitempty"`
+
+	// EmbedDomains lists the websites allowed to embed the share (if restricted).
+	EmbedDomains []string `json:"embed_domains,omitempty"`
+
 	// CreatedAt is the share link creation timestamp.
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -213,6 +522,44 @@ type SharesListResponse struct {
 
 	// Limit is the number of items per page.
 	Limit int `json:"limit"`
+
+	// Warnings lists records skipped by tolerant decoding (see WithTolerantDecoding).
+	Warnings []DecodeWarning `json:"-"`
+}
+
+// ShareRecipientStatus describes the state of an emailed share recipient.
+type ShareRecipientStatus string
+
+const (
+	// ShareRecipientPending means the access code email has not been sent yet.
+	ShareRecipientPending ShareRecipientStatus = "pending"
+
+	// ShareRecipientSent means the access code email was delivered.
+	ShareRecipientSent ShareRecipientStatus = "sent"
+
+	// ShareRecipientBounced means the access code email could not be delivered.
+	ShareRecipientBounced ShareRecipientStatus = "bounced"
+
+	// ShareRecipientAccessed means the recipient has opened the share.
+	ShareRecipientAccessed ShareRecipientStatus = "accessed"
+)
+
+// ShareRecipient represents an email recipient of a restricted share link.
+type ShareRecipient struct {
+	// Email is the recipient email address.
+	Email string `json:"email"`
+
+	// Status is the delivery and access status.
+	Status ShareRecipientStatus `json:"status"`
+
+	// SentAt is when the access code was emailed (if sent).
+	SentAt *time.Time `json:"sent_at,omitempty"`
+
+	// LastAccessedAt is when the recipient last opened the share (if ever).
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+
+	// ViewCount is the number of times the recipient viewed the share.
+	ViewCount int64 `json:"view_count"`
 }
 
 // SharedContent represents the content accessed via a share link.
@@ -248,8 +595,16 @@ type Tag struct {
 	FileCount int64 `json:"file_count"`
 }
 
-// TagsListResponse represents the response from listing tags.
-type TagsListResponse []Tag
+// TagsListResponse represents the response from listing tags with
+// aggregates.
+type TagsListResponse struct {
+	// Tags is the list of tags.
+	Tags []Tag `json:"tags"`
+
+	// TotalSize is the combined size in bytes of all tagged files.
+	// It is only populated when aggregates are requested.
+	TotalSize int64 `json:"total_size,omitempty"`
+}
 
 // TrashListResponse represents the response from listing trash items.
 type TrashListResponse struct {
@@ -264,6 +619,13 @@ type TrashListResponse struct {
 
 	// Limit is the number of items per page.
 	Limit int `json:"limit"`
+
+	// TotalSize is the combined size in bytes of all trashed files.
+	// It is only populated when aggregates are requested.
+	TotalSize int64 `json:"total_size,omitempty"`
+
+	// Warnings lists records skipped by tolerant decoding (see WithTolerantDecoding).
+	Warnings []DecodeWarning `json:"-"`
 }
 
 // DeleteResult represents the result of a delete operation.
@@ -299,28 +661,10 @@ type FailedDeletion struct {
 	ShareLinks []ShareLink `json:"share_links,omitempty"`
 }
 
-// BatchDeleteResponse represents the response from a batch delete operation.
-type BatchDeleteResponse struct {
-	// Deleted is the number of successfully deleted items.
-	Deleted int `json:"deleted"`
-
-	// Failed is the number of items that failed to delete.
-	Failed int `json:"failed"`
-
-	// Message is a human-readable message.
-	Message string `json:"message"`
-}
-
 // RestoreResponse represents the response from a restore operation.
 type RestoreResponse struct {
 	// Message is a human-readable message.
 	Message string `json:"message"`
-
-	// Restored is the number of restored files (for batch restore).
-	Restored int `json:"restored,omitempty"`
-
-	// Failed is the number of files that failed to restore.
-	Failed int `json:"failed,omitempty"`
 }
 
 // MessageResponse represents a simple message response.
@@ -0,0 +1,156 @@
+package fimage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrBatchAborted is reported for batch items that were skipped or
+// interrupted because FailFast stopped the batch after an earlier failure.
+var ErrBatchAborted = errors.New("batch aborted after an earlier failure")
+
+// UploadRequest is a single file of an UploadMany batch. Set either Reader
+// or Open.
+type UploadRequest struct {
+	// Reader supplies the file contents.
+	Reader io.Reader
+
+	// Open opens the file when its upload starts and is closed afterwards,
+	// so large batches do not hold every file open at once.
+	Open func() (io.ReadCloser, error)
+
+	// Options are the upload options for this file.
+	Options *UploadOptions
+}
+
+// UploadManyOptions configures UploadMany.
+type UploadManyOptions struct {
+	// Concurrency is the number of uploads in flight at once
+	// (default: DefaultConcurrency).
+	Concurrency int
+
+	// FailFast cancels the batch after the first failure. Files that were
+	// not attempted, or were interrupted, report ErrBatchAborted.
+	FailFast bool
+}
+
+// UploadResult is a successful upload of an UploadMany batch.
+type UploadResult struct {
+	// Index is the position of the file in the request.
+	Index int
+
+	// Response is the upload response. For duplicates, Response.Data
+	// describes the existing file.
+	Response *UploadResponse
+
+	// SkippedAsDuplicate reports that the server already stored identical
+	// bytes, so no new file was created (a flash upload).
+	SkippedAsDuplicate bool
+
+	// ExistingFileID is the ID of the file the upload was deduplicated
+	// against when SkippedAsDuplicate is true.
+	ExistingFileID int64
+}
+
+// UploadMany uploads files in parallel with a bounded worker pool. Per-file
+// outcomes are reported in the result: successes in request order, and
+// failures with their index. Files the server already stored are reported
+// as successes with SkippedAsDuplicate set, so ingestion pipelines can link
+// them to the existing file instead of treating them as new. It only returns
+// an error for invalid requests; use result.Err() for an aggregate error
+// covering failed files.
+//
+// Example:
+//
+//	var reqs []fimage.UploadRequest
+//	for _, path := range paths {
+//	    path := path
+//	    reqs = append(reqs, fimage.UploadRequest{
+//	        Open:    func() (io.ReadCloser, error) { return os.Open(path) },
+//	        Options: &fimage.UploadOptions{Filename: filepath.Base(path)},
+//	    })
+//	}
+//
+//	result, err := client.Files.UploadMany(ctx, reqs, &fimage.UploadManyOptions{Concurrency: 8})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, up := range result.Succeeded {
+//	    if up.SkippedAsDuplicate {
+//	        fmt.Println(paths[up.Index], "is a duplicate of file", up.ExistingFileID)
+//	        continue
+//	    }
+//	    fmt.Println(paths[up.Index], "→", up.Response.Data.URL)
+//	}
+//	if err := result.Err(); err != nil {
+//	    log.Printf("some uploads failed: %v", err)
+//	}
+func (s *FilesService) UploadMany(ctx context.Context, reqs []UploadRequest, opts *UploadManyOptions, callOpts ...CallOption) (*BatchResult[UploadResult], error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		opts = &UploadManyOptions{}
+	}
+	for i, req := range reqs {
+		if (req.Reader == nil) == (req.Open == nil) {
+			return nil, fmt.Errorf("upload request %d: exactly one of Reader or Open is required", i)
+		}
+	}
+
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	responses := make([]*UploadResponse, len(reqs))
+	errs := forEach(batchCtx, len(reqs), opts.Concurrency, func(ctx context.Context, i int) error {
+		resp, err := s.uploadRequest(ctx, reqs[i])
+		if err != nil {
+			if opts.FailFast {
+				cancel()
+			}
+			return err
+		}
+		responses[i] = resp
+		return nil
+	})
+
+	result := &BatchResult[UploadResult]{}
+	for i, err := range errs {
+		if err == nil {
+			up := UploadResult{Index: i, Response: responses[i]}
+			if data := responses[i].Data; data != nil && data.IsFlash {
+				up.SkippedAsDuplicate = true
+				up.ExistingFileID = data.ID
+			}
+			result.Succeeded = append(result.Succeeded, up)
+			continue
+		}
+		if errors.Is(err, context.Canceled) && batchCtx.Err() != nil && ctx.Err() == nil {
+			err = ErrBatchAborted
+		}
+		result.Failed = append(result.Failed, BatchItemError{Index: i, Err: err})
+	}
+
+	return result, nil
+}
+
+// uploadRequest uploads a single file of an UploadMany batch.
+func (s *FilesService) uploadRequest(ctx context.Context, req UploadRequest) (*UploadResponse, error) {
+	reader := req.Reader
+	if req.Open != nil {
+		rc, err := req.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer rc.Close()
+		reader = rc
+	}
+
+	var opts *UploadOptions
+	if req.Options != nil {
+		o := *req.Options
+		opts = &o
+	}
+	return s.Upload(ctx, reader, opts)
+}
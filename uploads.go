@@ -0,0 +1,222 @@
+package fimage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// UploadSessionStatus describes the state of an upload session.
+type UploadSessionStatus string
+
+const (
+	// UploadSessionPending means the session was created and awaits file bytes.
+	UploadSessionPending UploadSessionStatus = "pending"
+
+	// UploadSessionCompleted means the file was uploaded and has a final ID.
+	UploadSessionCompleted UploadSessionStatus = "completed"
+
+	// UploadSessionExpired means the session expired before it was completed.
+	UploadSessionExpired UploadSessionStatus = "expired"
+)
+
+// UploadSession is a provisional upload that can be referenced before the
+// file bytes have been sent.
+type UploadSession struct {
+	// ProvisionalID is the server-assigned provisional identifier.
+	ProvisionalID string `json:"provisional_id"`
+
+	// ClientID is the client-generated temporary identifier.
+	ClientID string `json:"client_id"`
+
+	// Status is the session state.
+	Status UploadSessionStatus `json:"status"`
+
+	// FileID is the final file ID once the upload completes.
+	FileID *int64 `json:"file_id,omitempty"`
+
+	// URL is the final file URL once the upload completes.
+	URL *string `json:"url,omitempty"`
+
+	// ExpiresAt is when a pending session expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// CreatedAt is the session creation timestamp.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeginUploadOptions contains options for starting an upload session.
+type BeginUploadOptions struct {
+	// ClientID is a client-generated temporary ID used to reference the file
+	// in your own records. A random ID is generated when empty.
+	ClientID string
+
+	// Filename is the name to use for the uploaded file.
+	Filename string
+
+	// Description is an optional description for the file.
+	Description string
+
+	// AlbumID is the optional album to add the file to.
+	AlbumID *int64
+
+	// Size is the expected file size in bytes (optional).
+	Size int64
+}
+
+// Validate checks the options for invalid fields.
+func (opts *BeginUploadOptions) Validate() error {
+	var v validator
+	v.check(opts.Size >= 0, "Size", "must not be negative")
+	return v.err()
+}
+
+// BeginUpload starts an upload session and returns a provisional ID
+// immediately, before any file bytes are sent. This lets offline-first
+// clients reference the file right away and finish the upload later with
+// CompleteUpload.
+//
+// Example:
+//
+//	session, err := client.Files.BeginUpload(ctx, &fimage.BeginUploadOptions{
+//	    ClientID: "local-7f3a",
+//	    Filename: "photo.jpg",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// ... later, when connectivity is available
+//	resp, err := client.Files.CompleteUpload(ctx, session.ProvisionalID, file)
+func (s *FilesService) BeginUpload(ctx context.Context, opts *BeginUploadOptions, callOpts ...CallOption) (*UploadSession, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if opts == nil {
+		opts = &BeginUploadOptions{}
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	clientID := opts.ClientID
+	if clientID == "" {
+		var err error
+		clientID, err = newClientID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := struct {
+		ClientID    string `json:"client_id"`
+		Filename    string `json:"filename,omitempty"`
+		Description string `json:"description,omitempty"`
+		AlbumID     *int64 `json:"album_id,omitempty"`
+		Size        int64  `json:"size,omitempty"`
+	}{
+		ClientID:    clientID,
+		Filename:    opts.Filename,
+		Description: opts.Description,
+		AlbumID:     opts.AlbumID,
+		Size:        opts.Size,
+	}
+
+	var session UploadSession
+	if err := s.client.request(ctx, http.MethodPost, "/api/files/uploads", req, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// CompleteUpload sends the file bytes for an upload session and finalizes it.
+func (s *FilesService) CompleteUpload(ctx context.Context, provisionalID string, reader io.Reader, callOpts ...CallOption) (*UploadResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if provisionalID == "" {
+		return nil, fmt.Errorf("provisional ID is required")
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+
+	path := fmt.Sprintf("/api/files/uploads/%s", url.PathEscape(provisionalID))
+
+	respBody, err := s.client.uploadMultipart(ctx, path, reader, "upload", nil, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp UploadResponse
+	if err := s.client.unmarshalJSON(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetUploadSession returns the current state of an upload session.
+func (s *FilesService) GetUploadSession(ctx context.Context, provisionalID string, callOpts ...CallOption) (*UploadSession, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	if provisionalID == "" {
+		return nil, fmt.Errorf("provisional ID is required")
+	}
+
+	path := fmt.Sprintf("/api/files/uploads/%s", url.PathEscape(provisionalID))
+
+	var session UploadSession
+	if err := s.client.request(ctx, http.MethodGet, path, nil, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// ResolveUploads maps provisional IDs to final file IDs. Sessions that have
+// not completed yet are omitted from the result.
+//
+// Example:
+//
+//	ids, err := client.Files.ResolveUploads(ctx, []string{"up_1", "up_2"})
+//	for provisional, fileID := range ids {
+//	    fmt.Printf("%s -> %d\n", provisional, fileID)
+//	}
+func (s *FilesService) ResolveUploads(ctx context.Context, provisionalIDs []string, callOpts ...CallOption) (map[string]int64, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	req := struct {
+		ProvisionalIDs []string `json:"provisional_ids"`
+	}{
+		ProvisionalIDs: provisionalIDs,
+	}
+
+	var resp struct {
+		Sessions []UploadSession `json:"sessions"`
+	}
+	if err := s.client.request(ctx, http.MethodPost, "/api/files/uploads/resolve", req, &resp); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int64, len(resp.Sessions))
+	for _, session := range resp.Sessions {
+		if session.FileID != nil {
+			ids[session.ProvisionalID] = *session.FileID
+		}
+	}
+
+	return ids, nil
+}
+
+// newClientID returns a random temporary ID for an upload session.
+func newClientID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate client ID: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,151 @@
+package fimage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBeginUploadGeneratesClientID(t *testing.T) {
+	t.Parallel()
+
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/uploads" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			ClientID string `json:"client_id"`
+			Filename string `json:"filename"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		sent = append(sent, req.ClientID)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"provisional_id": "up_1",
+			"client_id":      req.ClientID,
+			"status":         "pending",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	session, err := client.Files.BeginUpload(context.Background(), &BeginUploadOptions{Filename: "photo.jpg"})
+	if err != nil {
+		t.Fatalf("BeginUpload returned error: %v", err)
+	}
+	if len(sent[0]) != 32 {
+		t.Fatalf("expected a generated 32-character client ID, got %q", sent[0])
+	}
+	if session.ProvisionalID != "up_1" || session.ClientID != sent[0] || session.Status != UploadSessionPending {
+		t.Fatalf("unexpected session: %+v", session)
+	}
+
+	if _, err := client.Files.BeginUpload(context.Background(), &BeginUploadOptions{ClientID: "local-7f3a"}); err != nil {
+		t.Fatalf("BeginUpload returned error: %v", err)
+	}
+	if sent[1] != "local-7f3a" {
+		t.Fatalf("expected the given client ID to be sent, got %q", sent[1])
+	}
+}
+
+func TestCompleteUploadSendsFileToSession(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.EscapedPath() != "/api/files/uploads/up%2F1" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("missing file part: %v", err)
+		} else {
+			data, _ := io.ReadAll(file)
+			if string(data) != "image bytes" {
+				t.Errorf("unexpected file bytes: %q", data)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"id":42}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	resp, err := client.Files.CompleteUpload(context.Background(), "up/1", strings.NewReader("image bytes"))
+	if err != nil {
+		t.Fatalf("CompleteUpload returned error: %v", err)
+	}
+	if resp.Data.ID != 42 {
+		t.Fatalf("unexpected file ID: %d", resp.Data.ID)
+	}
+}
+
+func TestGetUploadSessionEscapesProvisionalID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.EscapedPath() != "/api/files/uploads/up%2F1%20a" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"provisional_id":"up/1 a","status":"completed","file_id":7,"url":"https://cdn.example.com/7.jpg"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	session, err := client.Files.GetUploadSession(context.Background(), "up/1 a")
+	if err != nil {
+		t.Fatalf("GetUploadSession returned error: %v", err)
+	}
+	if session.Status != UploadSessionCompleted || session.GetFileID() != 7 {
+		t.Fatalf("unexpected session: %+v", session)
+	}
+
+	if _, err := client.Files.GetUploadSession(context.Background(), ""); err == nil {
+		t.Fatal("expected error for empty provisional ID")
+	}
+}
+
+func TestResolveUploadsOmitsPendingSessions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/files/uploads/resolve" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req struct {
+			ProvisionalIDs []string `json:"provisional_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if strings.Join(req.ProvisionalIDs, ",") != "up_1,up_2" {
+			t.Errorf("unexpected provisional IDs: %v", req.ProvisionalIDs)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sessions":[
+			{"provisional_id":"up_1","status":"completed","file_id":11},
+			{"provisional_id":"up_2","status":"pending","file_id":null}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithHTTPClient(server.Client()))
+
+	ids, err := client.Files.ResolveUploads(context.Background(), []string{"up_1", "up_2"})
+	if err != nil {
+		t.Fatalf("ResolveUploads returned error: %v", err)
+	}
+	if len(ids) != 1 || ids["up_1"] != 11 {
+		t.Fatalf("unexpected IDs: %v", ids)
+	}
+}
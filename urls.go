@@ -0,0 +1,166 @@
+package fimage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ErrUnrecognizedURL is returned by ParseShareURL and ParseFileURL for URLs
+// that are not F-Image share or file links.
+var ErrUnrecognizedURL = errors.New("fimage: unrecognized URL")
+
+// maxTokenLength is the longest share token accepted by ParseShareURL.
+const maxTokenLength = 128
+
+// ParseShareURL extracts the share token from a share link, for use with
+// Share.Access. Any host is accepted so links on custom domains resolve too.
+// The recognized formats are:
+//
+//	https://f-image.com/s/{token}
+//	https://f-image.com/s/{token}/embed
+//	https://f-image.com/share/{token}      (legacy)
+//	https://f-image.com/shared?token={token} (legacy)
+//
+// Example:
+//
+//	token, err := fimage.ParseShareURL(pasted)
+//	if err != nil {
+//	    return err
+//	}
+//	content, err := client.Share.Access(ctx, token)
+func ParseShareURL(rawURL string) (string, error) {
+	u, err := parseLinkURL(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	var token string
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case segments[0] == "shared" && len(segments) == 1:
+		token = u.Query().Get("token")
+	case segments[0] == "s" && (len(segments) == 2 || (len(segments) == 3 && segments[2] == "embed")),
+		segments[0] == "share" && len(segments) == 2:
+		token = segments[1]
+	}
+	if !validLinkToken(token) {
+		return "", fmt.Errorf("%w: %q is not a share link", ErrUnrecognizedURL, rawURL)
+	}
+
+	return token, nil
+}
+
+// validLinkToken reports whether s has the form of a share token or a
+// stored file name.
+func validLinkToken(s string) bool {
+	if s == "" || len(s) > maxTokenLength {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// FileURL identifies the file a link points to.
+type FileURL struct {
+	// ID is the file ID for dashboard links, or 0 for image URLs.
+	ID int64
+
+	// Key is the stored name of the file for image URLs (e.g. "abc123"),
+	// or "" for dashboard links.
+	Key string
+
+	// Variant is the size variant an image URL serves.
+	Variant Variant
+
+	// Ext is the file extension of an image URL without the dot
+	// (e.g. "jpg").
+	Ext string
+}
+
+// variantSuffixes maps stored name suffixes to the variants they serve.
+var variantSuffixes = map[string]Variant{
+	"_m": VariantMedium,
+	"_t": VariantThumbnail,
+}
+
+// ParseFileURL identifies the file a link points to. Any host is accepted so
+// links on custom domains resolve too, and transformation and signature
+// query parameters are ignored. The recognized formats are:
+//
+//	https://i.f-image.com/images/{key}.{ext}     (original)
+//	https://i.f-image.com/images/{key}_m.{ext}   (medium)
+//	https://i.f-image.com/images/{key}_t.{ext}   (thumbnail)
+//	https://f-image.com/files/{id}               (dashboard)
+//
+// Example:
+//
+//	ref, err := fimage.ParseFileURL(pasted)
+//	if err != nil {
+//	    return err
+//	}
+//	if ref.ID != 0 {
+//	    file, err := client.Files.Get(ctx, ref.ID)
+//	    // ...
+//	} else {
+//	    fmt.Printf("stored file %s (%s)\n", ref.Key, ref.Variant)
+//	}
+func ParseFileURL(rawURL string) (*FileURL, error) {
+	u, err := parseLinkURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) != 2 {
+		return nil, fmt.Errorf("%w: %q is not a file link", ErrUnrecognizedURL, rawURL)
+	}
+
+	switch segments[0] {
+	case "files":
+		id, err := strconv.ParseInt(segments[1], 10, 64)
+		if err != nil || id <= 0 {
+			break
+		}
+		return &FileURL{ID: id}, nil
+
+	case "images":
+		ext := path.Ext(segments[1])
+		key := strings.TrimSuffix(segments[1], ext)
+		ref := &FileURL{Key: key, Variant: VariantOriginal, Ext: strings.TrimPrefix(ext, ".")}
+		for suffix, variant := range variantSuffixes {
+			if strings.HasSuffix(key, suffix) {
+				ref.Key, ref.Variant = strings.TrimSuffix(key, suffix), variant
+				break
+			}
+		}
+		if ref.Ext == "" || !validLinkToken(ref.Key) {
+			break
+		}
+		return ref, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q is not a file link", ErrUnrecognizedURL, rawURL)
+}
+
+// parseLinkURL parses an absolute http(s) URL, tolerating the surrounding
+// whitespace of pasted links.
+func parseLinkURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnrecognizedURL, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q is not an http(s) URL", ErrUnrecognizedURL, rawURL)
+	}
+	return u, nil
+}
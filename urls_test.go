@@ -0,0 +1,69 @@
+package fimage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseShareURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url   string
+		token string
+	}{
+		{"https://f-image.com/s/abc123", "abc123"},
+		{"  https://f-image.com/s/abc123/  ", "abc123"},
+		{"https://f-image.com/s/abc123/embed", "abc123"},
+		{"https://photos.example.com/s/Xy-9_z", "Xy-9_z"},
+		{"https://f-image.com/share/abc123", "abc123"},
+		{"https://f-image.com/shared?token=abc123", "abc123"},
+		{"https://f-image.com/s/", ""},
+		{"https://f-image.com/s/abc123/other", ""},
+		{"https://f-image.com/files/123", ""},
+		{"f-image.com/s/abc123", ""},
+		{"ftp://f-image.com/s/abc123", ""},
+	}
+	for _, tt := range tests {
+		token, err := ParseShareURL(tt.url)
+		if tt.token == "" {
+			if !errors.Is(err, ErrUnrecognizedURL) {
+				t.Errorf("ParseShareURL(%q) error = %v, want ErrUnrecognizedURL", tt.url, err)
+			}
+			continue
+		}
+		if err != nil || token != tt.token {
+			t.Errorf("ParseShareURL(%q) = %q, %v, want %q", tt.url, token, err, tt.token)
+		}
+	}
+}
+
+func TestParseFileURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url  string
+		want *FileURL
+	}{
+		{"https://i.f-image.com/images/abc123.jpg", &FileURL{Key: "abc123", Variant: VariantOriginal, Ext: "jpg"}},
+		{"https://i.f-image.com/images/abc123_m.jpg", &FileURL{Key: "abc123", Variant: VariantMedium, Ext: "jpg"}},
+		{"https://cdn.example.com/images/abc123_t.webp?w=200&sig=x", &FileURL{Key: "abc123", Variant: VariantThumbnail, Ext: "webp"}},
+		{"https://f-image.com/files/42", &FileURL{ID: 42}},
+		{"https://f-image.com/files/abc", nil},
+		{"https://i.f-image.com/images/abc123", nil},
+		{"https://i.f-image.com/logos/marriott.com", nil},
+		{"https://f-image.com/s/abc123", nil},
+	}
+	for _, tt := range tests {
+		got, err := ParseFileURL(tt.url)
+		if tt.want == nil {
+			if !errors.Is(err, ErrUnrecognizedURL) {
+				t.Errorf("ParseFileURL(%q) error = %v, want ErrUnrecognizedURL", tt.url, err)
+			}
+			continue
+		}
+		if err != nil || *got != *tt.want {
+			t.Errorf("ParseFileURL(%q) = %+v, %v, want %+v", tt.url, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package fimage
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// maxPageLimit is the largest page size accepted by the API.
+const maxPageLimit = 100
+
+// FieldError describes a single invalid option field.
+type FieldError struct {
+	// Field is the name of the invalid field.
+	Field string
+
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationError is returned when an options struct has invalid fields.
+// It lists every invalid field, not just the first one.
+type ValidationError struct {
+	// Errors contains one entry per invalid field.
+	Errors []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid options: " + strings.Join(msgs, "; ")
+}
+
+// validator collects field errors.
+type validator struct {
+	errs []FieldError
+}
+
+// check records a field error when ok is false.
+func (v *validator) check(ok bool, field, format string, args ...interface{}) {
+	if !ok {
+		v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+// paging validates common page and limit fields.
+func (v *validator) paging(page, limit int) {
+	v.check(page >= 0, "Page", "must not be negative")
+	v.check(limit >= 0 && limit <= maxPageLimit, "Limit", "must be between 0 and %d", maxPageLimit)
+}
+
+// err returns a *ValidationError if any field errors were recorded.
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+// isHexColor reports whether s is a #RGB or #RRGGBB color.
+func isHexColor(s string) bool {
+	if !strings.HasPrefix(s, "#") || (len(s) != 4 && len(s) != 7) {
+		return false
+	}
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isEmail reports whether s is a bare email address such as
+// "bob@example.com", without a display name or angle brackets.
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s && strings.Contains(s[strings.LastIndex(s, "@")+1:], ".")
+}
+
+// isDomain reports whether s is a host name such as "example.com", without
+// a scheme, port, or path.
+func isDomain(s string) bool {
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			switch {
+			case r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package fimage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// variantChain is the fallback order from the smallest variant to the
+// original.
+var variantChain = []Variant{VariantThumbnail, VariantMedium, VariantOriginal}
+
+// VariantURL returns the URL of a stored variant and whether it is available.
+func (f *File) VariantURL(v Variant) (string, bool) {
+	switch v {
+	case VariantOriginal:
+		return f.URL, f.URL != ""
+	case VariantMedium:
+		if f.MediumURL != nil && *f.MediumURL != "" {
+			return *f.MediumURL, true
+		}
+	case VariantThumbnail:
+		if f.ThumbnailURL != nil && *f.ThumbnailURL != "" {
+			return *f.ThumbnailURL, true
+		}
+	}
+	return "", false
+}
+
+// BestURL returns the URL of the first available preferred variant. When
+// none is available it falls back along thumbnail → medium → original,
+// starting after the last preferred variant. Without arguments the
+// thumbnail is preferred.
+//
+// Example:
+//
+//	// Thumbnail if generated, otherwise medium, otherwise original
+//	src := file.BestURL(fimage.VariantThumbnail)
+func (f *File) BestURL(preferred ...Variant) string {
+	if len(preferred) == 0 {
+		preferred = []Variant{VariantThumbnail}
+	}
+	for _, v := range preferred {
+		if u, ok := f.VariantURL(v); ok {
+			return u
+		}
+	}
+
+	last := preferred[len(preferred)-1]
+	fallback := false
+	for _, v := range variantChain {
+		if v == last {
+			fallback = true
+		}
+		if !fallback {
+			continue
+		}
+		if u, ok := f.VariantURL(v); ok {
+			return u
+		}
+	}
+	return f.URL
+}
+
+// GenerateVariants asks the server to (re)generate the medium and thumbnail
+// variants of a file, e.g. for files uploaded with SingleFileOnly. Variants
+// are generated in the background.
+//
+// Example:
+//
+//	_, err := client.Files.GenerateVariants(ctx, 123)
+func (s *FilesService) GenerateVariants(ctx context.Context, fileID int64, callOpts ...CallOption) (*MessageResponse, error) {
+	ctx = s.client.withCallOptions(ctx, ServiceFiles, callOpts)
+
+	path := fmt.Sprintf("/api/files/%d/variants", fileID)
+
+	var resp MessageResponse
+	if err := s.client.request(ctx, http.MethodPost, path, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// BestURL is like File.BestURL but also triggers variant generation when
+// the first preferred variant is missing, so later calls get the preferred
+// size. The fallback URL is returned even if triggering generation fails.
+//
+// Example:
+//
+//	src, err := client.Files.BestURL(ctx, file, fimage.VariantMedium)
+//	if err != nil {
+//	    log.Printf("variant generation not triggered: %v", err)
+//	}
+func (s *FilesService) BestURL(ctx context.Context, file *File, preferred ...Variant) (string, error) {
+	best := file.BestURL(preferred...)
+
+	want := VariantThumbnail
+	if len(preferred) > 0 {
+		want = preferred[0]
+	}
+	if _, ok := file.VariantURL(want); ok || want == VariantOriginal {
+		return best, nil
+	}
+
+	if _, err := s.GenerateVariants(ctx, file.ID); err != nil {
+		return best, err
+	}
+	return best, nil
+}
@@ -0,0 +1,283 @@
+// Package visualtest stores screenshot baselines in F-Image and checks new
+// screenshots against them, turning an F-Image account into a CI screenshot
+// store for visual regression tests.
+//
+// Baselines live in a dedicated album and are identified by name through
+// custom file attributes, so any number of test suites can share an account.
+package visualtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// DefaultAlbumName is the album baselines are stored in unless WithAlbumName
+// is used.
+const DefaultAlbumName = "Visual Baselines"
+
+// Attribute keys used to identify stored screenshots.
+const (
+	// NameAttribute holds the baseline name.
+	NameAttribute = "visualtest.name"
+
+	// RoleAttribute is "baseline" for baselines and "candidate" for
+	// screenshots kept after a failed check.
+	RoleAttribute = "visualtest.role"
+)
+
+const (
+	roleBaseline  = "baseline"
+	roleCandidate = "candidate"
+)
+
+// ErrNoBaseline is returned by Check when no baseline has been stored under
+// the given name.
+var ErrNoBaseline = errors.New("visualtest: no baseline stored")
+
+// Store checks screenshots against baselines kept in an F-Image album.
+// A Store is safe for concurrent use.
+type Store struct {
+	client    *fimage.Client
+	albumName string
+
+	mu      sync.Mutex
+	albumID int64
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithAlbumName sets the album baselines are stored in.
+func WithAlbumName(name string) Option {
+	return func(s *Store) {
+		s.albumName = name
+	}
+}
+
+// New creates a Store that uses client for all API calls.
+//
+// Example:
+//
+//	store := visualtest.New(client)
+//	result, err := store.Check(ctx, "checkout/summary", screenshot, 0.1)
+func New(client *fimage.Client, opts ...Option) *Store {
+	s := &Store{client: client, albumName: DefaultAlbumName}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Result is the outcome of a Check.
+type Result struct {
+	// Name is the baseline name.
+	Name string
+
+	// BaselineID is the file ID of the baseline.
+	BaselineID int64
+
+	// CandidateID is the file ID of the checked screenshot. It is only kept
+	// on the server when the check fails.
+	CandidateID int64
+
+	// Threshold is the maximum allowed pixel difference percentage.
+	Threshold float64
+
+	// Comparison is the server-side comparison result.
+	Comparison *fimage.ComparisonResult
+
+	// Passed reports whether the difference is within the threshold.
+	Passed bool
+}
+
+// String returns a one-line report suitable for test logs.
+func (r *Result) String() string {
+	status := "ok"
+	if !r.Passed {
+		status = "FAIL"
+	}
+	report := fmt.Sprintf("%s %s: %.2f%% pixels differ (threshold %.2f%%), similarity %.4f",
+		status, r.Name, r.Comparison.DiffPercent, r.Threshold, r.Comparison.Similarity)
+	if !r.Passed && r.Comparison.DiffURL != nil {
+		report += ", diff: " + *r.Comparison.DiffURL
+	}
+	return report
+}
+
+// Baseline stores image as the baseline for name, replacing any previous
+// baseline with the same name.
+//
+// Example:
+//
+//	if os.Getenv("UPDATE_BASELINES") != "" {
+//	    _, err := store.Baseline(ctx, "checkout/summary", screenshot)
+//	}
+func (s *Store) Baseline(ctx context.Context, name string, image io.Reader) (*fimage.UploadData, error) {
+	previous, err := s.find(ctx, name, roleBaseline)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.upload(ctx, name, image, roleBaseline)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range previous {
+		if _, err := s.client.Files.Delete(ctx, file.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete previous baseline %d: %w", file.ID, err)
+		}
+	}
+
+	return data, nil
+}
+
+// Check uploads image, compares it with the baseline stored under name, and
+// reports whether the percentage of differing pixels is at most threshold
+// (0-100). Passing screenshots are deleted; failing ones are kept in the
+// baseline album for review. Check returns ErrNoBaseline when no baseline
+// exists yet.
+//
+// Example:
+//
+//	result, err := store.Check(ctx, "checkout/summary", screenshot, 0.1)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	if !result.Passed {
+//	    t.Error(result)
+//	}
+func (s *Store) Check(ctx context.Context, name string, image io.Reader, threshold float64) (*Result, error) {
+	if threshold < 0 || threshold > 100 {
+		return nil, fmt.Errorf("threshold %g must be between 0 and 100", threshold)
+	}
+
+	baselines, err := s.find(ctx, name, roleBaseline)
+	if err != nil {
+		return nil, err
+	}
+	if len(baselines) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoBaseline, name)
+	}
+	baseline := baselines[0]
+
+	candidate, err := s.upload(ctx, name, image, roleCandidate)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison, err := s.client.Files.Compare(ctx, baseline.ID, candidate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Name:        name,
+		BaselineID:  baseline.ID,
+		CandidateID: candidate.ID,
+		Threshold:   threshold,
+		Comparison:  comparison,
+		Passed:      comparison.DiffPercent <= threshold,
+	}
+
+	if result.Passed {
+		if _, err := s.client.Files.Delete(ctx, candidate.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete passing screenshot %d: %w", candidate.ID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// upload stores image in the baseline album and tags it with name and role.
+func (s *Store) upload(ctx context.Context, name string, image io.Reader, role string) (*fimage.UploadData, error) {
+	albumID, err := s.album(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Files.Upload(ctx, image, &fimage.UploadOptions{
+		Filename: strings.ReplaceAll(name, "/", "_") + ".png",
+		AlbumID:  &albumID,
+		// Album watermarks or stripping would alter the pixels being compared.
+		IgnoreAlbumDefaults: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s screenshot: %w", role, err)
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("failed to upload %s screenshot: empty response", role)
+	}
+
+	_, err = s.client.Files.SetAttributes(ctx, resp.Data.ID, map[string]string{
+		NameAttribute: name,
+		RoleAttribute: role,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to label %s screenshot: %w", role, err)
+	}
+
+	return resp.Data, nil
+}
+
+// find returns the files stored under name with the given role, newest first.
+func (s *Store) find(ctx context.Context, name, role string) ([]fimage.File, error) {
+	albumID, err := s.album(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Files.SearchByAttribute(ctx, NameAttribute, name, &fimage.AttributeSearchOptions{
+		AlbumID: &albumID,
+		Sort:    fimage.SortCreatedDesc,
+		Limit:   100,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up %s: %w", name, err)
+	}
+
+	var files []fimage.File
+	for _, file := range resp.Files {
+		if file.Attributes[RoleAttribute] == role {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// album returns the ID of the baseline album, creating it on first use.
+func (s *Store) album(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.albumID != 0 {
+		return s.albumID, nil
+	}
+
+	albums, err := s.client.Albums.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list albums: %w", err)
+	}
+	for _, album := range albums {
+		if album.Name == s.albumName {
+			s.albumID = album.ID
+			return s.albumID, nil
+		}
+	}
+
+	album, err := s.client.Albums.Create(ctx, &fimage.CreateAlbumOptions{
+		Name:        s.albumName,
+		Description: "Screenshot baselines for visual regression tests",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create baseline album: %w", err)
+	}
+	s.albumID = album.ID
+	return s.albumID, nil
+}
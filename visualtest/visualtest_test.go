@@ -0,0 +1,97 @@
+package visualtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+func TestCheckComparesAgainstBaselineAndDeletesPassingScreenshot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		deleted []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/albums":
+			_, _ = w.Write([]byte(`{"albums":[{"id":7,"name":"Visual Baselines"}]}`))
+		case r.URL.Path == "/api/files/attributes/search":
+			if r.URL.Query().Get("album_id") != "7" || r.URL.Query().Get("value") != "home" {
+				t.Errorf("unexpected search query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"files":[
+				{"id":3,"attributes":{"visualtest.name":"home","visualtest.role":"candidate"}},
+				{"id":2,"attributes":{"visualtest.name":"home","visualtest.role":"baseline"}}
+			]}`))
+		case r.URL.Path == "/api/files/upload":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("ParseMultipartForm: %v", err)
+			}
+			if r.FormValue("album_id") != "7" {
+				t.Errorf("unexpected album_id: %q", r.FormValue("album_id"))
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":9}}`))
+		case r.URL.Path == "/api/files/9/attributes":
+			_, _ = w.Write([]byte(`{"attributes":{"visualtest.name":"home","visualtest.role":"candidate"}}`))
+		case r.URL.Path == "/api/files/compare":
+			_, _ = w.Write([]byte(`{"file_id_a":2,"file_id_b":9,"similarity":0.999,"diff_percent":0.05}`))
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, r.URL.Path)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"message":"ok"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := fimage.NewClient("test-token", fimage.WithBaseURL(server.URL), fimage.WithHTTPClient(server.Client()))
+	store := New(client)
+
+	result, err := store.Check(context.Background(), "home", strings.NewReader("png"), 0.1)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !result.Passed || result.BaselineID != 2 || result.CandidateID != 9 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(deleted) != 1 || deleted[0] != "/api/files/9" {
+		t.Fatalf("unexpected deletions: %v", deleted)
+	}
+}
+
+func TestCheckWithoutBaseline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/albums":
+			_, _ = w.Write([]byte(`{"albums":[{"id":7,"name":"Visual Baselines"}]}`))
+		case "/api/files/attributes/search":
+			_, _ = w.Write([]byte(`{"files":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := fimage.NewClient("test-token", fimage.WithBaseURL(server.URL), fimage.WithHTTPClient(server.Client()))
+
+	_, err := New(client).Check(context.Background(), "home", strings.NewReader("png"), 0.1)
+	if !errors.Is(err, ErrNoBaseline) {
+		t.Fatalf("expected ErrNoBaseline, got %v", err)
+	}
+}
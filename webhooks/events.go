@@ -0,0 +1,123 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	fimage "github.com/lpg-it/f-image-go"
+)
+
+// EventType identifies the kind of a webhook event.
+type EventType string
+
+const (
+	// EventFileUploaded is sent when a file has been uploaded and processed.
+	EventFileUploaded EventType = "file.uploaded"
+
+	// EventFileDeleted is sent when a file is moved to the trash or deleted
+	// permanently.
+	EventFileDeleted EventType = "file.deleted"
+
+	// EventShareViewed is sent when a share link is opened.
+	EventShareViewed EventType = "share.viewed"
+
+	// EventQuotaWarning is sent when storage or bandwidth usage crosses a
+	// warning threshold.
+	EventQuotaWarning EventType = "quota.warning"
+)
+
+// Event is a verified webhook delivery.
+type Event struct {
+	// ID is the event ID. Deliveries are retried, so use it to ignore
+	// duplicates.
+	ID string `json:"id"`
+
+	// Type is the event type.
+	Type EventType `json:"type"`
+
+	// CreatedAt is when the event happened.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Data is the raw event payload.
+	Data json.RawMessage `json:"data"`
+
+	// Payload is the decoded Data: a *FileUploaded, *FileDeleted,
+	// *ShareViewed, or *QuotaWarning depending on Type. It is nil for event
+	// types this package does not know yet.
+	Payload interface{} `json:"-"`
+}
+
+// FileUploaded is the payload of an EventFileUploaded event.
+type FileUploaded struct {
+	// File is the uploaded file.
+	File fimage.File `json:"file"`
+}
+
+// FileDeleted is the payload of an EventFileDeleted event.
+type FileDeleted struct {
+	// FileID is the ID of the deleted file.
+	FileID int64 `json:"file_id"`
+
+	// OriginalName is the original file name.
+	OriginalName string `json:"original_name"`
+
+	// Permanent reports whether the file was deleted permanently rather
+	// than moved to the trash.
+	Permanent bool `json:"permanent"`
+}
+
+// ShareViewed is the payload of an EventShareViewed event.
+type ShareViewed struct {
+	// ShareID is the ID of the share link.
+	ShareID int64 `json:"share_id"`
+
+	// Token is the share link token.
+	Token string `json:"token"`
+
+	// ViewCount is the total number of views, including this one.
+	ViewCount int64 `json:"view_count"`
+
+	// Referer is the referring page, if the viewer's browser sent one.
+	Referer string `json:"referer,omitempty"`
+
+	// Country is the viewer's ISO 3166-1 alpha-2 country code, if known.
+	Country string `json:"country,omitempty"`
+}
+
+// QuotaWarning is the payload of an EventQuotaWarning event.
+type QuotaWarning struct {
+	// Resource is the quota that crossed the threshold ("storage" or
+	// "bandwidth").
+	Resource string `json:"resource"`
+
+	// Used is the amount used in bytes.
+	Used int64 `json:"used"`
+
+	// Limit is the quota in bytes.
+	Limit int64 `json:"limit"`
+
+	// Percent is Used as a percentage of Limit.
+	Percent float64 `json:"percent"`
+}
+
+// decodePayload sets e.Payload from e.Data according to e.Type.
+func (e *Event) decodePayload() error {
+	var payload interface{}
+	switch e.Type {
+	case EventFileUploaded:
+		payload = &FileUploaded{}
+	case EventFileDeleted:
+		payload = &FileDeleted{}
+	case EventShareViewed:
+		payload = &ShareViewed{}
+	case EventQuotaWarning:
+		payload = &QuotaWarning{}
+	default:
+		return nil
+	}
+	if err := json.Unmarshal(e.Data, payload); err != nil {
+		return err
+	}
+	e.Payload = payload
+	return nil
+}
@@ -0,0 +1,201 @@
+// Package webhooks verifies and decodes F-Image webhook deliveries.
+//
+// Every delivery is a JSON event signed with the endpoint's secret. The
+// signature header has the form
+//
+//	X-FImage-Signature: t=1700000000,v1=5257a869...
+//
+// where v1 is the hex HMAC-SHA256 of the timestamp, a dot, and the raw
+// request body. During secret rotation the header carries one v1 entry per
+// active secret.
+//
+// Example:
+//
+//	http.Handle("/webhooks/fimage", webhooks.NewHandler(secret,
+//	    func(ctx context.Context, event *webhooks.Event) error {
+//	        switch p := event.Payload.(type) {
+//	        case *webhooks.FileUploaded:
+//	            log.Printf("uploaded %s", p.File.URL)
+//	        case *webhooks.QuotaWarning:
+//	            log.Printf("%s at %.0f%%", p.Resource, p.Percent)
+//	        }
+//	        return nil
+//	    }))
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the request header carrying the delivery signature.
+const SignatureHeader = "X-FImage-Signature"
+
+// DefaultTolerance is the maximum age of a delivery accepted by ParseEvent,
+// which protects against replayed requests.
+const DefaultTolerance = 5 * time.Minute
+
+// MaxPayloadSize is the largest request body NewHandler reads.
+const MaxPayloadSize = 1 << 20
+
+var (
+	// ErrMissingSignature is returned when the signature header is empty
+	// or malformed.
+	ErrMissingSignature = errors.New("webhooks: missing or malformed signature header")
+
+	// ErrInvalidSignature is returned when no signature matches the secret.
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+
+	// ErrExpired is returned when the signature timestamp is outside the
+	// tolerance.
+	ErrExpired = errors.New("webhooks: signature timestamp outside tolerance")
+)
+
+// Sign returns a signature header value for payload signed with secret at
+// time t. It is useful for testing webhook consumers.
+func Sign(payload []byte, secret string, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(computeMAC(payload, secret, ts))
+}
+
+// computeMAC returns the HMAC-SHA256 of ts.payload keyed with secret.
+func computeMAC(payload []byte, secret, ts string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// VerifySignature checks that signatureHeader is a valid signature of
+// payload with secret, made no more than tolerance ago (or in the future).
+// A tolerance of zero or less disables the timestamp check.
+func VerifySignature(payload []byte, signatureHeader, secret string, tolerance time.Duration) error {
+	var ts string
+	var sigs [][]byte
+	for _, part := range strings.Split(signatureHeader, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			if sig, err := hex.DecodeString(value); err == nil {
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return ErrMissingSignature
+	}
+
+	expected := computeMAC(payload, secret, ts)
+	valid := false
+	for _, sig := range sigs {
+		if hmac.Equal(sig, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		age := time.Since(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrExpired
+		}
+	}
+	return nil
+}
+
+// ParseEvent verifies the signature of a webhook delivery and decodes it.
+// payload must be the raw request body and signatureHeader the value of
+// the SignatureHeader header. Deliveries older than DefaultTolerance are
+// rejected.
+//
+// Example:
+//
+//	payload, _ := io.ReadAll(r.Body)
+//	event, err := webhooks.ParseEvent(payload, r.Header.Get(webhooks.SignatureHeader), secret)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusUnauthorized)
+//	    return
+//	}
+//	if deleted, ok := event.Payload.(*webhooks.FileDeleted); ok {
+//	    purgeCache(deleted.FileID)
+//	}
+func ParseEvent(payload []byte, signatureHeader, secret string) (*Event, error) {
+	if err := VerifySignature(payload, signatureHeader, secret, DefaultTolerance); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("webhooks: failed to decode event: %w", err)
+	}
+	if err := event.decodePayload(); err != nil {
+		return nil, fmt.Errorf("webhooks: failed to decode %s payload: %w", event.Type, err)
+	}
+
+	return &event, nil
+}
+
+// HandlerFunc processes a verified event. Returning an error makes the
+// handler respond with 500 so F-Image retries the delivery.
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// NewHandler returns an http.Handler that verifies deliveries signed with
+// secret and passes them to fn. It responds 405 to methods other than POST,
+// 413 to bodies over MaxPayloadSize, 401 to bad signatures, 400 to
+// undecodable events, and 204 once fn succeeds.
+func NewHandler(secret string, fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxPayloadSize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		event, err := ParseEvent(payload, r.Header.Get(SignatureHeader), secret)
+		switch {
+		case errors.Is(err, ErrMissingSignature), errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrExpired):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := fn(r.Context(), event); err != nil {
+			http.Error(w, "failed to process event", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "whsec_test"
+
+func TestParseEventVerifiesSignature(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"id":"evt_1","type":"file.uploaded","created_at":"2024-01-02T03:04:05Z","data":{"file":{"id":42,"url":"https://i.f-image.com/a.jpg"}}}`)
+	signedAt := time.Now()
+
+	event, err := ParseEvent(payload, Sign(payload, testSecret, signedAt), testSecret)
+	if err != nil {
+		t.Fatalf("ParseEvent returned error: %v", err)
+	}
+	uploaded, ok := event.Payload.(*FileUploaded)
+	if !ok || uploaded.File.ID != 42 || event.ID != "evt_1" {
+		t.Fatalf("unexpected event: %+v (payload %#v)", event, event.Payload)
+	}
+
+	rotated := Sign(payload, "old-secret", signedAt) + ",v1=" + strings.Split(Sign(payload, testSecret, signedAt), "v1=")[1]
+	if _, err := ParseEvent(payload, rotated, testSecret); err != nil {
+		t.Fatalf("expected any matching v1 signature to verify, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   error
+	}{
+		{"missing", "", payload, ErrMissingSignature},
+		{"wrong secret", Sign(payload, "other", signedAt), payload, ErrInvalidSignature},
+		{"tampered", Sign(payload, testSecret, signedAt), append([]byte(" "), payload...), ErrInvalidSignature},
+		{"expired", Sign(payload, testSecret, signedAt.Add(-time.Hour)), payload, ErrExpired},
+	}
+	for _, tt := range tests {
+		if _, err := ParseEvent(tt.body, tt.header, testSecret); !errors.Is(err, tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestHandlerDispatchesVerifiedEvents(t *testing.T) {
+	t.Parallel()
+
+	var got *QuotaWarning
+	handler := NewHandler(testSecret, func(ctx context.Context, event *Event) error {
+		got, _ = event.Payload.(*QuotaWarning)
+		if got != nil && got.Percent > 100 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	send := func(body, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+		req.Header.Set(SignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	body := `{"id":"evt_2","type":"quota.warning","data":{"resource":"storage","used":90,"limit":100,"percent":90}}`
+	if code := send(body, Sign([]byte(body), testSecret, time.Now())); code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", code)
+	}
+	if got == nil || got.Resource != "storage" || got.Percent != 90 {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+
+	if code := send(body, Sign([]byte(body), "wrong", time.Now())); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a bad signature, got %d", code)
+	}
+
+	failing := `{"id":"evt_3","type":"quota.warning","data":{"percent":120}}`
+	if code := send(failing, Sign([]byte(failing), testSecret, time.Now())); code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when the handler fails, got %d", code)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hook", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for GET, got %d", rec.Code)
+	}
+}